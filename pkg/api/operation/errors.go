@@ -0,0 +1,12 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operation
+
+import "errors"
+
+// ErrInvalidOperation is returned (wrapped) when an operation request fails parsing or validation.
+var ErrInvalidOperation = errors.New("invalid operation")
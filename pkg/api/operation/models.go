@@ -40,8 +40,14 @@ type AnchoredOperation struct {
 	// TransactionNumber is the transaction number of the transaction this operation was batched within.
 	TransactionNumber uint64 `json:"transactionNumber"`
 
+	// OperationIndex is the position of this operation within the batch (transaction) it was anchored in.
+	OperationIndex uint `json:"operationIndex"`
+
 	// ProtocolGenesisTime is the genesis time of the protocol that was used for this operation.
 	ProtocolGenesisTime uint64 `json:"protocolGenesisTime"`
+
+	// AnchorString is the anchor string of the transaction this operation was batched within.
+	AnchorString string `json:"anchorString,omitempty"`
 }
 
 // Type defines valid values for operation type.
@@ -0,0 +1,12 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+import "errors"
+
+// ErrUnavailable is returned (wrapped) when a protocol version cannot be retrieved from the protocol client.
+var ErrUnavailable = errors.New("protocol unavailable")
@@ -47,8 +47,13 @@ type Protocol struct {
 	Patches []string `json:"patches"`
 	// SignatureAlgorithms contain supported signature algorithms for signed operations (e.g. EdDSA, ES256, ES384, ES512, ES256K).
 	SignatureAlgorithms []string `json:"signatureAlgorithms"`
-	// KeyAlgorithms contain supported key algorithms for signed operations (e.g. secp256k1, P-256, P-384, P-512, Ed25519).
+	// KeyAlgorithms contain supported key algorithms for signed operations (e.g. secp256k1, P-256, P-384, P-521, Ed25519).
+	// The key algorithm of a key without a curve is its key type (e.g. RSA).
 	KeyAlgorithms []string `json:"keyAlgorithms"`
+	// JWKThumbprints enables JWK thumbprints (RFC 7638): commitments are calculated from the thumbprint of the key
+	// (instead of the whole JWK) and the key ID (kid) of signed operations, if present, must be the thumbprint
+	// of the signing key.
+	JWKThumbprints bool `json:"jwkThumbprints"`
 }
 
 // TxnProcessor defines the functions for processing a Sidetree transaction.
@@ -62,6 +67,25 @@ type OperationParser interface {
 	ParseDID(namespace, shortOrLongFormDID string) (string, []byte, error)
 	GetRevealValue(operation []byte) (*jws.JWK, error)
 	GetCommitment(operation []byte) (string, error)
+
+	// ParseAnchoredOperation parses the anchored operation for resolution. The returned operation may be
+	// applied using OperationApplier.ApplyParsed so that the operation doesn't have to be parsed again.
+	ParseAnchoredOperation(op *operation.AnchoredOperation) (*ParsedOperation, error)
+}
+
+// ParsedOperation is an anchored operation which has been parsed by the operation parser of a protocol version.
+type ParsedOperation struct {
+	*operation.AnchoredOperation
+
+	// RevealValue is the reveal value of the operation (nil for create operations).
+	RevealValue *jws.JWK
+
+	// NextCommitment is the next update commitment of an update operation or the next recovery commitment
+	// of a recover operation (empty for other operations).
+	NextCommitment string
+
+	// Model is the version specific model of the parsed operation (used by the operation applier).
+	Model interface{}
 }
 
 // ResolutionModel contains temporary data during document resolution.
@@ -77,6 +101,9 @@ type ResolutionModel struct {
 // OperationApplier applies the given operation to the document.
 type OperationApplier interface {
 	Apply(op *operation.AnchoredOperation, rm *ResolutionModel) (*ResolutionModel, error)
+
+	// ApplyParsed applies an operation which has been parsed using OperationParser.ParseAnchoredOperation.
+	ApplyParsed(op *ParsedOperation, rm *ResolutionModel) (*ResolutionModel, error)
 }
 
 // DocumentComposer applies patches to the given document.
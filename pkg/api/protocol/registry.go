@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/cas"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+)
+
+// CompressionProvider defines the functions for compressing and decompressing batch files.
+type CompressionProvider interface {
+	Compress(alg string, data []byte) ([]byte, error)
+	Decompress(alg string, data []byte) ([]byte, error)
+}
+
+// OperationStore defines the functions for storing and retrieving anchored operations
+// (a subset of api/store.OperationStore).
+type OperationStore interface {
+	store.OperationWriter
+	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
+}
+
+// Providers contains the providers that are shared by all protocol versions.
+type Providers struct {
+	CasClient           cas.Client
+	CompressionProvider CompressionProvider
+	OperationStore      OperationStore
+
+	// DocumentValidator is optional. If not set then the version's default validator is used.
+	DocumentValidator DocumentValidator
+
+	// VerifierRegistry is optional. If not set then the built-in signature verifiers are used.
+	VerifierRegistry *verifier.Registry
+}
+
+// VersionFactory creates a protocol version (i.e. the parser, applier, etc. of a versions package)
+// for the given protocol parameters.
+type VersionFactory interface {
+	Create(p Protocol, providers *Providers) (Version, error)
+}
+
+// Registry contains the version factories of the supported protocol versions.
+type Registry struct {
+	mutex     sync.RWMutex
+	factories map[string]VersionFactory
+}
+
+// NewRegistry returns a new, empty version registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]VersionFactory),
+	}
+}
+
+// Register registers the factory for the given version (e.g. "0.1").
+func (r *Registry) Register(version string, factory VersionFactory) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.factories[version]; ok {
+		return fmt.Errorf("version factory already registered for version [%s]", version)
+	}
+
+	r.factories[version] = factory
+
+	return nil
+}
+
+// CreateVersion creates the given version of the protocol using the registered factory.
+func (r *Registry) CreateVersion(version string, p Protocol, providers *Providers) (Version, error) {
+	r.mutex.RLock()
+	factory, ok := r.factories[version]
+	r.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("version factory not found for version [%s]", version)
+	}
+
+	v, err := factory.Create(p, providers)
+	if err != nil {
+		return nil, fmt.Errorf("create protocol version [%s]: %w", version, err)
+	}
+
+	return v, nil
+}
+
+// Versions returns the registered versions in sorted order.
+func (r *Registry) Versions() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var versions []string
+	for version := range r.factories {
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+
+	return versions
+}
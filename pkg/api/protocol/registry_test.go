@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		r := NewRegistry()
+		require.Empty(t, r.Versions())
+
+		require.NoError(t, r.Register("1.0", &mockFactory{}))
+		require.NoError(t, r.Register("0.1", &mockFactory{}))
+		require.Equal(t, []string{"0.1", "1.0"}, r.Versions())
+
+		providers := &Providers{}
+
+		v, err := r.CreateVersion("1.0", Protocol{GenesisTime: 100}, providers)
+		require.NoError(t, err)
+		require.Equal(t, "1.0", v.Version())
+		require.Equal(t, uint64(100), v.Protocol().GenesisTime)
+	})
+
+	t.Run("error - already registered", func(t *testing.T) {
+		r := NewRegistry()
+
+		require.NoError(t, r.Register("0.1", &mockFactory{}))
+		require.EqualError(t, r.Register("0.1", &mockFactory{}), "version factory already registered for version [0.1]")
+	})
+
+	t.Run("error - not registered", func(t *testing.T) {
+		v, err := NewRegistry().CreateVersion("0.1", Protocol{}, &Providers{})
+		require.EqualError(t, err, "version factory not found for version [0.1]")
+		require.Nil(t, v)
+	})
+
+	t.Run("error - factory error", func(t *testing.T) {
+		r := NewRegistry()
+		require.NoError(t, r.Register("0.1", &mockFactory{err: errors.New("injected factory error")}))
+
+		v, err := r.CreateVersion("0.1", Protocol{}, &Providers{})
+		require.EqualError(t, err, "create protocol version [0.1]: injected factory error")
+		require.Nil(t, v)
+	})
+}
+
+type mockFactory struct {
+	err error
+}
+
+func (f *mockFactory) Create(p Protocol, _ *Providers) (Version, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &mockVersion{p: p}, nil
+}
+
+type baseVersion = Version
+
+// mockVersion only implements the functions required by the tests (the mocks package can't be used
+// since it depends on this package).
+type mockVersion struct {
+	baseVersion
+
+	p Protocol
+}
+
+func (v *mockVersion) Version() string {
+	return "1.0"
+}
+
+func (v *mockVersion) Protocol() Protocol {
+	return v.p
+}
@@ -0,0 +1,165 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+import (
+	"crypto"
+	// register hash functions that may be configured as the protocol hash algorithm.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// ErrInvalidProtocol is returned (wrapped) when the protocol parameters are not valid.
+var ErrInvalidProtocol = errors.New("invalid protocol")
+
+const sha2256 = 18
+
+// supportedHashAlgorithms contains the supported multihash algorithm codes and, for each one,
+// the hash algorithms that it may be paired with.
+var supportedHashAlgorithms = map[uint][]crypto.Hash{
+	sha2256: {crypto.SHA256, crypto.SHA384, crypto.SHA512},
+}
+
+var supportedCompressionAlgorithms = []string{"GZIP"}
+
+var supportedPatches = []string{
+	string(patch.Replace),
+	string(patch.AddPublicKeys),
+	string(patch.RemovePublicKeys),
+	string(patch.AddServiceEndpoints),
+	string(patch.RemoveServiceEndpoints),
+	string(patch.JSONPatch),
+}
+
+// Validate validates the protocol parameters. The returned error wraps ErrInvalidProtocol and
+// describes the first invalid parameter that was found.
+func (p Protocol) Validate() error {
+	if err := p.validateLimits(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidProtocol, err.Error())
+	}
+
+	if err := p.validateAlgorithms(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidProtocol, err.Error())
+	}
+
+	if err := p.validatePatches(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidProtocol, err.Error())
+	}
+
+	return nil
+}
+
+// ValidateVerifiers validates that the given verifier registry is able to verify signatures for each of the
+// protocol's key and signature algorithms, i.e. every key algorithm has a verifier for at least one of the
+// signature algorithms and every signature algorithm has a verifier for at least one of the key algorithms.
+// The returned error wraps ErrInvalidProtocol.
+func (p Protocol) ValidateVerifiers(verifiers *verifier.Registry) error {
+	filtered := verifiers.Filter(p.SignatureAlgorithms, p.KeyAlgorithms)
+
+	for _, alg := range p.KeyAlgorithms {
+		if !filtered.SupportsKeyAlgorithm(alg) {
+			return fmt.Errorf("%w: key algorithm [%s] is not supported by any of the signature algorithms %v",
+				ErrInvalidProtocol, alg, p.SignatureAlgorithms)
+		}
+	}
+
+	for _, alg := range p.SignatureAlgorithms {
+		if !filtered.SupportsSignatureAlgorithm(alg) {
+			return fmt.Errorf("%w: signature algorithm [%s] is not supported for any of the key algorithms %v",
+				ErrInvalidProtocol, alg, p.KeyAlgorithms)
+		}
+	}
+
+	return nil
+}
+
+func (p Protocol) validateLimits() error {
+	limits := []struct {
+		name  string
+		value uint
+	}{
+		{"maxOperationCount", p.MaxOperationCount},
+		{"maxOperationSize", p.MaxOperationSize},
+		{"maxAnchorFileSize", p.MaxAnchorFileSize},
+		{"maxMapFileSize", p.MaxMapFileSize},
+		{"maxChunkFileSize", p.MaxChunkFileSize},
+	}
+
+	for _, l := range limits {
+		if l.value == 0 {
+			return fmt.Errorf("%s must be greater than 0", l.name)
+		}
+	}
+
+	return nil
+}
+
+func (p Protocol) validateAlgorithms() error {
+	hashAlgorithms, ok := supportedHashAlgorithms[p.MultihashAlgorithm]
+	if !ok {
+		return fmt.Errorf("multihash algorithm [%d] is not supported", p.MultihashAlgorithm)
+	}
+
+	if !containsHash(hashAlgorithms, crypto.Hash(p.HashAlgorithm)) {
+		return fmt.Errorf("hash algorithm [%d] is not supported with multihash algorithm [%d]",
+			p.HashAlgorithm, p.MultihashAlgorithm)
+	}
+
+	if !contains(supportedCompressionAlgorithms, p.CompressionAlgorithm) {
+		return fmt.Errorf("compression algorithm [%s] is not supported; supported algorithms: %v",
+			p.CompressionAlgorithm, supportedCompressionAlgorithms)
+	}
+
+	if len(p.KeyAlgorithms) == 0 {
+		return errors.New("at least one key algorithm is required")
+	}
+
+	if len(p.SignatureAlgorithms) == 0 {
+		return errors.New("at least one signature algorithm is required")
+	}
+
+	return nil
+}
+
+func (p Protocol) validatePatches() error {
+	if len(p.Patches) == 0 {
+		return errors.New("at least one patch is required")
+	}
+
+	for _, action := range p.Patches {
+		if !contains(supportedPatches, action) {
+			return fmt.Errorf("patch [%s] is not supported; supported patches: %v", action, supportedPatches)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsHash(values []crypto.Hash, value crypto.Hash) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
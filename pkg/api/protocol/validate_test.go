@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+)
+
+func TestProtocol_Validate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, newValidProtocol().Validate())
+	})
+
+	t.Run("success - sha512 hash algorithm", func(t *testing.T) {
+		p := newValidProtocol()
+		p.HashAlgorithm = 7
+
+		require.NoError(t, p.Validate())
+	})
+
+	t.Run("error - invalid limits", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(p *Protocol)
+			errMsg string
+		}{
+			{"max operation count", func(p *Protocol) { p.MaxOperationCount = 0 }, "maxOperationCount must be greater than 0"},
+			{"max operation size", func(p *Protocol) { p.MaxOperationSize = 0 }, "maxOperationSize must be greater than 0"},
+			{"max anchor file size", func(p *Protocol) { p.MaxAnchorFileSize = 0 }, "maxAnchorFileSize must be greater than 0"},
+			{"max map file size", func(p *Protocol) { p.MaxMapFileSize = 0 }, "maxMapFileSize must be greater than 0"},
+			{"max chunk file size", func(p *Protocol) { p.MaxChunkFileSize = 0 }, "maxChunkFileSize must be greater than 0"},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				p := newValidProtocol()
+				tc.modify(&p)
+
+				err := p.Validate()
+				require.Error(t, err)
+				require.True(t, errors.Is(err, ErrInvalidProtocol))
+				require.EqualError(t, err, "invalid protocol: "+tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("error - unsupported multihash algorithm", func(t *testing.T) {
+		p := newValidProtocol()
+		p.MultihashAlgorithm = 22
+
+		err := p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err, "invalid protocol: multihash algorithm [22] is not supported")
+	})
+
+	t.Run("error - unsupported hash algorithm pairing", func(t *testing.T) {
+		p := newValidProtocol()
+		p.HashAlgorithm = 3 // MD5
+
+		err := p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err, "invalid protocol: hash algorithm [3] is not supported with multihash algorithm [18]")
+	})
+
+	t.Run("error - unsupported compression algorithm", func(t *testing.T) {
+		p := newValidProtocol()
+		p.CompressionAlgorithm = "ZIP"
+
+		err := p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err, "invalid protocol: compression algorithm [ZIP] is not supported; supported algorithms: [GZIP]")
+	})
+
+	t.Run("error - key algorithms", func(t *testing.T) {
+		p := newValidProtocol()
+		p.KeyAlgorithms = nil
+
+		err := p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err, "invalid protocol: at least one key algorithm is required")
+	})
+
+	t.Run("error - missing signature algorithms", func(t *testing.T) {
+		p := newValidProtocol()
+		p.SignatureAlgorithms = nil
+
+		err := p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err, "invalid protocol: at least one signature algorithm is required")
+	})
+
+	t.Run("error - patches", func(t *testing.T) {
+		p := newValidProtocol()
+		p.Patches = nil
+
+		err := p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err, "invalid protocol: at least one patch is required")
+
+		p.Patches = []string{"add-public-keys", "add-keys"}
+
+		err = p.Validate()
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "invalid protocol: patch [add-keys] is not supported")
+	})
+}
+
+func TestProtocol_ValidateVerifiers(t *testing.T) {
+	verifiers := verifier.New(verifier.WithDefaultVerifiers())
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, newValidProtocol().ValidateVerifiers(verifiers))
+	})
+
+	t.Run("success - custom verifier", func(t *testing.T) {
+		p := newValidProtocol()
+		p.SignatureAlgorithms = []string{"PS256"}
+		p.KeyAlgorithms = []string{"RSA"}
+
+		custom := verifier.New(verifier.WithVerifier("PS256", "RSA", "",
+			verifier.VerifierFunc(func(*jws.JWK, []byte, []byte) error { return nil })))
+
+		require.NoError(t, p.ValidateVerifiers(custom))
+	})
+
+	t.Run("error - key algorithm", func(t *testing.T) {
+		p := newValidProtocol()
+		p.KeyAlgorithms = []string{"P-256", "RSA"}
+
+		err := p.ValidateVerifiers(verifiers)
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err,
+			"invalid protocol: key algorithm [RSA] is not supported by any of the signature algorithms [EdDSA ES256]")
+	})
+
+	t.Run("error - signature algorithm", func(t *testing.T) {
+		p := newValidProtocol()
+		p.SignatureAlgorithms = []string{"EdDSA", "ES256", "RS256"}
+
+		err := p.ValidateVerifiers(verifiers)
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.EqualError(t, err,
+			"invalid protocol: signature algorithm [RS256] is not supported for any of the key algorithms [Ed25519 P-256]")
+
+		// ES384 has a verifier but not for any of the protocol's key algorithms
+		p.SignatureAlgorithms = []string{"EdDSA", "ES256", "ES384"}
+
+		err = p.ValidateVerifiers(verifiers)
+		require.True(t, errors.Is(err, ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "signature algorithm [ES384] is not supported")
+	})
+}
+
+func newValidProtocol() Protocol {
+	return Protocol{
+		GenesisTime:          0,
+		MultihashAlgorithm:   18,
+		HashAlgorithm:        5,
+		MaxOperationCount:    10,
+		MaxOperationSize:     2000,
+		CompressionAlgorithm: "GZIP",
+		MaxAnchorFileSize:    1000000,
+		MaxMapFileSize:       1000000,
+		MaxChunkFileSize:     10000000,
+		Patches:              []string{"replace", "add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
+		SignatureAlgorithms:  []string{"EdDSA", "ES256"},
+		KeyAlgorithms:        []string{"Ed25519", "P-256"},
+	}
+}
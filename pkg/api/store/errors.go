@@ -0,0 +1,12 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import "errors"
+
+// ErrInvalidQuery indicates that a query contains invalid criteria (e.g. a malformed cursor).
+var ErrInvalidQuery = errors.New("invalid query")
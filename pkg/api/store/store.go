@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+// OperationWriter stores anchored operations. It is the subset of OperationStore which is used by the observer
+// (transaction processor), so operation store wrappers (e.g. which update an index or publish events whenever
+// operations are stored) implement and wrap an OperationWriter in order to be composable.
+type OperationWriter interface {
+	// Put stores the given anchored operations. Storing an operation which is already stored
+	// (same suffix, transaction time, transaction number and operation index) is a no-op.
+	Put(ops []*operation.AnchoredOperation) error
+}
+
+// OperationStore stores and retrieves anchored operations.
+type OperationStore interface {
+	OperationWriter
+
+	// Get retrieves all anchored operations for the given unique suffix. If there are no operations
+	// for the suffix then the returned error wraps document.ErrNotFound.
+	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
+
+	// DeleteAfter deletes all operations which were anchored after the given transaction time
+	// (e.g. in order to roll back operations from blocks which were orphaned by a ledger fork).
+	DeleteAfter(transactionTime uint64) error
+
+	// Iterate invokes the given function for each of the anchored operations for the given unique suffix
+	// in the order in which they were anchored. Iteration stops if the function returns false.
+	Iterate(uniqueSuffix string, fn func(op *operation.AnchoredOperation) bool) error
+}
+
+// Query contains the criteria for querying anchored operations using the secondary indexes.
+type Query struct {
+	// OperationType restricts the results to operations of the given type. All operation types
+	// are included if empty.
+	OperationType operation.Type
+
+	// AnchoredAfter restricts the results to operations which were anchored after the given transaction time.
+	AnchoredAfter uint64
+
+	// Cursor is the cursor returned by the previous query. The first page is returned if empty.
+	Cursor string
+
+	// Limit is the maximum number of operations to return.
+	Limit int
+}
+
+// QueryResult contains a page of anchored operations (ordered by transaction time).
+type QueryResult struct {
+	// Operations contains the operations in this page.
+	Operations []*operation.AnchoredOperation
+
+	// Cursor is used to retrieve the next page. It is empty if there are no more operations.
+	Cursor string
+}
+
+// OperationIndex queries anchored operations using secondary indexes (operation type,
+// transaction time and anchor string).
+type OperationIndex interface {
+	// Query returns a page of anchored operations which match the given query. If the query contains
+	// invalid criteria then the returned error wraps ErrInvalidQuery.
+	Query(query *Query) (*QueryResult, error)
+
+	// GetByAnchorString returns the anchored operations which were batched in the transaction with
+	// the given anchor string.
+	GetByAnchorString(anchorString string) ([]*operation.AnchoredOperation, error)
+}
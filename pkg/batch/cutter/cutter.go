@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package cutter
 
 import (
+	"errors"
+
 	"github.com/trustbloc/edge-core/pkg/log"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
@@ -70,35 +72,61 @@ func (r *BatchCutter) Add(op *operation.QueuedOperation, protocolGenesisTime uin
 // If force is false then the batch will be cut only if it has reached the max batch size (as specified in the protocol)
 // If force is true then the batch will be cut if there is at least one Data in the batch
 // Note that the operations are removed from the queue when Result.Commit is invoked, otherwise they remain in the queue.
+//
+// The batch is always cut using the current protocol version. Operations that were added to the queue under a different
+// protocol version (i.e. before a protocol upgrade) are revalidated against the current protocol version. Operations that
+// are still valid are included in the batch and operations that are no longer valid are rejected (i.e. they are removed
+// from the queue without being included in a batch).
 func (r *BatchCutter) Cut(force bool) (Result, error) {
+	for {
+		result, rejected, err := r.cut(force)
+		if err != nil || len(result.Operations) > 0 || rejected == 0 {
+			return result, err
+		}
+
+		// All of the operations at the head of the queue were rejected. Remove them and try again.
+		logger.Infof("Removing %d rejected operations from the queue", rejected)
+
+		if _, _, err := r.pendingBatch.Remove(rejected); err != nil {
+			return Result{Pending: result.Pending + rejected}, err
+		}
+	}
+}
+
+func (r *BatchCutter) cut(force bool) (Result, uint, error) {
 	pending := r.pendingBatch.Len()
 
 	currentProtocol, err := r.client.Current()
 	if err != nil {
-		return Result{}, err
+		return Result{}, 0, err
 	}
 
 	maxOperationsPerBatch := currentProtocol.Protocol().MaxOperationCount
 	if !force && pending < maxOperationsPerBatch {
-		return Result{Pending: pending}, nil
+		return Result{Pending: pending}, 0, nil
 	}
 
 	batchSize := min(pending, maxOperationsPerBatch)
 	ops, err := r.pendingBatch.Peek(batchSize)
 	if err != nil {
-		return Result{Pending: pending}, nil
+		return Result{Pending: pending}, 0, nil
 	}
 
-	operations, protocolGenesisTime := getOperationsAtProtocolVersion(ops)
-
-	batchSize = uint(len(operations))
+	// the batch size includes the rejected operations since they also have to be removed from the queue
+	batchSize = uint(len(ops))
 
 	if batchSize == 0 {
-		return Result{Pending: pending}, nil
+		return Result{Pending: pending}, 0, nil
 	}
 
+	operations := getValidOperations(ops, currentProtocol)
+
 	pending -= batchSize
 
+	if len(operations) == 0 {
+		return Result{Pending: pending}, batchSize, nil
+	}
+
 	logger.Infof("Pending Size: %d, MaxOperationsPerBatch: %d, Batch Size: %d", pending, maxOperationsPerBatch, batchSize)
 
 	committer := func() (uint, error) {
@@ -111,27 +139,28 @@ func (r *BatchCutter) Cut(force bool) (Result, error) {
 
 	return Result{
 		Operations:          operations,
-		ProtocolGenesisTime: protocolGenesisTime,
+		ProtocolGenesisTime: currentProtocol.Protocol().GenesisTime,
 		Pending:             pending,
 		Commit:              committer,
-	}, nil
+	}, batchSize - uint(len(operations)), nil
 }
 
-// getOperationsAtProtocolVersion iterates through the operations and returns the operations which are at the same protocol genesis time.
-func getOperationsAtProtocolVersion(opsAtTime []*operation.QueuedOperationAtTime) ([]*operation.QueuedOperation, uint64) {
+// getValidOperations returns the operations which are valid for the given (current) protocol version. Operations which
+// were added to the queue using the same protocol version are valid; all other operations are revalidated.
+func getValidOperations(opsAtTime []*operation.QueuedOperationAtTime, current protocol.Version) []*operation.QueuedOperation {
 	var ops []*operation.QueuedOperation
-	var protocolGenesisTime uint64
 
 	for _, op := range opsAtTime {
-		if protocolGenesisTime == 0 {
-			protocolGenesisTime = op.ProtocolGenesisTime
-		}
+		if op.ProtocolGenesisTime != current.Protocol().GenesisTime {
+			if err := validate(op, current); err != nil {
+				logger.Warnf("Rejecting operation for suffix [%s] since it was added using protocol genesis time [%d] and it is not valid for the current protocol genesis time [%d]: %s",
+					op.UniqueSuffix, op.ProtocolGenesisTime, current.Protocol().GenesisTime, err)
 
-		if op.ProtocolGenesisTime != protocolGenesisTime {
-			// This operation was added using a different transaction time so it can't go into the same batch
-			logger.Infof("Not adding operation since its protocol genesis time [%d] is different from the protocol genesis time [%d] of the existing ops in the batch", op.ProtocolGenesisTime, protocolGenesisTime)
+				continue
+			}
 
-			break
+			logger.Infof("Operation for suffix [%s] was added using protocol genesis time [%d] and is still valid for the current protocol genesis time [%d]",
+				op.UniqueSuffix, op.ProtocolGenesisTime, current.Protocol().GenesisTime)
 		}
 
 		ops = append(ops,
@@ -143,7 +172,18 @@ func getOperationsAtProtocolVersion(opsAtTime []*operation.QueuedOperationAtTime
 		)
 	}
 
-	return ops, protocolGenesisTime
+	return ops
+}
+
+// validate validates the operation against the given protocol version.
+func validate(op *operation.QueuedOperationAtTime, v protocol.Version) error {
+	if len(op.OperationBuffer) > int(v.Protocol().MaxOperationSize) {
+		return errors.New("operation byte size exceeds protocol max operation byte size")
+	}
+
+	_, err := v.OperationParser().Parse(op.Namespace, op.OperationBuffer)
+
+	return err
 }
 
 func min(i, j uint) uint {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/batch/opqueue"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 )
@@ -29,6 +30,7 @@ var (
 func TestBatchCutter(t *testing.T) {
 	c := mocks.NewMockProtocolClient()
 	c.Protocol.MaxOperationCount = 3
+	c.Protocol.GenesisTime = 10
 	c.CurrentVersion.ProtocolReturns(c.Protocol)
 
 	r := New(c, &opqueue.MemQueue{})
@@ -102,33 +104,139 @@ func TestBatchCutter(t *testing.T) {
 	require.Equal(t, uint(2), result.Pending)
 	require.Nil(t, result.Commit)
 
-	l, err = r.Add(operation5, 20)
+	l, err = r.Add(operation5, 10)
 	require.NoError(t, err)
 	require.Equal(t, uint(3), l)
-	l, err = r.Add(operation6, 20)
+	l, err = r.Add(operation6, 10)
 	require.NoError(t, err)
 	require.Equal(t, uint(4), l)
 
 	result, err = r.Cut(false)
 	require.NoError(t, err)
-	require.Lenf(t, result.Operations, 2, "should have only cut two operations since the third operation in the queue is using a different protocol version")
+	require.Len(t, result.Operations, 3)
 	require.Equal(t, operation3, result.Operations[0])
 	require.Equal(t, operation4, result.Operations[1])
-	require.Equal(t, uint(2), result.Pending)
+	require.Equal(t, operation5, result.Operations[2])
+	require.Equal(t, uint(1), result.Pending)
+	require.Equal(t, uint64(10), result.ProtocolGenesisTime)
 	require.NotNil(t, result.Commit)
 
 	pending, err = result.Commit()
 	require.NoError(t, err)
-	require.Equal(t, uint(2), pending)
+	require.Equal(t, uint(1), pending)
 
 	result, err = r.Cut(true)
 	require.NoError(t, err)
-	require.Len(t, result.Operations, 2)
-	require.Equal(t, operation5, result.Operations[0])
-	require.Equal(t, operation6, result.Operations[1])
+	require.Len(t, result.Operations, 1)
+	require.Equal(t, operation6, result.Operations[0])
 	require.Zero(t, result.Pending)
 
 	pending, err = result.Commit()
 	require.NoError(t, err)
 	require.Zero(t, pending)
 }
+
+func TestBatchCutter_ProtocolUpgrade(t *testing.T) {
+	c := mocks.NewMockProtocolClient()
+
+	v10 := newProtocolVersion(c.Protocol, 10)
+	v20 := newProtocolVersion(c.Protocol, 20)
+
+	// operation2 and operation4 are not valid in the new protocol version
+	v20.OperationParser().(*mocks.OperationParser).ParseStub = func(_ string, buffer []byte) (*operation.Operation, error) {
+		if string(buffer) == string(operation2.OperationBuffer) || string(buffer) == string(operation4.OperationBuffer) {
+			return nil, fmt.Errorf("injected parse error")
+		}
+
+		return &operation.Operation{}, nil
+	}
+
+	c.CurrentVersion = v10
+	c.Versions = []*mocks.ProtocolVersion{v10}
+
+	r := New(c, &opqueue.MemQueue{})
+
+	for _, op := range []*operation.QueuedOperation{operation1, operation2, operation3, operation4} {
+		_, err := r.Add(op, 10)
+		require.NoError(t, err)
+	}
+
+	// upgrade the protocol
+	c.CurrentVersion = v20
+	c.Versions = []*mocks.ProtocolVersion{v10, v20}
+
+	_, err := r.Add(operation5, 20)
+	require.NoError(t, err)
+
+	t.Run("operations added under the previous version are revalidated", func(t *testing.T) {
+		result, err := r.Cut(true)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1, "operation2 should have been rejected")
+		require.Equal(t, operation1, result.Operations[0])
+		require.Equal(t, uint64(20), result.ProtocolGenesisTime)
+		require.Equal(t, uint(3), result.Pending)
+
+		pending, err := result.Commit()
+		require.NoError(t, err)
+		require.Equal(t, uint(3), pending)
+	})
+
+	t.Run("operations added under the current version", func(t *testing.T) {
+		result, err := r.Cut(true)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1, "operation4 should have been rejected")
+		require.Equal(t, operation3, result.Operations[0])
+		require.Equal(t, uint(1), result.Pending)
+
+		pending, err := result.Commit()
+		require.NoError(t, err)
+		require.Equal(t, uint(1), pending)
+
+		result, err = r.Cut(true)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1)
+		require.Equal(t, operation5, result.Operations[0])
+		require.Equal(t, uint64(20), result.ProtocolGenesisTime)
+		require.Zero(t, result.Pending)
+
+		pending, err = result.Commit()
+		require.NoError(t, err)
+		require.Zero(t, pending)
+	})
+
+	t.Run("rejected operations are removed from the queue", func(t *testing.T) {
+		_, err := r.Add(operation2, 10)
+		require.NoError(t, err)
+		_, err = r.Add(operation4, 10)
+		require.NoError(t, err)
+		_, err = r.Add(operation6, 20)
+		require.NoError(t, err)
+
+		result, err := r.Cut(true)
+		require.NoError(t, err)
+		require.Len(t, result.Operations, 1)
+		require.Equal(t, operation6, result.Operations[0])
+		require.Zero(t, result.Pending)
+
+		pending, err := result.Commit()
+		require.NoError(t, err)
+		require.Zero(t, pending)
+
+		_, err = r.Add(operation2, 10)
+		require.NoError(t, err)
+
+		result, err = r.Cut(true)
+		require.NoError(t, err)
+		require.Empty(t, result.Operations)
+		require.Zero(t, result.Pending)
+		require.Nil(t, result.Commit)
+		require.Zero(t, r.pendingBatch.Len())
+	})
+}
+
+func newProtocolVersion(p protocol.Protocol, genesisTime uint64) *mocks.ProtocolVersion {
+	p.GenesisTime = genesisTime
+	p.MaxOperationCount = 2
+
+	return mocks.GetProtocolVersion(p)
+}
@@ -532,12 +532,22 @@ func (m *mockContext) OperationQueue() cutter.OperationQueue {
 
 func newMockProtocolClient() *mocks.MockProtocolClient {
 	pc := mocks.NewMockProtocolClient()
-	parser := operationparser.New(pc.Protocol)
+	parser, err := operationparser.New(pc.Protocol)
+	if err != nil {
+		panic(err)
+	}
+
 	dc := doccomposer.New()
-	oa := operationapplier.New(pc.Protocol, parser, dc)
+	oa, err := operationapplier.New(pc.Protocol, parser, dc)
+	if err != nil {
+		panic(err)
+	}
 
 	pc.CasClient = mocks.NewMockCasClient(nil)
-	th := txnprovider.NewOperationHandler(pc.Protocol, pc.CasClient, compression.New(compression.WithDefaultAlgorithms()), parser)
+	th, err := txnprovider.NewOperationHandler(pc.Protocol, pc.CasClient, compression.New(compression.WithDefaultAlgorithms()), parser)
+	if err != nil {
+		panic(err)
+	}
 
 	pv := pc.CurrentVersion
 	pv.OperationParserReturns(parser)
@@ -8,9 +8,11 @@ package commitment
 
 import (
 	"crypto"
+	"errors"
 
 	"github.com/trustbloc/edge-core/pkg/log"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/hashing"
@@ -28,6 +30,39 @@ func Calculate(jwk *jws.JWK, multihashCode uint, hash crypto.Hash) (string, erro
 
 	logger.Debugf("calculating commitment from JWK: %s", string(data))
 
+	return calculate(data, multihashCode, hash)
+}
+
+// CalculateFromThumbprint will calculate commitment hash from the JWK thumbprint (RFC 7638) input of the key,
+// i.e. only the required members of the key are committed to. With SHA-256 the inner hash of the commitment
+// is the JWK thumbprint of the key. For an EC key without extraneous members the commitment is the same as
+// the commitment from the whole JWK.
+func CalculateFromThumbprint(jwk *jws.JWK, multihashCode uint, hash crypto.Hash) (string, error) {
+	if jwk == nil {
+		return "", errors.New("missing JWK")
+	}
+
+	data, err := jwk.ThumbprintInput()
+	if err != nil {
+		return "", err
+	}
+
+	logger.Debugf("calculating commitment from JWK thumbprint input: %s", string(data))
+
+	return calculate(data, multihashCode, hash)
+}
+
+// CalculateForProtocol will calculate commitment hash from JWK using the multihash and hash algorithms
+// of the protocol. The commitment is calculated from the JWK thumbprint if the protocol enables JWK thumbprints.
+func CalculateForProtocol(jwk *jws.JWK, p protocol.Protocol) (string, error) {
+	if p.JWKThumbprints {
+		return CalculateFromThumbprint(jwk, p.MultihashAlgorithm, crypto.Hash(p.HashAlgorithm))
+	}
+
+	return Calculate(jwk, p.MultihashAlgorithm, crypto.Hash(p.HashAlgorithm))
+}
+
+func calculate(data []byte, multihashCode uint, hash crypto.Hash) (string, error) {
 	dataHash, err := hashing.GetHash(hash, data)
 	if err != nil {
 		return "", err
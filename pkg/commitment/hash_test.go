@@ -8,11 +8,14 @@ package commitment
 
 import (
 	"crypto"
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 )
 
@@ -55,6 +58,39 @@ func TestCalculate(t *testing.T) {
 		require.Contains(t, err.Error(), "Expected '{' but got 'n'")
 	})
 
+	t.Run("P-384 and P-521 test vectors", func(t *testing.T) {
+		// expected values were calculated independently: base64url(multihash(SHA-256(SHA-256(JCS(jwk)))))
+		tests := []struct {
+			jwk      *jws.JWK
+			expected string
+		}{
+			{
+				jwk: &jws.JWK{
+					Kty: "EC",
+					Crv: "P-384",
+					X:   "xh-auOSfoVQPKcJe1CAkpRPXb9xG0a-ZQMiZq7BNDHhujZ68vRInavOvN2NPXzVv",
+					Y:   "IRYp2_YgYkSWp11EDcDmP_3xYn0bNVqG2QFFj-MGOWZ8DEKQ8hdkk_0qx5i8I2H9",
+				},
+				expected: "EiDJbR-CX7ril6zFaUa58ySzCAaK9YDSecHzzKOHomHHEg",
+			},
+			{
+				jwk: &jws.JWK{
+					Kty: "EC",
+					Crv: "P-521",
+					X:   "AekpBQ8ST8a8VcfVOTNl353vSrDCLLJXmPk06wTjxrrjcBpXp5EOnYG_NjFZ6OvLFV1jSfS9tsz4qUxcWceqwQGk",
+					Y:   "ADSmRA43Z1DSNx_RvcLI87cdL07l6jQyyBXMoxVg_l2Th-x3S1WDhjDly79ajL4Kkd0AZMaZmh9ubmf63e3kyMj2",
+				},
+				expected: "EiBcFG-pXQfes-E3fWnHylIcqFp9Hph233POZa64BLCGgQ",
+			},
+		}
+
+		for _, tc := range tests {
+			commitment, err := Calculate(tc.jwk, sha2_256, crypto.SHA256)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, commitment, tc.jwk.Crv)
+		}
+	})
+
 	t.Run("interop test", func(t *testing.T) {
 		jwk := &jws.JWK{
 			Kty: "EC",
@@ -70,3 +106,84 @@ func TestCalculate(t *testing.T) {
 		require.Equal(t, string(canonicalized), expected)
 	})
 }
+
+func TestCalculateFromThumbprint(t *testing.T) {
+	// Test vector from RFC 8037 (Appendix A.3)
+	jwk := &jws.JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}
+
+	thumbprint, err := base64.RawURLEncoding.DecodeString("kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k")
+	require.NoError(t, err)
+
+	multihash, err := docutil.ComputeMultihash(sha2_256, thumbprint)
+	require.NoError(t, err)
+
+	expected := docutil.EncodeToString(multihash)
+
+	t.Run("success", func(t *testing.T) {
+		commitment, err := CalculateFromThumbprint(jwk, sha2_256, crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, expected, commitment)
+
+		// the commitment from the whole JWK is different since it includes "y":""
+		jwkCommitment, err := Calculate(jwk, sha2_256, crypto.SHA256)
+		require.NoError(t, err)
+		require.NotEqual(t, expected, jwkCommitment)
+	})
+
+	t.Run("success - extraneous members are ignored", func(t *testing.T) {
+		commitment, err := CalculateFromThumbprint(&jws.JWK{Kty: jwk.Kty, Crv: jwk.Crv, X: jwk.X, Y: "y"}, sha2_256, crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, expected, commitment)
+	})
+
+	t.Run("error - missing JWK", func(t *testing.T) {
+		commitment, err := CalculateFromThumbprint(nil, sha2_256, crypto.SHA256)
+		require.EqualError(t, err, "missing JWK")
+		require.Empty(t, commitment)
+	})
+
+	t.Run("error - unsupported key type", func(t *testing.T) {
+		commitment, err := CalculateFromThumbprint(&jws.JWK{Kty: "oct"}, sha2_256, crypto.SHA256)
+		require.EqualError(t, err, "JWK thumbprint not supported for key type [oct]")
+		require.Empty(t, commitment)
+	})
+
+	t.Run("error - hash not supported", func(t *testing.T) {
+		commitment, err := CalculateFromThumbprint(jwk, sha2_256, 55)
+		require.EqualError(t, err, "hash function not available for: 55")
+		require.Empty(t, commitment)
+	})
+}
+
+func TestCalculateForProtocol(t *testing.T) {
+	jwk := &jws.JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}
+
+	p := protocol.Protocol{
+		MultihashAlgorithm: sha2_256,
+		HashAlgorithm:      uint(crypto.SHA256),
+	}
+
+	expected, err := Calculate(jwk, sha2_256, crypto.SHA256)
+	require.NoError(t, err)
+
+	commitment, err := CalculateForProtocol(jwk, p)
+	require.NoError(t, err)
+	require.Equal(t, expected, commitment)
+
+	p.JWKThumbprints = true
+
+	expected, err = CalculateFromThumbprint(jwk, sha2_256, crypto.SHA256)
+	require.NoError(t, err)
+
+	commitment, err = CalculateForProtocol(jwk, p)
+	require.NoError(t, err)
+	require.Equal(t, expected, commitment)
+}
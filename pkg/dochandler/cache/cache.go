@@ -0,0 +1,275 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cache provides a caching layer around the operation processor which caches resolution results
+// per unique suffix.
+//
+// Cached entries are invalidated when new operations are stored for a suffix. In order for this to happen the
+// operation store which is used by the observer (transaction processor) has to be wrapped using
+// Processor.WrapOperationStore. Cached entries also expire after a configurable TTL as a safety net.
+// The number of cached entries is bounded; the least recently used entry is evicted when the cache is full.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+)
+
+var logger = log.New("sidetree-core-resolution-cache")
+
+const (
+	defaultTTL        = time.Minute
+	defaultMaxEntries = 10000
+)
+
+// OperationProcessor resolves the document based on the unique suffix.
+type OperationProcessor interface {
+	Resolve(uniqueSuffix string) (*document.ResolutionResult, error)
+}
+
+// Option is a caching processor option.
+type Option func(opts *Processor)
+
+// WithTTL sets the time after which a cached resolution result expires.
+func WithTTL(ttl time.Duration) Option {
+	return func(opts *Processor) {
+		opts.ttl = ttl
+	}
+}
+
+// WithMaxEntries sets the maximum number of cached resolution results (default 10000). The least recently
+// used entry is evicted when the maximum is reached.
+func WithMaxEntries(maxEntries int) Option {
+	return func(opts *Processor) {
+		opts.maxEntries = maxEntries
+	}
+}
+
+// Stats contains cache statistics.
+type Stats struct {
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64
+	Evictions     uint64
+}
+
+type entry struct {
+	uniqueSuffix string
+	result       *document.ResolutionResult
+	expires      time.Time
+}
+
+// Processor is an operation processor which caches the resolution results of the underlying processor.
+type Processor struct {
+	processor  OperationProcessor
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	// lru contains the entries ordered from the most recently used to the least recently used
+	lru *list.List
+	// generation is incremented on every invalidation so that results which were resolved
+	// concurrently with an invalidation are not cached
+	generation uint64
+
+	hits          uint64
+	misses        uint64
+	invalidations uint64
+	evictions     uint64
+}
+
+// New returns a new caching processor which wraps the given processor.
+func New(processor OperationProcessor, opts ...Option) *Processor {
+	p := &Processor{
+		processor:  processor,
+		ttl:        defaultTTL,
+		maxEntries: defaultMaxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Resolve returns the cached resolution result for the given unique suffix. If the result is not cached (or has
+// expired) then the document is resolved using the underlying processor and the result is cached.
+// Errors are not cached.
+func (p *Processor) Resolve(uniqueSuffix string) (*document.ResolutionResult, error) {
+	result, generation, ok := p.get(uniqueSuffix)
+	if ok {
+		atomic.AddUint64(&p.hits, 1)
+
+		return copyResult(result)
+	}
+
+	atomic.AddUint64(&p.misses, 1)
+
+	result, err := p.processor.Resolve(uniqueSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	p.put(uniqueSuffix, result, generation)
+
+	return copyResult(result)
+}
+
+// Invalidate removes the cached resolution results for the given unique suffixes.
+func (p *Processor) Invalidate(uniqueSuffixes ...string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.generation++
+
+	for _, suffix := range uniqueSuffixes {
+		if elem, ok := p.entries[suffix]; ok {
+			logger.Debugf("Invalidating cached resolution result for suffix [%s]", suffix)
+
+			p.remove(elem)
+
+			atomic.AddUint64(&p.invalidations, 1)
+		}
+	}
+}
+
+// Stats returns the cache statistics.
+func (p *Processor) Stats() Stats {
+	return Stats{
+		Hits:          atomic.LoadUint64(&p.hits),
+		Misses:        atomic.LoadUint64(&p.misses),
+		Invalidations: atomic.LoadUint64(&p.invalidations),
+		Evictions:     atomic.LoadUint64(&p.evictions),
+	}
+}
+
+// WrapOperationStore returns an operation store which stores operations using the given store and then
+// invalidates the cached resolution results for the suffixes of the stored operations.
+func (p *Processor) WrapOperationStore(opStore store.OperationWriter) store.OperationWriter {
+	return &invalidatingStore{
+		OperationWriter: opStore,
+		cache:           p,
+	}
+}
+
+func (p *Processor) get(uniqueSuffix string) (*document.ResolutionResult, uint64, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	elem, ok := p.entries[uniqueSuffix]
+	if !ok {
+		return nil, p.generation, false
+	}
+
+	e := elem.Value.(*entry)
+
+	if !p.now().Before(e.expires) {
+		p.remove(elem)
+
+		return nil, p.generation, false
+	}
+
+	p.lru.MoveToFront(elem)
+
+	return e.result, p.generation, true
+}
+
+func (p *Processor) put(uniqueSuffix string, result *document.ResolutionResult, generation uint64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if generation != p.generation {
+		logger.Debugf("Not caching resolution result for suffix [%s] since the cache was invalidated during resolution", uniqueSuffix)
+
+		return
+	}
+
+	e := &entry{
+		uniqueSuffix: uniqueSuffix,
+		result:       result,
+		expires:      p.now().Add(p.ttl),
+	}
+
+	if elem, ok := p.entries[uniqueSuffix]; ok {
+		elem.Value = e
+		p.lru.MoveToFront(elem)
+
+		return
+	}
+
+	p.entries[uniqueSuffix] = p.lru.PushFront(e)
+
+	for p.lru.Len() > p.maxEntries {
+		p.evict()
+	}
+}
+
+// evict removes the least recently used entry. The caller must hold the lock.
+func (p *Processor) evict() {
+	elem := p.lru.Back()
+
+	logger.Debugf("Evicting cached resolution result for suffix [%s]", elem.Value.(*entry).uniqueSuffix)
+
+	p.remove(elem)
+
+	atomic.AddUint64(&p.evictions, 1)
+}
+
+// remove removes the given entry. The caller must hold the lock.
+func (p *Processor) remove(elem *list.Element) {
+	p.lru.Remove(elem)
+	delete(p.entries, elem.Value.(*entry).uniqueSuffix)
+}
+
+type invalidatingStore struct {
+	store.OperationWriter
+	cache *Processor
+}
+
+// Put stores the operations and invalidates the cached resolution results for the suffixes of the operations.
+func (s *invalidatingStore) Put(ops []*operation.AnchoredOperation) error {
+	err := s.OperationWriter.Put(ops)
+
+	// invalidate even if there was an error since some of the operations may have been stored
+	suffixes := make([]string, len(ops))
+	for i, op := range ops {
+		suffixes[i] = op.UniqueSuffix
+	}
+
+	s.cache.Invalidate(suffixes...)
+
+	return err
+}
+
+// copyResult returns a deep copy of the resolution result since callers may modify the returned document.
+func copyResult(result *document.ResolutionResult) (*document.ResolutionResult, error) {
+	doc, err := docutil.DeepCopyMap(result.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document.ResolutionResult{
+		Context:        result.Context,
+		Document:       doc,
+		MethodMetadata: result.MethodMetadata,
+	}, nil
+}
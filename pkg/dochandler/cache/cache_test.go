@@ -0,0 +1,295 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const suffix = "suffix"
+
+func TestProcessor_Resolve(t *testing.T) {
+	t.Run("success - cache hit", func(t *testing.T) {
+		op := newMockProcessor()
+		p := New(op)
+
+		result, err := p.Resolve(suffix)
+		require.NoError(t, err)
+		require.Equal(t, suffix, result.Document.ID())
+
+		// modifying the returned document must not affect the cached document
+		result.Document["id"] = "modified"
+
+		result, err = p.Resolve(suffix)
+		require.NoError(t, err)
+		require.Equal(t, suffix, result.Document.ID())
+
+		require.Equal(t, 1, op.count(suffix))
+		require.Equal(t, Stats{Hits: 1, Misses: 1}, p.Stats())
+	})
+
+	t.Run("error - not cached", func(t *testing.T) {
+		op := newMockProcessor()
+		op.err = errors.New("injected resolve error")
+
+		p := New(op)
+
+		for i := 0; i < 2; i++ {
+			result, err := p.Resolve(suffix)
+			require.EqualError(t, err, op.err.Error())
+			require.Nil(t, result)
+		}
+
+		require.Equal(t, 2, op.count(suffix))
+		require.Equal(t, Stats{Misses: 2}, p.Stats())
+	})
+
+	t.Run("expired entry", func(t *testing.T) {
+		op := newMockProcessor()
+		p := New(op, WithTTL(time.Second))
+
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		_, err := p.Resolve(suffix)
+		require.NoError(t, err)
+
+		_, err = p.Resolve(suffix)
+		require.NoError(t, err)
+		require.Equal(t, 1, op.count(suffix))
+
+		now = now.Add(time.Second)
+
+		_, err = p.Resolve(suffix)
+		require.NoError(t, err)
+		require.Equal(t, 2, op.count(suffix))
+		require.Equal(t, Stats{Hits: 1, Misses: 2}, p.Stats())
+	})
+
+	t.Run("expired entry is purged", func(t *testing.T) {
+		op := newMockProcessor()
+		p := New(op, WithTTL(time.Second))
+
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		_, err := p.Resolve(suffix)
+		require.NoError(t, err)
+
+		now = now.Add(time.Second)
+
+		_, _, ok := p.get(suffix)
+		require.False(t, ok)
+		require.Empty(t, p.entries)
+		require.Zero(t, p.lru.Len())
+	})
+
+	t.Run("nested values are copied", func(t *testing.T) {
+		op := newMockProcessor()
+		op.doc = document.Document{
+			"id":         suffix,
+			"publicKey":  []interface{}{map[string]interface{}{"id": "key1"}},
+			"controller": []string{"controller1"},
+			"service":    document.Document{"id": "service1"},
+		}
+
+		p := New(op)
+
+		result, err := p.Resolve(suffix)
+		require.NoError(t, err)
+
+		result.Document["publicKey"].([]interface{})[0].(map[string]interface{})["id"] = "modified"
+		result.Document["controller"].([]interface{})[0] = "modified"
+		result.Document["service"].(map[string]interface{})["id"] = "modified"
+
+		result, err = p.Resolve(suffix)
+		require.NoError(t, err)
+		require.Equal(t, "key1", result.Document["publicKey"].([]interface{})[0].(map[string]interface{})["id"])
+		require.Equal(t, []interface{}{"controller1"}, result.Document["controller"])
+		require.Equal(t, "service1", result.Document["service"].(map[string]interface{})["id"])
+		require.Equal(t, 1, op.count(suffix))
+	})
+
+	t.Run("error - copy result", func(t *testing.T) {
+		op := newMockProcessor()
+		op.doc = document.Document{"id": suffix, "invalid": make(chan int)}
+
+		result, err := New(op).Resolve(suffix)
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestProcessor_MaxEntries(t *testing.T) {
+	op := newMockProcessor()
+	p := New(op, WithMaxEntries(2))
+
+	for _, s := range []string{"suffix1", "suffix2", "suffix1", "suffix3"} {
+		_, err := p.Resolve(s)
+		require.NoError(t, err)
+	}
+
+	// suffix2 was the least recently used entry so it was evicted
+	require.Len(t, p.entries, 2)
+	require.Equal(t, 2, p.lru.Len())
+	require.Equal(t, Stats{Hits: 1, Misses: 3, Evictions: 1}, p.Stats())
+
+	_, err := p.Resolve("suffix1")
+	require.NoError(t, err)
+	require.Equal(t, 1, op.count("suffix1"))
+
+	_, err = p.Resolve("suffix2")
+	require.NoError(t, err)
+	require.Equal(t, 2, op.count("suffix2"))
+
+	// suffix3 was evicted to make room for suffix2
+	_, err = p.Resolve("suffix3")
+	require.NoError(t, err)
+	require.Equal(t, 2, op.count("suffix3"))
+	require.Equal(t, Stats{Hits: 2, Misses: 5, Evictions: 3}, p.Stats())
+}
+
+func TestProcessor_Invalidate(t *testing.T) {
+	op := newMockProcessor()
+	p := New(op)
+
+	_, err := p.Resolve(suffix)
+	require.NoError(t, err)
+
+	_, err = p.Resolve("other")
+	require.NoError(t, err)
+
+	store := p.WrapOperationStore(&mockOperationStore{})
+
+	err = store.Put([]*operation.AnchoredOperation{{UniqueSuffix: suffix, Type: operation.TypeUpdate}})
+	require.NoError(t, err)
+
+	_, err = p.Resolve(suffix)
+	require.NoError(t, err)
+	require.Equal(t, 2, op.count(suffix))
+
+	_, err = p.Resolve("other")
+	require.NoError(t, err)
+	require.Equal(t, 1, op.count("other"))
+
+	require.Equal(t, Stats{Hits: 1, Misses: 3, Invalidations: 1}, p.Stats())
+
+	t.Run("store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+		store := p.WrapOperationStore(&mockOperationStore{err: errExpected})
+
+		err = store.Put([]*operation.AnchoredOperation{{UniqueSuffix: "other", Type: operation.TypeUpdate}})
+		require.EqualError(t, err, errExpected.Error())
+
+		_, err = p.Resolve("other")
+		require.NoError(t, err)
+		require.Equal(t, 2, op.count("other"))
+	})
+}
+
+func TestProcessor_InvalidateDuringResolve(t *testing.T) {
+	op := newMockProcessor()
+	p := New(op)
+
+	// invalidate the suffix while the underlying processor is resolving the document
+	op.onResolve = func() {
+		p.Invalidate(suffix)
+	}
+
+	_, err := p.Resolve(suffix)
+	require.NoError(t, err)
+
+	op.onResolve = nil
+
+	// the result must not have been cached
+	_, err = p.Resolve(suffix)
+	require.NoError(t, err)
+	require.Equal(t, 2, op.count(suffix))
+}
+
+func TestProcessor_Concurrent(t *testing.T) {
+	op := newMockProcessor()
+	p := New(op)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := p.Resolve(suffix)
+			require.NoError(t, err)
+
+			result.Document["id"] = "modified"
+
+			p.Invalidate(suffix)
+		}()
+	}
+
+	wg.Wait()
+
+	stats := p.Stats()
+	require.Equal(t, uint64(20), stats.Hits+stats.Misses)
+}
+
+type mockProcessor struct {
+	mutex     sync.Mutex
+	counts    map[string]int
+	doc       document.Document
+	err       error
+	onResolve func()
+}
+
+func newMockProcessor() *mockProcessor {
+	return &mockProcessor{counts: make(map[string]int)}
+}
+
+func (m *mockProcessor) Resolve(uniqueSuffix string) (*document.ResolutionResult, error) {
+	m.mutex.Lock()
+	m.counts[uniqueSuffix]++
+	m.mutex.Unlock()
+
+	if m.onResolve != nil {
+		m.onResolve()
+	}
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	doc := m.doc
+	if doc == nil {
+		doc = document.Document{"id": uniqueSuffix}
+	}
+
+	return &document.ResolutionResult{Document: doc}, nil
+}
+
+func (m *mockProcessor) count(uniqueSuffix string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.counts[uniqueSuffix]
+}
+
+type mockOperationStore struct {
+	err error
+}
+
+func (m *mockOperationStore) Put(ops []*operation.AnchoredOperation) error {
+	return m.err
+}
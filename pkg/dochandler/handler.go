@@ -16,18 +16,24 @@ SPDX-License-Identifier: Apache-2.0
 // compute ID, after which the resolution is done against the computed ID. If a document cannot be found,
 // the supplied document is used directly to generate and return a resolved document. In this case the supplied document
 // is subject to the same validation as an original document in a create operation.
+//
+// Errors returned by the document handler wrap the sentinel errors defined in the core packages
+// (e.g. document.ErrNotFound, document.ErrInvalidDID, operation.ErrInvalidOperation) so that callers
+// can inspect them using errors.Is.
 package dochandler
 
 import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/trustbloc/edge-core/pkg/log"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docindex"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 )
@@ -37,7 +43,7 @@ var logger = log.New("sidetree-core-dochandler")
 const (
 	keyID = "id"
 
-	badRequest = "bad request"
+	defaultMaxConcurrentResolutions = 10
 )
 
 // DocumentHandler implements document handler.
@@ -48,6 +54,9 @@ type DocumentHandler struct {
 	transformer DocumentTransformer
 	namespace   string
 	aliases     []string // namespace aliases
+
+	maxConcurrentResolutions int
+	index                    DocumentIndex
 }
 
 // OperationProcessor is an interface which resolves the document based on the ID.
@@ -65,16 +74,51 @@ type DocumentTransformer interface {
 	TransformDocument(doc document.Document) (*document.ResolutionResult, error)
 }
 
+// DocumentIndex finds the unique suffixes of the documents which contain a given property value.
+type DocumentIndex interface {
+	Find(property docindex.Property, value string) ([]string, error)
+}
+
+// Option is a document handler option.
+type Option func(opts *DocumentHandler)
+
+// WithMaxConcurrentResolutions sets the maximum number of DIDs that are resolved concurrently
+// during batch resolution (see ResolveDocuments).
+func WithMaxConcurrentResolutions(n int) Option {
+	return func(opts *DocumentHandler) {
+		opts.maxConcurrentResolutions = n
+	}
+}
+
+// WithDocumentIndex sets the document index which is used to find DIDs by property (see FindDIDs).
+// The index is kept up to date by wrapping the observer's operation store (see docindex.Index.WrapOperationStore).
+func WithDocumentIndex(index DocumentIndex) Option {
+	return func(opts *DocumentHandler) {
+		opts.index = index
+	}
+}
+
 // New creates a new requestHandler with the context.
-func New(namespace string, aliases []string, pc protocol.Client, transformer DocumentTransformer, writer BatchWriter, processor OperationProcessor) *DocumentHandler {
-	return &DocumentHandler{
-		protocol:    pc,
-		processor:   processor,
-		writer:      writer,
-		transformer: transformer,
-		namespace:   namespace,
-		aliases:     aliases,
+func New(namespace string, aliases []string, pc protocol.Client, transformer DocumentTransformer, writer BatchWriter, processor OperationProcessor, opts ...Option) *DocumentHandler {
+	dh := &DocumentHandler{
+		protocol:                 pc,
+		processor:                processor,
+		writer:                   writer,
+		transformer:              transformer,
+		namespace:                namespace,
+		aliases:                  aliases,
+		maxConcurrentResolutions: defaultMaxConcurrentResolutions,
 	}
+
+	for _, opt := range opts {
+		opt(dh)
+	}
+
+	if dh.maxConcurrentResolutions < 1 {
+		dh.maxConcurrentResolutions = 1
+	}
+
+	return dh
 }
 
 // Namespace returns the namespace of the document handler.
@@ -82,23 +126,43 @@ func (r *DocumentHandler) Namespace() string {
 	return r.namespace
 }
 
+// FindDIDs returns the DIDs of the documents which contain the given property value
+// (e.g. the JWK thumbprint of one of the public keys).
+func (r *DocumentHandler) FindDIDs(property docindex.Property, value string) ([]string, error) {
+	if r.index == nil {
+		return nil, errors.New("document index is not configured")
+	}
+
+	suffixes, err := r.index.Find(property, value)
+	if err != nil {
+		return nil, err
+	}
+
+	dids := make([]string, len(suffixes))
+	for i, suffix := range suffixes {
+		dids[i] = r.namespace + docutil.NamespaceDelimiter + suffix
+	}
+
+	return dids, nil
+}
+
 // ProcessOperation validates operation and adds it to the batch.
 func (r *DocumentHandler) ProcessOperation(operationBuffer []byte, protocolGenesisTime uint64) (*document.ResolutionResult, error) {
 	pv, err := r.protocol.Get(protocolGenesisTime)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %s", protocol.ErrUnavailable, err.Error())
 	}
 
 	op, err := pv.OperationParser().Parse(r.namespace, operationBuffer)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
 	}
 
 	// perform validation for operation request
 	if err := r.validateOperation(op, pv); err != nil {
 		logger.Warnf("Failed to validate operation: %s", err.Error())
 
-		return nil, err
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
 	}
 
 	// validated operation will be added to the batch
@@ -167,23 +231,23 @@ func (r *DocumentHandler) getCreateResponse(op *operation.Operation, pv protocol
 func (r *DocumentHandler) ResolveDocument(shortOrLongFormDID string) (*document.ResolutionResult, error) {
 	ns, err := r.getNamespace(shortOrLongFormDID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: %s", document.ErrInvalidDID, err.Error())
 	}
 
 	pv, err := r.protocol.Current()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %s", protocol.ErrUnavailable, err.Error())
 	}
 
 	// extract did and optional initial document value
 	shortFormDID, createReq, err := pv.OperationParser().ParseDID(ns, shortOrLongFormDID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: %s", document.ErrInvalidDID, err.Error())
 	}
 
 	uniquePortion, err := getSuffix(ns, shortFormDID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: %s", document.ErrInvalidDID, err.Error())
 	}
 
 	// resolve document from the blockchain
@@ -193,13 +257,81 @@ func (r *DocumentHandler) ResolveDocument(shortOrLongFormDID string) (*document.
 	}
 
 	// if document was not found on the blockchain and initial value has been provided resolve using initial value
-	if createReq != nil && strings.Contains(err.Error(), "not found") {
-		return r.resolveRequestWithInitialState(uniquePortion, shortOrLongFormDID, createReq, pv)
+	if createReq != nil && errors.Is(err, document.ErrNotFound) {
+		return r.resolveWithInitialStateAcrossVersions(uniquePortion, shortOrLongFormDID, createReq, pv)
 	}
 
 	return nil, err
 }
 
+// resolveWithInitialStateAcrossVersions resolves the initial state using the given (current) protocol version.
+// Since an unpublished long-form DID may have been generated under an earlier protocol version (e.g. with
+// a different hash algorithm), previous protocol versions are tried if the initial state is not valid
+// for the current version. The error for the current version is returned if no version accepts the initial state.
+func (r *DocumentHandler) resolveWithInitialStateAcrossVersions(uniqueSuffix, longFormDID string, initialBytes []byte, current protocol.Version) (*document.ResolutionResult, error) {
+	result, currentErr := r.resolveRequestWithInitialState(uniqueSuffix, longFormDID, initialBytes, current)
+	if currentErr == nil || !errors.Is(currentErr, document.ErrInvalidDID) {
+		return result, currentErr
+	}
+
+	pv := current
+
+	for pv.Protocol().GenesisTime > 0 {
+		prev, err := r.protocol.Get(pv.Protocol().GenesisTime - 1)
+		if err != nil {
+			break
+		}
+
+		pv = prev
+
+		result, err = r.resolveRequestWithInitialState(uniqueSuffix, longFormDID, initialBytes, pv)
+		if err == nil {
+			logger.Debugf("Resolved initial state for [%s] using protocol version with genesis time [%d]",
+				uniqueSuffix, pv.Protocol().GenesisTime)
+
+			return result, nil
+		}
+	}
+
+	return nil, currentErr
+}
+
+// ResolveDocuments resolves the given short or long form DIDs concurrently (the number of concurrent resolutions
+// is bounded by the WithMaxConcurrentResolutions option). A resolution is returned for each of the given DIDs
+// in the same order as the request; each resolution contains either the resolution result or the error.
+func (r *DocumentHandler) ResolveDocuments(shortOrLongFormDIDs []string) []*document.DIDResolution {
+	resolutions := make([]*document.DIDResolution, len(shortOrLongFormDIDs))
+
+	semaphore := make(chan struct{}, r.maxConcurrentResolutions)
+
+	var wg sync.WaitGroup
+
+	for i, did := range shortOrLongFormDIDs {
+		wg.Add(1)
+
+		semaphore <- struct{}{}
+
+		go func(i int, did string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			result, err := r.ResolveDocument(did)
+
+			resolutions[i] = &document.DIDResolution{
+				ID:     did,
+				Result: result,
+				Err:    err,
+			}
+		}(i, did)
+	}
+
+	wg.Wait()
+
+	return resolutions
+}
+
 func (r *DocumentHandler) getNamespace(shortOrLongFormDID string) (string, error) {
 	// check namespace
 	if strings.HasPrefix(shortOrLongFormDID, r.namespace+docutil.NamespaceDelimiter) {
@@ -244,16 +376,16 @@ func (r *DocumentHandler) resolveRequestWithID(namespace, uniquePortion string)
 func (r *DocumentHandler) resolveRequestWithInitialState(uniqueSuffix, longFormDID string, initialBytes []byte, pv protocol.Version) (*document.ResolutionResult, error) {
 	// verify size of create request does not exceed the maximum allowed limit
 	if len(initialBytes) > int(pv.Protocol().MaxOperationSize) {
-		return nil, fmt.Errorf("%s: operation byte size exceeds protocol max operation byte size", badRequest)
+		return nil, fmt.Errorf("%w: operation byte size exceeds protocol max operation byte size", document.ErrInvalidDID)
 	}
 
 	op, err := pv.OperationParser().Parse(r.namespace, initialBytes)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: %s", document.ErrInvalidDID, err.Error())
 	}
 
 	if uniqueSuffix != op.UniqueSuffix {
-		return nil, fmt.Errorf("%s: provided did doesn't match did created from initial state", badRequest)
+		return nil, fmt.Errorf("%w: provided did doesn't match did created from initial state", document.ErrInvalidDID)
 	}
 
 	rm, err := r.getCreateResult(op, pv)
@@ -268,7 +400,7 @@ func (r *DocumentHandler) resolveRequestWithInitialState(uniqueSuffix, longFormD
 
 	err = pv.DocumentValidator().IsValidOriginalDocument(docBytes)
 	if err != nil {
-		return nil, fmt.Errorf("%s: validate initial document: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: validate initial document: %s", document.ErrInvalidDID, err.Error())
 	}
 
 	externalResult, err := r.transformToExternalDoc(rm.Doc, longFormDID)
@@ -23,7 +23,9 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/batch/opqueue"
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
 	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/compression"
 	"github.com/trustbloc/sidetree-core-go/pkg/dochandler/transformer/doctransformer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docindex"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
@@ -34,6 +36,7 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprovider"
 )
 
 const (
@@ -84,6 +87,7 @@ func TestDocumentHandler_ProcessOperation_MaxOperationSizeError(t *testing.T) {
 	doc, err := dochandler.ProcessOperation(createOp.OperationBuffer, 0)
 	require.Error(t, err)
 	require.Nil(t, doc)
+	require.True(t, errors.Is(err, operation.ErrInvalidOperation))
 	require.Contains(t, err.Error(), "operation byte size exceeds protocol max operation byte size")
 }
 
@@ -97,7 +101,8 @@ func TestDocumentHandler_ProcessOperation_ProtocolError(t *testing.T) {
 	createOp := getCreateOperation()
 
 	doc, err := dochandler.ProcessOperation(createOp.OperationBuffer, 0)
-	require.EqualError(t, err, pc.Err.Error())
+	require.True(t, errors.Is(err, protocol.ErrUnavailable))
+	require.Contains(t, err.Error(), pc.Err.Error())
 	require.Nil(t, doc)
 }
 
@@ -114,10 +119,10 @@ func TestDocumentHandler_ResolveDocument_DID(t *testing.T) {
 	result, err := dochandler.ResolveDocument(docID)
 	require.NotNil(t, err)
 	require.Nil(t, result)
-	require.Contains(t, err.Error(), "not found")
+	require.True(t, errors.Is(err, document.ErrNotFound))
 
 	// insert document in the store
-	err = store.Put(getAnchoredCreateOperation())
+	err = store.Put([]*operation.AnchoredOperation{getAnchoredCreateOperation()})
 	require.Nil(t, err)
 
 	// scenario: resolved document (success)
@@ -148,6 +153,82 @@ func TestDocumentHandler_ResolveDocument_DID(t *testing.T) {
 	require.Contains(t, err.Error(), "did suffix is empty")
 }
 
+func TestDocumentHandler_ResolveDocuments(t *testing.T) {
+	store := mocks.NewMockOperationStore(nil)
+	dochandler, cleanup := getDocumentHandler(store)
+	require.NotNil(t, dochandler)
+	defer cleanup()
+
+	dochandler.maxConcurrentResolutions = 2
+
+	docID := getCreateOperation().ID
+
+	err := store.Put([]*operation.AnchoredOperation{getAnchoredCreateOperation()})
+	require.Nil(t, err)
+
+	dids := []string{docID, "doc:invalid", namespace + docutil.NamespaceDelimiter + "someID", docID}
+
+	resolutions := dochandler.ResolveDocuments(dids)
+	require.Len(t, resolutions, len(dids))
+
+	for i, resolution := range resolutions {
+		require.Equal(t, dids[i], resolution.ID)
+	}
+
+	require.NoError(t, resolutions[0].Err)
+	require.Equal(t, docID, resolutions[0].Result.Document.ID())
+	require.True(t, errors.Is(resolutions[1].Err, document.ErrInvalidDID))
+	require.Nil(t, resolutions[1].Result)
+	require.True(t, errors.Is(resolutions[2].Err, document.ErrNotFound))
+	require.NoError(t, resolutions[3].Err)
+	require.Equal(t, docID, resolutions[3].Result.Document.ID())
+
+	require.Empty(t, dochandler.ResolveDocuments(nil))
+}
+
+func TestDocumentHandler_WithMaxConcurrentResolutions(t *testing.T) {
+	dh := New(namespace, nil, nil, nil, nil, nil, WithMaxConcurrentResolutions(5))
+	require.Equal(t, 5, dh.maxConcurrentResolutions)
+
+	dh = New(namespace, nil, nil, nil, nil, nil, WithMaxConcurrentResolutions(0))
+	require.Equal(t, 1, dh.maxConcurrentResolutions)
+
+	dh = New(namespace, nil, nil, nil, nil, nil)
+	require.Equal(t, defaultMaxConcurrentResolutions, dh.maxConcurrentResolutions)
+}
+
+func TestDocumentHandler_FindDIDs(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		index := docindex.New()
+		require.NoError(t, index.Update("suffix2", document.Document{
+			document.PublicKeyProperty: []interface{}{map[string]interface{}{"id": "key1"}},
+		}))
+		require.NoError(t, index.Update("suffix1", document.Document{
+			document.PublicKeyProperty: []interface{}{map[string]interface{}{"id": "key1"}},
+		}))
+
+		dh := New(namespace, nil, nil, nil, nil, nil, WithDocumentIndex(index))
+
+		dids, err := dh.FindDIDs(docindex.PropertyKeyID, "key1")
+		require.NoError(t, err)
+		require.Equal(t, []string{namespace + ":suffix1", namespace + ":suffix2"}, dids)
+
+		dids, err = dh.FindDIDs(docindex.PropertyKeyID, "key2")
+		require.NoError(t, err)
+		require.Empty(t, dids)
+
+		_, err = dh.FindDIDs("invalid", "key1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported property")
+	})
+
+	t.Run("index not configured", func(t *testing.T) {
+		dids, err := New(namespace, nil, nil, nil, nil, nil).FindDIDs(docindex.PropertyKeyID, "key1")
+		require.EqualError(t, err, "document index is not configured")
+		require.Nil(t, dids)
+	})
+}
+
 func TestDocumentHandler_ResolveDocument_InitialValue(t *testing.T) {
 	pc := newMockProtocolClient()
 	dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
@@ -176,7 +257,8 @@ func TestDocumentHandler_ResolveDocument_InitialValue(t *testing.T) {
 		result, err := dochandler.ResolveDocument(docID + ":payload")
 		require.NotNil(t, err)
 		require.Nil(t, result)
-		require.Contains(t, err.Error(), "bad request: invalid character")
+		require.True(t, errors.Is(err, document.ErrInvalidDID))
+		require.Contains(t, err.Error(), "invalid DID: invalid character")
 	})
 
 	t.Run("error - did doesn't match the one created by parsing original create request", func(t *testing.T) {
@@ -213,7 +295,8 @@ func TestDocumentHandler_ResolveDocument_InitialValue(t *testing.T) {
 		result, err := dochandlerWithValidator.ResolveDocument(docID + longFormPart)
 		require.Error(t, err)
 		require.Nil(t, result)
-		require.Equal(t, err.Error(), "bad request: validate initial document: test error")
+		require.True(t, errors.Is(err, document.ErrInvalidDID))
+		require.Equal(t, err.Error(), "invalid DID: validate initial document: test error")
 	})
 
 	t.Run("error - protocol error", func(t *testing.T) {
@@ -225,8 +308,53 @@ func TestDocumentHandler_ResolveDocument_InitialValue(t *testing.T) {
 		defer cleanup()
 
 		result, err := dochandler.ResolveDocument(docID + longFormPart)
-		require.EqualError(t, err, pc.Err.Error())
+		require.True(t, errors.Is(err, protocol.ErrUnavailable))
+		require.Contains(t, err.Error(), pc.Err.Error())
+		require.Nil(t, result)
+	})
+}
+
+func TestDocumentHandler_ResolveDocument_InitialValue_ProtocolUpgrade(t *testing.T) {
+	createOp := getCreateOperation()
+
+	createReq, err := canonicalizer.MarshalCanonical(model.CreateRequest{
+		Delta:      createOp.Delta,
+		SuffixData: createOp.SuffixData,
+	})
+	require.NoError(t, err)
+
+	longFormDID := createOp.ID + ":" + docutil.EncodeToString(createReq)
+
+	t.Run("success - initial state created with previous protocol version", func(t *testing.T) {
+		pc := newMockProtocolClientWithUpgrade()
+
+		dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
+		require.NotNil(t, dochandler)
+		defer cleanup()
+
+		result, err := dochandler.ResolveDocument(longFormDID)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, false, result.MethodMetadata.Published)
+	})
+
+	t.Run("error - initial state not valid for any protocol version", func(t *testing.T) {
+		pc := newMockProtocolClientWithUpgrade()
+
+		// initial state exceeds maximum operation size of previous protocol version
+		previous := pc.Versions[0].Protocol()
+		previous.MaxOperationSize = 10
+		pc.Versions[0].ProtocolReturns(previous)
+
+		dochandler, cleanup := getDocumentHandlerWithProtocolClient(mocks.NewMockOperationStore(nil), pc)
+		require.NotNil(t, dochandler)
+		defer cleanup()
+
+		result, err := dochandler.ResolveDocument(longFormDID)
+		require.Error(t, err)
 		require.Nil(t, result)
+		require.True(t, errors.Is(err, document.ErrInvalidDID))
+		require.Contains(t, err.Error(), "add-public-keys patch action is not enabled")
 	})
 }
 
@@ -234,8 +362,11 @@ func TestDocumentHandler_ResolveDocument_Interop(t *testing.T) {
 	pc := newMockProtocolClient()
 	pc.Protocol.Patches = []string{"replace", "add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"}
 
-	parser := operationparser.New(pc.Protocol)
-	oa := operationapplier.New(pc.Protocol, parser, doccomposer.New())
+	parser, err := operationparser.New(pc.Protocol)
+	require.NoError(t, err)
+
+	oa, err := operationapplier.New(pc.Protocol, parser, doccomposer.New())
+	require.NoError(t, err)
 
 	pv := pc.CurrentVersion
 	pv.OperationParserReturns(parser)
@@ -279,7 +410,8 @@ func TestDocumentHandler_ResolveDocument_InitialValue_MaxOperationSizeError(t *t
 	result, err := dochandler.ResolveDocument(docID + longFormPart)
 	require.NotNil(t, err)
 	require.Nil(t, result)
-	require.Contains(t, err.Error(), "bad request: operation byte size exceeds protocol max operation byte size")
+	require.True(t, errors.Is(err, document.ErrInvalidDID))
+	require.Contains(t, err.Error(), "operation byte size exceeds protocol max operation byte size")
 }
 
 func TestDocumentHandler_ResolveDocument_InitialDocumentNotValid(t *testing.T) {
@@ -352,13 +484,14 @@ func TestProcessOperation_ParseOperationError(t *testing.T) {
 	defer cleanup()
 
 	// insert document in the store
-	err := store.Put(getAnchoredCreateOperation())
+	err := store.Put([]*operation.AnchoredOperation{getAnchoredCreateOperation()})
 	require.Nil(t, err)
 
 	doc, err := dochandler.ProcessOperation(getUpdateOperation().OperationBuffer, 0)
 	require.NotNil(t, err)
 	require.Nil(t, doc)
-	require.Contains(t, err.Error(), "bad request: missing signed data")
+	require.True(t, errors.Is(err, operation.ErrInvalidOperation))
+	require.Contains(t, err.Error(), "invalid operation: missing signed data")
 }
 
 // BatchContext implements batch writer context.
@@ -613,11 +746,27 @@ func newMockProtocolClient() *mocks.MockProtocolClient {
 	pc := mocks.NewMockProtocolClient()
 
 	for _, v := range pc.Versions {
-		parser := operationparser.New(v.Protocol())
+		parser, err := operationparser.New(v.Protocol())
+		if err != nil {
+			panic(err)
+		}
+
 		dc := doccomposer.New()
-		oa := operationapplier.New(v.Protocol(), parser, dc)
+		oa, err := operationapplier.New(v.Protocol(), parser, dc)
+		if err != nil {
+			panic(err)
+		}
+
 		dv := &mocks.DocumentValidator{}
+		// the batch writer may cut a batch after the test completes so it needs an operation handler
+		th, err := txnprovider.NewOperationHandler(v.Protocol(), mocks.NewMockCasClient(nil),
+			compression.New(compression.WithDefaultAlgorithms()), parser)
+		if err != nil {
+			panic(err)
+		}
+
 		v.OperationParserReturns(parser)
+		v.OperationHandlerReturns(th)
 		v.OperationApplierReturns(oa)
 		v.DocumentComposerReturns(dc)
 		v.DocumentValidatorReturns(dv)
@@ -625,3 +774,47 @@ func newMockProtocolClient() *mocks.MockProtocolClient {
 
 	return pc
 }
+
+// newMockProtocolClientWithUpgrade returns a protocol client with an additional protocol version
+// (effective at genesis time 100) that uses a different hash algorithm and only allows replace patches.
+func newMockProtocolClientWithUpgrade() *mocks.MockProtocolClient {
+	pc := mocks.NewMockProtocolClient()
+
+	latest := pc.Protocol
+	latest.GenesisTime = 100
+	latest.HashAlgorithm = 7 // crypto code for sha512 hash function
+	latest.Patches = []string{"replace"}
+
+	latestVersion := mocks.GetProtocolVersion(latest)
+
+	pc.Protocol = latest
+	pc.Versions = append(pc.Versions, latestVersion)
+	pc.CurrentVersion = latestVersion
+
+	for _, v := range pc.Versions {
+		parser, err := operationparser.New(v.Protocol())
+		if err != nil {
+			panic(err)
+		}
+
+		dc := doccomposer.New()
+		oa, err := operationapplier.New(v.Protocol(), parser, dc)
+		if err != nil {
+			panic(err)
+		}
+
+		th, err := txnprovider.NewOperationHandler(v.Protocol(), mocks.NewMockCasClient(nil),
+			compression.New(compression.WithDefaultAlgorithms()), parser)
+		if err != nil {
+			panic(err)
+		}
+
+		v.OperationParserReturns(parser)
+		v.OperationHandlerReturns(th)
+		v.OperationApplierReturns(oa)
+		v.DocumentComposerReturns(dc)
+		v.DocumentValidatorReturns(&mocks.DocumentValidator{})
+	}
+
+	return pc
+}
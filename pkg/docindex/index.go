@@ -0,0 +1,180 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package docindex implements a reverse index which maps properties of the current state of documents
+// (JWK thumbprints, key IDs and service endpoints) to the unique suffixes of the documents which contain them.
+//
+// The index is updated whenever operations are stored for a document. In order for this to happen the operation
+// store which is used by the observer (transaction processor) has to be wrapped using Index.WrapOperationStore.
+// Entries for a document are replaced as a whole on each update (e.g. after a recover operation) and removed
+// when the document is deactivated.
+//
+// The index is kept in memory so it has to be rebuilt from the operation store on startup (see Index.Rebuild).
+package docindex
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+var logger = log.New("sidetree-core-docindex")
+
+// Property is a document property which is indexed.
+type Property string
+
+const (
+	// PropertyJWKThumbprint is the JWK thumbprint (RFC 7638) of a public key in the document.
+	PropertyJWKThumbprint Property = "jwkThumbprint"
+
+	// PropertyKeyID is the ID of a public key in the document.
+	PropertyKeyID Property = "keyId"
+
+	// PropertyServiceEndpoint is the endpoint of a service in the document.
+	PropertyServiceEndpoint Property = "serviceEndpoint"
+)
+
+// Properties contains all of the indexed properties.
+var Properties = []Property{PropertyJWKThumbprint, PropertyKeyID, PropertyServiceEndpoint}
+
+type entry struct {
+	property Property
+	value    string
+}
+
+// Index is an in-memory document index.
+type Index struct {
+	mutex    sync.RWMutex
+	suffixes map[entry]map[string]struct{}
+	entries  map[string][]entry
+}
+
+// New returns a new in-memory document index.
+func New() *Index {
+	return &Index{
+		suffixes: make(map[entry]map[string]struct{}),
+		entries:  make(map[string][]entry),
+	}
+}
+
+// Update replaces the index entries for the given unique suffix with the properties of the given document.
+func (idx *Index) Update(uniqueSuffix string, doc document.Document) error {
+	entries := getEntries(uniqueSuffix, doc)
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.remove(uniqueSuffix)
+
+	for _, e := range entries {
+		suffixes, ok := idx.suffixes[e]
+		if !ok {
+			suffixes = make(map[string]struct{})
+			idx.suffixes[e] = suffixes
+		}
+
+		suffixes[uniqueSuffix] = struct{}{}
+	}
+
+	if len(entries) > 0 {
+		idx.entries[uniqueSuffix] = entries
+	}
+
+	return nil
+}
+
+// Delete removes the index entries for the given unique suffix.
+func (idx *Index) Delete(uniqueSuffix string) error {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.remove(uniqueSuffix)
+
+	return nil
+}
+
+// Find returns the unique suffixes (sorted) of the documents which contain the given property value.
+// If the property is not supported then the returned error wraps store.ErrInvalidQuery.
+func (idx *Index) Find(property Property, value string) ([]string, error) {
+	if !isSupported(property) {
+		return nil, fmt.Errorf("%w: unsupported property [%s]", store.ErrInvalidQuery, property)
+	}
+
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	var result []string
+	for suffix := range idx.suffixes[entry{property: property, value: value}] {
+		result = append(result, suffix)
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
+func (idx *Index) remove(uniqueSuffix string) {
+	for _, e := range idx.entries[uniqueSuffix] {
+		suffixes := idx.suffixes[e]
+
+		delete(suffixes, uniqueSuffix)
+
+		if len(suffixes) == 0 {
+			delete(idx.suffixes, e)
+		}
+	}
+
+	delete(idx.entries, uniqueSuffix)
+}
+
+func getEntries(uniqueSuffix string, doc document.Document) []entry {
+	didDoc := document.DidDocumentFromJSONLDObject(doc.JSONLdObject())
+
+	var entries []entry
+
+	for _, pk := range didDoc.PublicKeys() {
+		if pk.ID() != "" {
+			entries = append(entries, entry{property: PropertyKeyID, value: pk.ID()})
+		}
+
+		jwk := pk.PublicKeyJwk()
+		if jwk == nil {
+			continue
+		}
+
+		thumbprint, err := jwk.Thumbprint()
+		if err != nil {
+			logger.Debugf("Unable to index JWK thumbprint of key [%s] for suffix [%s]: %s", pk.ID(), uniqueSuffix, err)
+
+			continue
+		}
+
+		entries = append(entries, entry{property: PropertyJWKThumbprint, value: thumbprint})
+	}
+
+	for _, service := range didDoc.Services() {
+		if service.ServiceEndpoint() != "" {
+			entries = append(entries, entry{property: PropertyServiceEndpoint, value: service.ServiceEndpoint()})
+		}
+	}
+
+	return entries
+}
+
+func isSupported(property Property) bool {
+	for _, p := range Properties {
+		if p == property {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docindex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const (
+	suffix1 = "suffix1"
+	suffix2 = "suffix2"
+
+	// JWK thumbprint of the key in doc1.
+	thumbprint = "eGM4rpL22FURuIpUPgS0zqbzIChYteJOnJvNbg8h3o0"
+)
+
+const doc1 = `{
+	"publicKey": [{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+		}
+	},
+	{
+		"id": "key2",
+		"type": "Ed25519VerificationKey2018",
+		"publicKeyBase58": "36d8RkFy2SdabnGzcZ3LcCSDA8NP5T4bsoADwuXtoN3B"
+	}],
+	"service": [{
+		"id": "hub",
+		"type": "IdentityHub",
+		"serviceEndpoint": "https://example.com/hub/"
+	}]
+}`
+
+const doc2 = `{
+	"publicKey": [{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "invalid"
+		}
+	}]
+}`
+
+func TestIndex(t *testing.T) {
+	idx := New()
+
+	require.NoError(t, idx.Update(suffix1, getDocument(t, doc1)))
+	require.NoError(t, idx.Update(suffix2, getDocument(t, doc2)))
+
+	requireFind(t, idx, PropertyKeyID, "key1", suffix1, suffix2)
+	requireFind(t, idx, PropertyKeyID, "key2", suffix1)
+	requireFind(t, idx, PropertyJWKThumbprint, thumbprint, suffix1)
+	requireFind(t, idx, PropertyServiceEndpoint, "https://example.com/hub/", suffix1)
+	requireFind(t, idx, PropertyServiceEndpoint, "https://example.com/other/")
+
+	t.Run("update replaces entries", func(t *testing.T) {
+		require.NoError(t, idx.Update(suffix1, getDocument(t, doc2)))
+
+		requireFind(t, idx, PropertyKeyID, "key1", suffix1, suffix2)
+		requireFind(t, idx, PropertyKeyID, "key2")
+		requireFind(t, idx, PropertyJWKThumbprint, thumbprint)
+		requireFind(t, idx, PropertyServiceEndpoint, "https://example.com/hub/")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, idx.Delete(suffix1))
+		require.NoError(t, idx.Delete(suffix1))
+
+		requireFind(t, idx, PropertyKeyID, "key1", suffix2)
+
+		require.NoError(t, idx.Update(suffix2, make(document.Document)))
+
+		requireFind(t, idx, PropertyKeyID, "key1")
+		require.Empty(t, idx.suffixes)
+		require.Empty(t, idx.entries)
+	})
+
+	t.Run("unsupported property", func(t *testing.T) {
+		suffixes, err := idx.Find("invalid", "value")
+		require.True(t, errors.Is(err, store.ErrInvalidQuery))
+		require.Empty(t, suffixes)
+	})
+}
+
+func getDocument(t *testing.T, doc string) document.Document {
+	t.Helper()
+
+	d, err := document.FromBytes([]byte(doc))
+	require.NoError(t, err)
+
+	return d
+}
+
+func requireFind(t *testing.T, idx *Index, property Property, value string, expected ...string) {
+	t.Helper()
+
+	suffixes, err := idx.Find(property, value)
+	require.NoError(t, err)
+	require.Equal(t, expected, suffixes)
+}
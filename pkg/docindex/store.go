@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docindex
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const rebuildPageSize = 100
+
+// Resolver resolves the current state of a document based on the unique suffix (e.g. the operation processor).
+type Resolver interface {
+	Resolve(uniqueSuffix string) (*document.ResolutionResult, error)
+}
+
+// OperationIndex queries anchored operations.
+type OperationIndex interface {
+	Query(query *store.Query) (*store.QueryResult, error)
+}
+
+// Refresh resolves the documents with the given unique suffixes and replaces their index entries with
+// the properties of the current state of the documents. The entries of documents which are deactivated or
+// not found are removed. Documents which fail to resolve for any other reason are left as is.
+func (idx *Index) Refresh(resolver Resolver, uniqueSuffixes ...string) {
+	for _, suffix := range uniqueSuffixes {
+		result, err := resolver.Resolve(suffix)
+
+		switch {
+		case err == nil:
+			err = idx.Update(suffix, result.Document)
+		case errors.Is(err, document.ErrDeactivated), errors.Is(err, document.ErrNotFound):
+			err = idx.Delete(suffix)
+		}
+
+		if err != nil {
+			logger.Warnf("Failed to refresh document index for suffix [%s]: %s", suffix, err)
+		}
+	}
+}
+
+// Rebuild indexes the current state of all of the documents which have a create operation in the given
+// operation index. It should be called on startup since the index is not persisted.
+func (idx *Index) Rebuild(resolver Resolver, opIndex OperationIndex) error {
+	query := &store.Query{OperationType: operation.TypeCreate, Limit: rebuildPageSize}
+
+	count := 0
+
+	for {
+		result, err := opIndex.Query(query)
+		if err != nil {
+			return err
+		}
+
+		for _, op := range result.Operations {
+			idx.Refresh(resolver, op.UniqueSuffix)
+		}
+
+		count += len(result.Operations)
+
+		if result.Cursor == "" {
+			break
+		}
+
+		query.Cursor = result.Cursor
+	}
+
+	logger.Infof("Rebuilt document index for %d documents", count)
+
+	return nil
+}
+
+// WrapOperationStore returns an operation store which stores operations using the given store and then
+// refreshes the index entries for the suffixes of the stored operations (using the given resolver). In order for
+// the index to be kept up to date, the operation store which is used by the observer (transaction processor)
+// has to be wrapped.
+func (idx *Index) WrapOperationStore(opStore store.OperationWriter, resolver Resolver) store.OperationWriter {
+	return &indexingStore{
+		OperationWriter: opStore,
+		index:           idx,
+		resolver:        resolver,
+	}
+}
+
+type indexingStore struct {
+	store.OperationWriter
+	index    *Index
+	resolver Resolver
+	mutex    sync.Mutex
+}
+
+// Put stores the operations and refreshes the index entries for the suffixes of the operations. Concurrent calls
+// are serialized so that the index entries written by a refresh are never older than the stored operations.
+func (s *indexingStore) Put(ops []*operation.AnchoredOperation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.OperationWriter.Put(ops)
+
+	// refresh even if there was an error since some of the operations may have been stored
+	var suffixes []string
+
+	refreshed := make(map[string]bool)
+
+	for _, op := range ops {
+		if !refreshed[op.UniqueSuffix] {
+			refreshed[op.UniqueSuffix] = true
+
+			suffixes = append(suffixes, op.UniqueSuffix)
+		}
+	}
+
+	s.index.Refresh(s.resolver, suffixes...)
+
+	return err
+}
@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docindex
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+func TestIndex_WrapOperationStore(t *testing.T) {
+	resolver := newMockResolver()
+	resolver.set(suffix1, getDocument(t, doc1), nil)
+
+	idx := New()
+	opStore := idx.WrapOperationStore(&mockOperationStore{}, resolver)
+
+	require.NoError(t, opStore.Put([]*operation.AnchoredOperation{{UniqueSuffix: suffix1, Type: operation.TypeCreate}}))
+
+	requireFind(t, idx, PropertyJWKThumbprint, thumbprint, suffix1)
+
+	t.Run("entries are replaced after recover", func(t *testing.T) {
+		// the document is not resolved by a client after the recover operation is stored
+		resolver.set(suffix1, getDocument(t, doc2), nil)
+
+		require.NoError(t, opStore.Put([]*operation.AnchoredOperation{
+			{UniqueSuffix: suffix1, Type: operation.TypeRecover},
+			{UniqueSuffix: suffix1, Type: operation.TypeUpdate},
+		}))
+
+		requireFind(t, idx, PropertyJWKThumbprint, thumbprint)
+		requireFind(t, idx, PropertyKeyID, "key2")
+		requireFind(t, idx, PropertyKeyID, "key1", suffix1)
+		require.Equal(t, 2, resolver.count(suffix1))
+	})
+
+	t.Run("entries are removed after deactivate", func(t *testing.T) {
+		resolver.set(suffix1, nil, document.ErrDeactivated)
+
+		require.NoError(t, opStore.Put([]*operation.AnchoredOperation{{UniqueSuffix: suffix1, Type: operation.TypeDeactivate}}))
+
+		requireFind(t, idx, PropertyKeyID, "key1")
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		resolver.set(suffix2, getDocument(t, doc1), nil)
+		idx.Refresh(resolver, suffix2)
+
+		resolver.set(suffix2, nil, errors.New("injected resolve error"))
+
+		require.NoError(t, opStore.Put([]*operation.AnchoredOperation{{UniqueSuffix: suffix2, Type: operation.TypeUpdate}}))
+
+		// the state of the document is unknown so the entries are left as is
+		requireFind(t, idx, PropertyKeyID, "key1", suffix2)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+
+		resolver.set(suffix2, getDocument(t, doc2), nil)
+
+		opStore := idx.WrapOperationStore(&mockOperationStore{err: errExpected}, resolver)
+
+		err := opStore.Put([]*operation.AnchoredOperation{{UniqueSuffix: suffix2, Type: operation.TypeUpdate}})
+		require.EqualError(t, err, errExpected.Error())
+
+		// some of the operations may have been stored so the index is refreshed anyway
+		requireFind(t, idx, PropertyKeyID, "key2")
+	})
+}
+
+func TestIndex_Rebuild(t *testing.T) {
+	resolver := newMockResolver()
+	opIndex := &mockOperationIndex{}
+
+	for i := 0; i < 2*rebuildPageSize+1; i++ {
+		suffix := "suffix" + strconv.Itoa(i)
+
+		resolver.set(suffix, getDocument(t, doc2), nil)
+		opIndex.ops = append(opIndex.ops, &operation.AnchoredOperation{UniqueSuffix: suffix, Type: operation.TypeCreate})
+	}
+
+	resolver.set("suffix0", getDocument(t, doc1), nil)
+	resolver.set("suffix1", nil, document.ErrDeactivated)
+
+	idx := New()
+
+	require.NoError(t, idx.Rebuild(resolver, opIndex))
+
+	requireFind(t, idx, PropertyJWKThumbprint, thumbprint, "suffix0")
+
+	suffixes, err := idx.Find(PropertyKeyID, "key1")
+	require.NoError(t, err)
+	require.Len(t, suffixes, 2*rebuildPageSize)
+	require.NotContains(t, suffixes, "suffix1")
+
+	t.Run("query error", func(t *testing.T) {
+		opIndex := &mockOperationIndex{err: errors.New("injected query error")}
+
+		require.EqualError(t, New().Rebuild(resolver, opIndex), opIndex.err.Error())
+	})
+}
+
+type mockResolver struct {
+	mutex  sync.Mutex
+	docs   map[string]document.Document
+	errs   map[string]error
+	counts map[string]int
+}
+
+func newMockResolver() *mockResolver {
+	return &mockResolver{
+		docs:   make(map[string]document.Document),
+		errs:   make(map[string]error),
+		counts: make(map[string]int),
+	}
+}
+
+func (m *mockResolver) set(uniqueSuffix string, doc document.Document, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.docs[uniqueSuffix] = doc
+	m.errs[uniqueSuffix] = err
+}
+
+func (m *mockResolver) Resolve(uniqueSuffix string) (*document.ResolutionResult, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.counts[uniqueSuffix]++
+
+	if err := m.errs[uniqueSuffix]; err != nil {
+		return nil, err
+	}
+
+	doc, ok := m.docs[uniqueSuffix]
+	if !ok {
+		return nil, document.ErrNotFound
+	}
+
+	return &document.ResolutionResult{Document: doc}, nil
+}
+
+func (m *mockResolver) count(uniqueSuffix string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.counts[uniqueSuffix]
+}
+
+type mockOperationStore struct {
+	err error
+}
+
+func (m *mockOperationStore) Put([]*operation.AnchoredOperation) error {
+	return m.err
+}
+
+type mockOperationIndex struct {
+	ops []*operation.AnchoredOperation
+	err error
+}
+
+func (m *mockOperationIndex) Query(query *store.Query) (*store.QueryResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	start := 0
+	if query.Cursor != "" {
+		start, _ = strconv.Atoi(query.Cursor)
+	}
+
+	end := start + query.Limit
+	if end >= len(m.ops) {
+		return &store.QueryResult{Operations: m.ops[start:]}, nil
+	}
+
+	return &store.QueryResult{Operations: m.ops[start:end], Cursor: strconv.Itoa(end)}, nil
+}
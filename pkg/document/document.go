@@ -44,6 +44,11 @@ func (doc Document) PublicKeys() []PublicKey {
 	return ParsePublicKeys(doc[PublicKeyProperty])
 }
 
+// Services in generic document are the advertised service endpoints.
+func (doc Document) Services() []Service {
+	return ParseServices(doc[ServiceProperty])
+}
+
 // GetStringValue returns string value for specified key or "" if not found or wrong type.
 func (doc Document) GetStringValue(key string) string {
 	return stringEntry(doc[key])
@@ -0,0 +1,21 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package document
+
+import "errors"
+
+var (
+	// ErrNotFound is returned (wrapped) when a document cannot be found.
+	ErrNotFound = errors.New("document not found")
+
+	// ErrDeactivated is returned (wrapped) when a document has been deactivated.
+	ErrDeactivated = errors.New("document was deactivated")
+
+	// ErrInvalidDID is returned (wrapped) when a DID (short or long form) is malformed or doesn't
+	// belong to a supported namespace.
+	ErrInvalidDID = errors.New("invalid DID")
+)
@@ -6,7 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 
 package document
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
 
 // JWK represents public key in JWK format.
 type JWK map[string]interface{}
@@ -39,7 +43,11 @@ func (jwk JWK) Y() string {
 // Validate will validate JWK properties.
 func (jwk JWK) Validate() error {
 	// TODO: validation of the JWK fields depends on the algorithm (issue-409)
-	// For now check required fields for currently supported algorithms secp256k1, P-256, P-384, P-512 and Ed25519
+	// For now check required fields for currently supported algorithms secp256k1, P-256, P-384, P-521 and Ed25519
+
+	if _, ok := jwk["d"]; ok {
+		return errors.New("JWK must not contain the private key (d)")
+	}
 
 	if jwk.Crv() == "" {
 		return errors.New("JWK crv is missing")
@@ -55,3 +63,18 @@ func (jwk JWK) Validate() error {
 
 	return nil
 }
+
+// Thumbprint returns the JWK thumbprint (RFC 7638) of the key, computed using SHA-256 and base64url encoded.
+// The thumbprint is computed by jws.JWK so that the same key always has the same thumbprint.
+func (jwk JWK) Thumbprint() (string, error) {
+	key := &jws.JWK{
+		Kty: jwk.Kty(),
+		Crv: jwk.Crv(),
+		X:   jwk.X(),
+		Y:   jwk.Y(),
+		N:   stringEntry(jwk["n"]),
+		E:   stringEntry(jwk["e"]),
+	}
+
+	return key.Thumbprint()
+}
@@ -7,9 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package document
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 )
 
 func TestJWK(t *testing.T) {
@@ -71,4 +80,84 @@ func TestValidate(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "JWK x is missing")
 	})
+
+	t.Run("private key", func(t *testing.T) {
+		jwk := JWK{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   "x",
+			"d":   "d",
+		}
+
+		err := jwk.Validate()
+		require.EqualError(t, err, "JWK must not contain the private key (d)")
+	})
+}
+
+func TestThumbprint(t *testing.T) {
+	t.Run("EC", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		requireThumbprint(t, &privateKey.PublicKey)
+	})
+
+	t.Run("OKP", func(t *testing.T) {
+		// Test vector from RFC 8037 (Appendix A.3)
+		thumbprint, err := JWK{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+		}.Thumbprint()
+		require.NoError(t, err)
+		require.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", thumbprint)
+	})
+
+	t.Run("RSA", func(t *testing.T) {
+		// Test vector from RFC 7638 (Section 3.1)
+		const expected = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+
+		const n = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+
+		thumbprint, err := JWK{"kty": "RSA", "n": n, "e": "AQAB", "alg": "RS256", "kid": "2011-04-29"}.Thumbprint()
+		require.NoError(t, err)
+		require.Equal(t, expected, thumbprint)
+
+		// the thumbprint is the same as the thumbprint of the key in the jws package
+		thumbprint, err = (&jws.JWK{Kty: "RSA", N: n, E: "AQAB"}).Thumbprint()
+		require.NoError(t, err)
+		require.Equal(t, expected, thumbprint)
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		thumbprint, err := JWK{"kty": "oct", "k": "k"}.Thumbprint()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not supported for key type [oct]")
+		require.Empty(t, thumbprint)
+	})
+
+	t.Run("missing member", func(t *testing.T) {
+		thumbprint, err := JWK{"kty": "EC", "crv": "P-256", "x": "x"}.Thumbprint()
+		require.EqualError(t, err, "JWK y is missing")
+		require.Empty(t, thumbprint)
+	})
+}
+
+func requireThumbprint(t *testing.T, publicKey interface{}) {
+	t.Helper()
+
+	key := jose.JSONWebKey{Key: publicKey}
+
+	expected, err := key.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	jwkBytes, err := key.MarshalJSON()
+	require.NoError(t, err)
+
+	jwk := JWK{}
+	require.NoError(t, json.Unmarshal(jwkBytes, &jwk))
+
+	thumbprint, err := jwk.Thumbprint()
+	require.NoError(t, err)
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(expected), thumbprint)
 }
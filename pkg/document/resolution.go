@@ -20,3 +20,13 @@ type MethodMetadata struct {
 	Published          bool   `json:"published"`
 	CanonicalID        string `json:"canonicalID,omitempty"`
 }
+
+// DIDResolution holds the outcome of resolving a single DID as part of a batch resolution.
+type DIDResolution struct {
+	// ID is the requested short or long form DID.
+	ID string
+	// Result is the resolution result (nil if resolution failed).
+	Result *ResolutionResult
+	// Err is the resolution error (nil if resolution succeeded).
+	Err error
+}
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docutil
+
+import (
+	"encoding/json"
+)
+
+// DeepCopy returns a deep copy of a JSON value which is the same as the result of a JSON round-trip of the value.
+// The types produced by unmarshalling JSON are copied directly; any other type is copied using a JSON round-trip
+// (which converts it to the unmarshalled form).
+func DeepCopy(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool, float64:
+		return v, nil
+	case map[string]interface{}:
+		if v == nil {
+			return nil, nil
+		}
+
+		return DeepCopyMap(v)
+	case []interface{}:
+		if v == nil {
+			return nil, nil
+		}
+
+		return copySlice(v)
+	default:
+		return copyJSON(v)
+	}
+}
+
+// DeepCopyMap returns a deep copy of a JSON object (see DeepCopy).
+func DeepCopyMap(m map[string]interface{}) (map[string]interface{}, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	result := make(map[string]interface{}, len(m))
+
+	for key, value := range m {
+		v, err := DeepCopy(value)
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = v
+	}
+
+	return result, nil
+}
+
+func copySlice(s []interface{}) ([]interface{}, error) {
+	result := make([]interface{}, len(s))
+
+	for i, value := range s {
+		v, err := DeepCopy(value)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = v
+	}
+
+	return result, nil
+}
+
+func copyJSON(value interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+
+	err = json.Unmarshal(bytes, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
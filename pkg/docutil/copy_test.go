@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package docutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepCopyMap(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		original := map[string]interface{}{
+			"id":       "doc1",
+			"nested":   map[string]interface{}{"values": []interface{}{"a", map[string]interface{}{"b": true}}},
+			"nilMap":   map[string]interface{}(nil),
+			"nilSlice": []interface{}(nil),
+			"strings":  []string{"a", "b"},
+			"number":   1,
+			"data":     &testData{FieldA: "a"},
+		}
+
+		// the copy must be the same as the result of a JSON round-trip
+		bytes, err := json.Marshal(original)
+		require.NoError(t, err)
+
+		expected := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(bytes, &expected))
+
+		result, err := DeepCopyMap(original)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+
+		// modifying the copy must not modify the original
+		result["nested"].(map[string]interface{})["values"].([]interface{})[0] = "modified"
+		require.Equal(t, "a", original["nested"].(map[string]interface{})["values"].([]interface{})[0])
+	})
+
+	t.Run("nil map", func(t *testing.T) {
+		result, err := DeepCopyMap(nil)
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+
+	t.Run("error - value can't be marshalled", func(t *testing.T) {
+		result, err := DeepCopyMap(map[string]interface{}{"invalid": make(chan int)})
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
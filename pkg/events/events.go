@@ -0,0 +1,273 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package events publishes events for DID changes (created, updated, recovered and deactivated) to pluggable sinks.
+//
+// Events are published after the anchored operations have been stored and reflect the change in the resolved
+// state of the documents, so operations which are ignored by resolution don't result in events. In order for
+// this to happen the operation store which is used by the observer (transaction processor) has to be wrapped
+// using WrapOperationStore.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+)
+
+var logger = log.New("sidetree-core-events")
+
+// Type is the type of a DID event.
+type Type string
+
+const (
+	// TypeCreated indicates that a DID was created.
+	TypeCreated Type = "created"
+
+	// TypeUpdated indicates that a DID document was updated.
+	TypeUpdated Type = "updated"
+
+	// TypeRecovered indicates that a DID was recovered.
+	TypeRecovered Type = "recovered"
+
+	// TypeDeactivated indicates that a DID was deactivated.
+	TypeDeactivated Type = "deactivated"
+)
+
+// Event is published when an anchored operation has changed the resolved state of a DID.
+type Event struct {
+	// ID uniquely identifies the event within the namespace (transaction time, transaction number
+	// and operation index).
+	ID string `json:"id"`
+
+	Type              Type   `json:"type"`
+	DID               string `json:"did"`
+	UniqueSuffix      string `json:"didSuffix"`
+	TransactionTime   uint64 `json:"transactionTime"`
+	TransactionNumber uint64 `json:"transactionNumber"`
+	AnchorString      string `json:"anchorString"`
+}
+
+// Sink receives published events. Sinks must not block since events are published synchronously
+// by the observer.
+type Sink interface {
+	Publish(events ...*Event) error
+}
+
+// Resolver resolves the current state of a document based on the unique suffix (e.g. the operation processor).
+type Resolver interface {
+	Resolve(uniqueSuffix string) (*document.ResolutionResult, error)
+}
+
+// NewEvent returns an event of the given type for the given anchored operation.
+func NewEvent(namespace string, t Type, op *operation.AnchoredOperation) *Event {
+	return &Event{
+		ID:                fmt.Sprintf("%d.%d.%d", op.TransactionTime, op.TransactionNumber, op.OperationIndex),
+		Type:              t,
+		DID:               namespace + docutil.NamespaceDelimiter + op.UniqueSuffix,
+		UniqueSuffix:      op.UniqueSuffix,
+		TransactionTime:   op.TransactionTime,
+		TransactionNumber: op.TransactionNumber,
+		AnchorString:      op.AnchorString,
+	}
+}
+
+// WrapOperationStore returns an operation store which stores operations using the given store and then
+// publishes an event to the given sink for each document whose resolved state (using the given resolver)
+// was changed by the stored operations. Operations which are ignored by resolution (e.g. operations with
+// an invalid signature or commitment) don't result in events.
+func WrapOperationStore(namespace string, opStore store.OperationWriter, resolver Resolver, sink Sink) store.OperationWriter {
+	return &publishingStore{
+		OperationWriter: opStore,
+		namespace:       namespace,
+		resolver:        resolver,
+		sink:            sink,
+	}
+}
+
+type publishingStore struct {
+	store.OperationWriter
+	namespace string
+	resolver  Resolver
+	sink      Sink
+	mutex     sync.Mutex
+}
+
+type state struct {
+	result *document.ResolutionResult
+	err    error
+}
+
+// Put stores the operations and publishes an event for each document whose state was changed by the
+// operations. Concurrent calls are serialized so that the state before the operations were stored is
+// not changed by another call.
+func (s *publishingStore) Put(ops []*operation.AnchoredOperation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the last operation of each suffix identifies the event
+	var suffixes []string
+
+	lastOps := make(map[string]*operation.AnchoredOperation)
+
+	for _, op := range ops {
+		if _, ok := lastOps[op.UniqueSuffix]; !ok {
+			suffixes = append(suffixes, op.UniqueSuffix)
+		}
+
+		lastOps[op.UniqueSuffix] = op
+	}
+
+	before := make(map[string]*state, len(suffixes))
+
+	for _, suffix := range suffixes {
+		before[suffix] = s.resolve(suffix)
+	}
+
+	err := s.OperationWriter.Put(ops)
+
+	// publish even if there was an error since some of the operations may have been stored
+	var events []*Event
+
+	for _, suffix := range suffixes {
+		t, err := eventType(before[suffix], s.resolve(suffix))
+		if err != nil {
+			logger.Warnf("[%s] Not publishing event for suffix [%s]: %s", s.namespace, suffix, err)
+
+			continue
+		}
+
+		if t != "" {
+			events = append(events, NewEvent(s.namespace, t, lastOps[suffix]))
+		}
+	}
+
+	if len(events) > 0 {
+		// a failure to publish doesn't fail the store
+		if e := s.sink.Publish(events...); e != nil {
+			logger.Warnf("[%s] Failed to publish %d events: %s", s.namespace, len(events), e)
+		}
+	}
+
+	return err
+}
+
+func (s *publishingStore) resolve(suffix string) *state {
+	result, err := s.resolver.Resolve(suffix)
+
+	return &state{result: result, err: err}
+}
+
+// eventType returns the type of the event for the change between the given document states or an empty
+// type if the state hasn't changed.
+func eventType(before, after *state) (Type, error) {
+	if before.err != nil && !errors.Is(before.err, document.ErrNotFound) &&
+		!errors.Is(before.err, document.ErrDeactivated) {
+		return "", fmt.Errorf("resolve previous state: %w", before.err)
+	}
+
+	switch {
+	case errors.Is(after.err, document.ErrDeactivated):
+		if errors.Is(before.err, document.ErrDeactivated) {
+			return "", nil
+		}
+
+		return TypeDeactivated, nil
+	case errors.Is(after.err, document.ErrNotFound):
+		return "", nil
+	case after.err != nil:
+		return "", fmt.Errorf("resolve current state: %w", after.err)
+	case before.err != nil:
+		// the document didn't exist before (a deactivated document can't be re-created)
+		return TypeCreated, nil
+	case before.result.MethodMetadata.RecoveryCommitment != after.result.MethodMetadata.RecoveryCommitment:
+		return TypeRecovered, nil
+	case before.result.MethodMetadata.UpdateCommitment != after.result.MethodMetadata.UpdateCommitment:
+		return TypeUpdated, nil
+	default:
+		return "", nil
+	}
+}
+
+// NewMultiSink returns a sink which publishes events to each of the given sinks.
+func NewMultiSink(sinks ...Sink) Sink {
+	return multiSink(sinks)
+}
+
+type multiSink []Sink
+
+// Publish publishes the events to each of the sinks. All sinks are invoked even if one of them fails.
+func (s multiSink) Publish(events ...*Event) error {
+	var errs []error
+
+	for _, sink := range s {
+		if err := sink.Publish(events...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish events to %d of %d sinks: %v", len(errs), len(s), errs)
+	}
+
+	return nil
+}
+
+// Filter selects the events for a subscriber. An empty filter matches all events.
+type Filter struct {
+	DIDs  []string
+	Types []Type
+}
+
+// Matches returns true if the event matches the filter.
+func (f *Filter) Matches(e *Event) bool {
+	if f == nil {
+		return true
+	}
+
+	return (len(f.DIDs) == 0 || containsString(f.DIDs, e.DID)) &&
+		(len(f.Types) == 0 || containsType(f.Types, e.Type))
+}
+
+// Apply returns the events which match the filter.
+func (f *Filter) Apply(events []*Event) []*Event {
+	var matching []*Event
+
+	for _, e := range events {
+		if f.Matches(e) {
+			matching = append(matching, e)
+		}
+	}
+
+	return matching
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsType(values []Type, value Type) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,244 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+const namespace = "did:sidetree"
+
+func TestNewEvent(t *testing.T) {
+	e := NewEvent(namespace, TypeRecovered, &operation.AnchoredOperation{
+		Type:              operation.TypeRecover,
+		UniqueSuffix:      "suffix1",
+		TransactionTime:   10,
+		TransactionNumber: 2,
+		OperationIndex:    3,
+		AnchorString:      "1.anchor",
+	})
+	require.Equal(t, &Event{
+		ID:                "10.2.3",
+		Type:              TypeRecovered,
+		DID:               "did:sidetree:suffix1",
+		UniqueSuffix:      "suffix1",
+		TransactionTime:   10,
+		TransactionNumber: 2,
+		AnchorString:      "1.anchor",
+	}, e)
+}
+
+func TestWrapOperationStore(t *testing.T) {
+	notFound := &state{err: fmt.Errorf("resolve: %w", document.ErrNotFound)}
+	deactivated := &state{err: fmt.Errorf("resolve: %w", document.ErrDeactivated)}
+	state1 := newState("u1", "r1")
+	updated := newState("u2", "r1")
+	recovered := newState("u3", "r2")
+
+	ops := []*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: "created", TransactionTime: 10},
+		{Type: operation.TypeUpdate, UniqueSuffix: "updated", TransactionTime: 10, OperationIndex: 1},
+		{Type: operation.TypeRecover, UniqueSuffix: "recovered", TransactionTime: 10, OperationIndex: 2},
+		{Type: operation.TypeDeactivate, UniqueSuffix: "deactivated", TransactionTime: 10, OperationIndex: 3},
+		{Type: operation.TypeDeactivate, UniqueSuffix: "invalid", TransactionTime: 10, OperationIndex: 4},
+		{Type: operation.TypeDeactivate, UniqueSuffix: "already-deactivated", TransactionTime: 10, OperationIndex: 5},
+		{Type: operation.TypeUpdate, UniqueSuffix: "resolve-error", TransactionTime: 10, OperationIndex: 6},
+		{Type: operation.TypeCreate, UniqueSuffix: "invalid-create", TransactionTime: 10, OperationIndex: 7},
+	}
+
+	before := map[string]*state{
+		"created":             notFound,
+		"updated":             state1,
+		"recovered":           state1,
+		"deactivated":         state1,
+		"invalid":             state1,
+		"already-deactivated": deactivated,
+		"resolve-error":       state1,
+		"invalid-create":      notFound,
+	}
+
+	after := map[string]*state{
+		"created":             state1,
+		"updated":             updated,
+		"recovered":           recovered,
+		"deactivated":         deactivated,
+		"invalid":             state1,
+		"already-deactivated": deactivated,
+		"resolve-error":       {err: errors.New("injected resolve error")},
+		"invalid-create":      notFound,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		opStore := mocks.NewMockOperationStore(nil)
+		resolver := &mockResolver{states: before}
+		sink := &mockSink{}
+
+		require.NoError(t, WrapOperationStore(namespace, &stateChangingStore{opStore, resolver, after},
+			resolver, sink).Put(ops))
+
+		stored, err := opStore.Get("created")
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+
+		require.Equal(t, 1, sink.calls)
+		require.Len(t, sink.events, 4)
+		require.Equal(t, TypeCreated, sink.events[0].Type)
+		require.Equal(t, "did:sidetree:created", sink.events[0].DID)
+		require.Equal(t, TypeUpdated, sink.events[1].Type)
+		require.Equal(t, TypeRecovered, sink.events[2].Type)
+		require.Equal(t, TypeDeactivated, sink.events[3].Type)
+		require.Equal(t, "10.0.3", sink.events[3].ID)
+	})
+
+	t.Run("last operation of a suffix identifies the event", func(t *testing.T) {
+		resolver := &mockResolver{states: map[string]*state{"suffix": notFound}}
+		sink := &mockSink{}
+
+		err := WrapOperationStore(namespace,
+			&stateChangingStore{mocks.NewMockOperationStore(nil), resolver, map[string]*state{"suffix": updated}},
+			resolver, sink).Put([]*operation.AnchoredOperation{
+			{Type: operation.TypeCreate, UniqueSuffix: "suffix", TransactionTime: 10},
+			{Type: operation.TypeUpdate, UniqueSuffix: "suffix", TransactionTime: 11},
+		})
+		require.NoError(t, err)
+		require.Len(t, sink.events, 1)
+		require.Equal(t, TypeCreated, sink.events[0].Type)
+		require.Equal(t, "11.0.0", sink.events[0].ID)
+	})
+
+	t.Run("previous state resolve error", func(t *testing.T) {
+		resolver := &mockResolver{states: map[string]*state{"suffix": {err: errors.New("injected resolve error")}}}
+		sink := &mockSink{}
+
+		err := WrapOperationStore(namespace,
+			&stateChangingStore{mocks.NewMockOperationStore(nil), resolver, map[string]*state{"suffix": updated}},
+			resolver, sink).Put(ops[1:2])
+		require.NoError(t, err)
+		require.Equal(t, 0, sink.calls)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		errExpected := errors.New("injected store error")
+		resolver := &mockResolver{states: before}
+		sink := &mockSink{}
+
+		err := WrapOperationStore(namespace, mocks.NewMockOperationStore(errExpected), resolver, sink).Put(ops)
+		require.True(t, errors.Is(err, errExpected))
+		require.Empty(t, sink.events)
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		resolver := &mockResolver{states: before}
+		sink := &mockSink{err: errors.New("injected publish error")}
+
+		require.NoError(t, WrapOperationStore(namespace,
+			&stateChangingStore{mocks.NewMockOperationStore(nil), resolver, after}, resolver, sink).Put(ops))
+		require.Equal(t, 1, sink.calls)
+	})
+}
+
+func TestMultiSink(t *testing.T) {
+	sink1 := &mockSink{}
+	sink2 := &mockSink{err: errors.New("injected publish error")}
+	sink3 := &mockSink{}
+
+	err := NewMultiSink(sink1, sink2, sink3).Publish(&Event{ID: "1"}, &Event{ID: "2"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to publish events to 1 of 3 sinks")
+
+	require.Len(t, sink1.events, 2)
+	require.Len(t, sink3.events, 2)
+
+	require.NoError(t, NewMultiSink(sink1, sink3).Publish(&Event{ID: "3"}))
+	require.Len(t, sink1.events, 3)
+}
+
+func TestFilter(t *testing.T) {
+	e1 := &Event{DID: "did:sidetree:1", Type: TypeCreated}
+	e2 := &Event{DID: "did:sidetree:2", Type: TypeUpdated}
+	e3 := &Event{DID: "did:sidetree:1", Type: TypeDeactivated}
+
+	all := []*Event{e1, e2, e3}
+
+	var nilFilter *Filter
+
+	require.Equal(t, all, nilFilter.Apply(all))
+	require.Equal(t, all, (&Filter{}).Apply(all))
+	require.Equal(t, []*Event{e1, e3}, (&Filter{DIDs: []string{"did:sidetree:1"}}).Apply(all))
+	require.Equal(t, []*Event{e2, e3}, (&Filter{Types: []Type{TypeUpdated, TypeDeactivated}}).Apply(all))
+	require.Equal(t, []*Event{e3},
+		(&Filter{DIDs: []string{"did:sidetree:1"}, Types: []Type{TypeDeactivated}}).Apply(all))
+	require.Empty(t, (&Filter{DIDs: []string{"did:sidetree:3"}}).Apply(all))
+}
+
+type mockSink struct {
+	events []*Event
+	calls  int
+	err    error
+}
+
+func (m *mockSink) Publish(events ...*Event) error {
+	m.calls++
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.events = append(m.events, events...)
+
+	return nil
+}
+
+type mockResolver struct {
+	states map[string]*state
+}
+
+func (m *mockResolver) Resolve(uniqueSuffix string) (*document.ResolutionResult, error) {
+	s, ok := m.states[uniqueSuffix]
+	if !ok {
+		return nil, document.ErrNotFound
+	}
+
+	return s.result, s.err
+}
+
+// stateChangingStore changes the states of the resolver once the operations have been stored.
+type stateChangingStore struct {
+	store.OperationWriter
+	resolver *mockResolver
+	states   map[string]*state
+}
+
+func (s *stateChangingStore) Put(ops []*operation.AnchoredOperation) error {
+	if err := s.OperationWriter.Put(ops); err != nil {
+		return err
+	}
+
+	s.resolver.states = s.states
+
+	return nil
+}
+
+func newState(updateCommitment, recoveryCommitment string) *state {
+	return &state{
+		result: &document.ResolutionResult{
+			MethodMetadata: document.MethodMetadata{
+				UpdateCommitment:   updateCommitment,
+				RecoveryCommitment: recoveryCommitment,
+			},
+		},
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureHeader contains the signature of the webhook payload:
+	//
+	//	sha256=<hex encoded HMAC-SHA256 of "<timestamp>.<payload>">
+	//
+	// where the key is the secret of the subscriber and the timestamp is the value of TimestampHeader.
+	SignatureHeader = "X-Sidetree-Signature"
+
+	// TimestampHeader contains the time (Unix seconds) at which the webhook payload was signed. Receivers
+	// should reject payloads with old timestamps in order to prevent replays.
+	TimestampHeader = "X-Sidetree-Timestamp"
+
+	signaturePrefix = "sha256="
+
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = time.Second
+	defaultQueueSize    = 100
+	defaultTimeout      = 10 * time.Second
+)
+
+// ErrSinkStopped is returned when events are published to a stopped sink.
+var ErrSinkStopped = errors.New("sink stopped")
+
+// Subscriber is a webhook subscriber.
+type Subscriber struct {
+	// URL is the URL that events are posted to.
+	URL string
+
+	// Secret is the key which is used to sign the payloads (see SignatureHeader).
+	Secret []byte
+
+	// Filter selects the events which are delivered to the subscriber (nil for all events).
+	Filter *Filter
+}
+
+// WebhookPayload is the JSON body which is posted to the subscribers.
+type WebhookPayload struct {
+	Events []*Event `json:"events"`
+}
+
+// HTTPClient sends HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookSink posts events to webhook subscribers. Events are delivered asynchronously (in order) by a separate
+// worker for each subscriber. Deliveries which fail with a network error or a 5xx or 429 status are retried with
+// exponential backoff.
+type WebhookSink struct {
+	client       HTTPClient
+	maxAttempts  int
+	retryBackoff time.Duration
+	queueSize    int
+	now          func() time.Time
+
+	subscribers []*webhookSubscriber
+
+	mutex   sync.RWMutex
+	started bool
+	stopped bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+type webhookSubscriber struct {
+	*Subscriber
+	queue chan []*Event
+}
+
+// WebhookOption is a webhook sink option.
+type WebhookOption func(opts *WebhookSink)
+
+// WithHTTPClient sets the HTTP client which is used to post events.
+func WithHTTPClient(client HTTPClient) WebhookOption {
+	return func(opts *WebhookSink) {
+		opts.client = client
+	}
+}
+
+// WithRetries sets the maximum number of delivery attempts and the backoff before the first retry.
+// The backoff is doubled for every subsequent retry.
+func WithRetries(maxAttempts int, backoff time.Duration) WebhookOption {
+	return func(opts *WebhookSink) {
+		opts.maxAttempts = maxAttempts
+		opts.retryBackoff = backoff
+	}
+}
+
+// WithQueueSize sets the maximum number of pending deliveries per subscriber. Events are dropped (and a
+// warning is logged) if the queue of a subscriber is full.
+func WithQueueSize(size int) WebhookOption {
+	return func(opts *WebhookSink) {
+		opts.queueSize = size
+	}
+}
+
+// NewWebhookSink returns a new webhook sink for the given subscribers. The sink has to be started before
+// events are delivered.
+func NewWebhookSink(subscribers []*Subscriber, opts ...WebhookOption) *WebhookSink {
+	s := &WebhookSink{
+		client:       &http.Client{Timeout: defaultTimeout},
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: defaultRetryBackoff,
+		queueSize:    defaultQueueSize,
+		now:          time.Now,
+		stopCh:       make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for _, sub := range subscribers {
+		s.subscribers = append(s.subscribers, &webhookSubscriber{
+			Subscriber: sub,
+			queue:      make(chan []*Event, s.queueSize),
+		})
+	}
+
+	return s
+}
+
+// Start starts the delivery workers.
+func (s *WebhookSink) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started || s.stopped {
+		return
+	}
+
+	s.started = true
+
+	for _, sub := range s.subscribers {
+		s.wg.Add(1)
+
+		go s.deliverAll(sub)
+	}
+}
+
+// Stop stops the delivery workers. Pending retries are abandoned.
+func (s *WebhookSink) Stop() {
+	s.mutex.Lock()
+
+	if s.stopped {
+		s.mutex.Unlock()
+
+		return
+	}
+
+	s.stopped = true
+	close(s.stopCh)
+
+	for _, sub := range s.subscribers {
+		close(sub.queue)
+	}
+
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+}
+
+// Publish queues the events for delivery to the subscribers whose filter matches the events.
+func (s *WebhookSink) Publish(events ...*Event) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.stopped {
+		return ErrSinkStopped
+	}
+
+	for _, sub := range s.subscribers {
+		matching := sub.Filter.Apply(events)
+		if len(matching) == 0 {
+			continue
+		}
+
+		select {
+		case sub.queue <- matching:
+		default:
+			logger.Warnf("Webhook queue for [%s] is full. Dropping %d events.", sub.URL, len(matching))
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookSink) deliverAll(sub *webhookSubscriber) {
+	defer s.wg.Done()
+
+	for events := range sub.queue {
+		s.deliver(sub, events)
+	}
+}
+
+func (s *WebhookSink) deliver(sub *webhookSubscriber, events []*Event) {
+	payload, err := json.Marshal(&WebhookPayload{Events: events})
+	if err != nil {
+		logger.Errorf("Failed to marshal webhook payload: %s", err)
+
+		return
+	}
+
+	backoff := s.retryBackoff
+
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(sub, payload)
+		if err == nil {
+			logger.Debugf("Delivered %d events to [%s]", len(events), sub.URL)
+
+			return
+		}
+
+		if !retry || attempt >= s.maxAttempts {
+			logger.Warnf("Failed to deliver %d events to [%s] after %d attempt(s): %s", len(events), sub.URL, attempt, err)
+
+			return
+		}
+
+		logger.Debugf("Failed to deliver events to [%s] (attempt %d): %s. Retrying in %s", sub.URL, attempt, err, backoff)
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-s.stopCh:
+			logger.Warnf("Webhook sink stopped. Abandoning delivery of %d events to [%s]", len(events), sub.URL)
+
+			return
+		}
+	}
+}
+
+// post posts the payload to the subscriber and returns whether or not a failed delivery should be retried.
+func (s *WebhookSink) post(sub *webhookSubscriber, payload []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(payload)) //nolint:noctx
+	if err != nil {
+		return false, fmt.Errorf("create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	// drain the body so that the connection may be reused
+	_, _ = io.Copy(ioutil.Discard, resp.Body) //nolint:errcheck
+
+	if e := resp.Body.Close(); e != nil {
+		logger.Debugf("Failed to close response body: %s", e)
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+
+	return retry, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
+
+// Sign returns the signature (see SignatureHeader) of the payload.
+func Sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	// hash.Hash never returns an error
+	_, _ = mac.Write([]byte(timestamp + "."))
+	_, _ = mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature verifies the signature (see SignatureHeader) of a webhook payload.
+func VerifySignature(secret []byte, timestamp string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, payload)))
+}
@@ -0,0 +1,284 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink(t *testing.T) {
+	secret := []byte("secret")
+
+	t.Run("success", func(t *testing.T) {
+		receiver := newReceiver(t, secret)
+		defer receiver.Close()
+
+		sink := NewWebhookSink([]*Subscriber{
+			{URL: receiver.URL, Secret: secret},
+			{URL: receiver.URL + "/filtered", Secret: secret, Filter: &Filter{DIDs: []string{"did:sidetree:2"}}},
+		})
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1", DID: "did:sidetree:1"}, &Event{ID: "2", DID: "did:sidetree:2"}))
+		require.NoError(t, sink.Publish(&Event{ID: "3", DID: "did:sidetree:3"}))
+
+		receiver.waitFor(t, 3)
+		sink.Stop()
+
+		require.Equal(t, []string{"1", "2", "3"}, receiver.eventIDs("/"))
+		require.Equal(t, []string{"2"}, receiver.eventIDs("/filtered"))
+
+		require.True(t, errors.Is(sink.Publish(&Event{ID: "4"}), ErrSinkStopped))
+
+		// stopping twice is a no-op
+		sink.Stop()
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		receiver := newReceiver(t, secret)
+		receiver.statuses = []int{http.StatusInternalServerError, http.StatusTooManyRequests}
+
+		defer receiver.Close()
+
+		sink := NewWebhookSink([]*Subscriber{{URL: receiver.URL, Secret: secret}},
+			WithRetries(3, time.Millisecond))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+
+		receiver.waitFor(t, 1)
+		sink.Stop()
+
+		require.Equal(t, int32(3), atomic.LoadInt32(&receiver.attempts))
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		receiver := newReceiver(t, secret)
+		receiver.statuses = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+
+		defer receiver.Close()
+
+		sink := NewWebhookSink([]*Subscriber{{URL: receiver.URL, Secret: secret}},
+			WithRetries(2, time.Millisecond))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+		require.NoError(t, sink.Publish(&Event{ID: "2"}))
+
+		// the first delivery is abandoned after two attempts and the second delivery fails once more
+		receiver.waitForAttempts(t, 4)
+		sink.Stop()
+
+		require.Equal(t, []string{"2"}, receiver.eventIDs("/"))
+	})
+
+	t.Run("no retry for client error", func(t *testing.T) {
+		receiver := newReceiver(t, secret)
+		receiver.statuses = []int{http.StatusBadRequest}
+
+		defer receiver.Close()
+
+		sink := NewWebhookSink([]*Subscriber{{URL: receiver.URL, Secret: secret}},
+			WithRetries(3, time.Millisecond))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+		require.NoError(t, sink.Publish(&Event{ID: "2"}))
+
+		receiver.waitFor(t, 1)
+		sink.Stop()
+
+		require.Equal(t, []string{"2"}, receiver.eventIDs("/"))
+		require.Equal(t, int32(2), atomic.LoadInt32(&receiver.attempts))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		receiver := newReceiver(t, []byte("other secret"))
+		defer receiver.Close()
+
+		sink := NewWebhookSink([]*Subscriber{{URL: receiver.URL, Secret: secret}}, WithRetries(1, time.Millisecond))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+
+		receiver.waitForAttempts(t, 1)
+		sink.Stop()
+
+		require.Empty(t, receiver.eventIDs("/"))
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		client := &mockHTTPClient{err: errors.New("injected network error")}
+
+		sink := NewWebhookSink([]*Subscriber{{URL: "http://localhost", Secret: secret}},
+			WithHTTPClient(client), WithRetries(2, time.Millisecond))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+
+		waitUntil(t, func() bool { return atomic.LoadInt32(&client.calls) == 2 })
+		sink.Stop()
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		client := &mockHTTPClient{}
+
+		sink := NewWebhookSink([]*Subscriber{{URL: "://invalid", Secret: secret}}, WithHTTPClient(client))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+		sink.Stop()
+
+		require.Equal(t, int32(0), atomic.LoadInt32(&client.calls))
+	})
+
+	t.Run("stop abandons retries", func(t *testing.T) {
+		client := &mockHTTPClient{err: errors.New("injected network error")}
+
+		sink := NewWebhookSink([]*Subscriber{{URL: "http://localhost", Secret: secret}},
+			WithHTTPClient(client), WithRetries(5, time.Hour))
+		sink.Start()
+
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+		waitUntil(t, func() bool { return atomic.LoadInt32(&client.calls) == 1 })
+
+		sink.Stop()
+		require.Equal(t, int32(1), atomic.LoadInt32(&client.calls))
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		sink := NewWebhookSink([]*Subscriber{{URL: "http://localhost", Secret: secret}},
+			WithHTTPClient(&mockHTTPClient{}), WithQueueSize(1))
+
+		// the sink is not started so the queue isn't drained
+		require.NoError(t, sink.Publish(&Event{ID: "1"}))
+		require.NoError(t, sink.Publish(&Event{ID: "2"}))
+		require.Len(t, sink.subscribers[0].queue, 1)
+
+		sink.Stop()
+		sink.Start()
+	})
+}
+
+func TestSignature(t *testing.T) {
+	payload := []byte(`{"events":[]}`)
+
+	signature := Sign([]byte("secret"), "1600000000", payload)
+	require.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+
+	require.True(t, VerifySignature([]byte("secret"), "1600000000", payload, signature))
+	require.False(t, VerifySignature([]byte("secret"), "1600000001", payload, signature))
+	require.False(t, VerifySignature([]byte("other"), "1600000000", payload, signature))
+	require.False(t, VerifySignature([]byte("secret"), "1600000000", []byte(`{}`), signature))
+}
+
+type receiver struct {
+	*httptest.Server
+
+	secret   []byte
+	statuses []int
+	attempts int32
+
+	mutex    sync.Mutex
+	received map[string][]*Event
+	count    int
+}
+
+func newReceiver(t *testing.T, secret []byte) *receiver {
+	r := &receiver{secret: secret, received: make(map[string][]*Event)}
+
+	r.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempt := atomic.AddInt32(&r.attempts, 1)
+
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		if !VerifySignature(r.secret, req.Header.Get(TimestampHeader), body, req.Header.Get(SignatureHeader)) {
+			rw.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		if int(attempt) <= len(r.statuses) {
+			rw.WriteHeader(r.statuses[attempt-1])
+
+			return
+		}
+
+		var payload WebhookPayload
+		require.NoError(t, json.Unmarshal(body, &payload))
+
+		r.received[req.URL.Path] = append(r.received[req.URL.Path], payload.Events...)
+		r.count += len(payload.Events)
+	}))
+
+	return r
+}
+
+func (r *receiver) waitFor(t *testing.T, count int) {
+	waitUntil(t, func() bool {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		return r.count >= count
+	})
+}
+
+func (r *receiver) waitForAttempts(t *testing.T, attempts int32) {
+	waitUntil(t, func() bool { return atomic.LoadInt32(&r.attempts) >= attempts })
+}
+
+func (r *receiver) eventIDs(path string) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var ids []string
+	for _, e := range r.received[path] {
+		ids = append(ids, e.ID)
+	}
+
+	return ids
+}
+
+// waitUntil polls the condition until it's true or a second has elapsed.
+func waitUntil(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !condition(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			require.FailNow(t, "condition not satisfied")
+		}
+	}
+}
+
+type mockHTTPClient struct {
+	err   error
+	calls int32
+}
+
+func (m *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	atomic.AddInt32(&m.calls, 1)
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(nil)}, nil
+}
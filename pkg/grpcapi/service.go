@@ -0,0 +1,417 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package grpcapi implements the Sidetree gRPC service (see sidetreepb/sidetree.proto) on top of the
+// document handlers which serve the REST API.
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/grpcapi/sidetreepb"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/discovery"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+var logger = log.New("sidetree-core-grpcapi")
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo detail which is attached to the errors
+// returned by the service.
+const ErrorDomain = "sidetree"
+
+// DocumentHandler processes operations and resolves documents for a namespace.
+type DocumentHandler interface {
+	dochandler.Processor
+	dochandler.Resolver
+}
+
+// Namespace is a namespace which is served by the service.
+type Namespace struct {
+	DocumentHandler DocumentHandler
+	ProtocolClient  protocol.Client
+	Aliases         []string
+}
+
+// Service implements the Sidetree gRPC service.
+//
+// As with the REST API, authentication and authorization (if configured) apply to ProcessOperation only.
+// The authenticators of the REST API are used to authenticate the gRPC call: they are passed an HTTP request
+// which carries the call's metadata as headers (e.g. "authorization"), the TLS connection state of the peer,
+// the full gRPC method name as the path and the operation as the body.
+type Service struct {
+	sidetreepb.UnimplementedSidetreeServer
+
+	namespaces    []*Namespace
+	authenticator auth.Authenticator
+	authorizer    auth.Authorizer
+}
+
+// Option is a service option.
+type Option func(opts *Service)
+
+// WithAuthenticator requires operation requests to be authenticated by the given authenticator.
+func WithAuthenticator(a auth.Authenticator) Option {
+	return func(opts *Service) {
+		opts.authenticator = a
+	}
+}
+
+// WithAuthorizer requires operations to be permitted by the given authorizer. The authorizer is passed
+// the principal returned by the authenticator (or nil if no authenticator is configured).
+func WithAuthorizer(a auth.Authorizer) Option {
+	return func(opts *Service) {
+		opts.authorizer = a
+	}
+}
+
+// New returns a new Sidetree gRPC service for the given namespaces.
+func New(namespaces []*Namespace, opts ...Option) *Service {
+	s := &Service{namespaces: namespaces}
+
+	// apply options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Register registers the service with the given gRPC server.
+func (s *Service) Register(server *grpc.Server) {
+	sidetreepb.RegisterSidetreeServer(server, s)
+}
+
+// ProcessOperation processes a create, update, recover or deactivate operation.
+func (s *Service) ProcessOperation(ctx context.Context,
+	req *sidetreepb.ProcessOperationRequest) (*sidetreepb.ProcessOperationResponse, error) {
+	principal, err := s.authenticate(ctx, req.Operation)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	ns := s.getNamespace(req.Namespace)
+	if ns == nil {
+		return nil, toStatusError(common.NewHTTPError(http.StatusBadRequest,
+			fmt.Errorf("unsupported namespace [%s]", req.Namespace)))
+	}
+
+	currentProtocol, err := ns.ProtocolClient.Current()
+	if err != nil {
+		logger.Errorf("unable to retrieve current protocol: %s", err.Error())
+
+		return nil, toStatusError(fmt.Errorf("%w: %s", protocol.ErrUnavailable, err.Error()))
+	}
+
+	maxSize := currentProtocol.Protocol().MaxOperationSize
+	if maxSize > 0 && uint(len(req.Operation)) > maxSize {
+		return nil, toStatusError(common.NewHTTPError(http.StatusRequestEntityTooLarge,
+			fmt.Errorf("operation size [%d] exceeds maximum operation size [%d]", len(req.Operation), maxSize)))
+	}
+
+	err = s.authorize(principal, ns, req.Operation)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result, err := ns.DocumentHandler.ProcessOperation(req.Operation, currentProtocol.Protocol().GenesisTime)
+	if err != nil {
+		logger.Warnf("[%s] failed to process operation: %s", req.Namespace, err.Error())
+
+		return nil, toStatusError(err)
+	}
+
+	resp := &sidetreepb.ProcessOperationResponse{}
+
+	// the result is nil for deactivate operations
+	if result != nil {
+		resp.Result, err = toResolutionResult(result)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+	}
+
+	return resp, nil
+}
+
+// ResolveDocument resolves a short or long form DID.
+func (s *Service) ResolveDocument(_ context.Context,
+	req *sidetreepb.ResolveDocumentRequest) (*sidetreepb.ResolutionResult, error) {
+	ns := s.getNamespaceForDID(req.Id)
+	if ns == nil {
+		return nil, toStatusError(fmt.Errorf("%w: did must start with a supported namespace", document.ErrInvalidDID))
+	}
+
+	logger.Debugf("Resolving DID document for ID [%s]", req.Id)
+
+	result, err := ns.DocumentHandler.ResolveDocument(req.Id)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp, err := toResolutionResult(result)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return resp, nil
+}
+
+// DiscoverProtocols returns the current and historical protocol parameters for each namespace.
+func (s *Service) DiscoverProtocols(context.Context,
+	*sidetreepb.DiscoverProtocolsRequest) (*sidetreepb.DiscoverProtocolsResponse, error) {
+	namespaces := make([]*discovery.Namespace, len(s.namespaces))
+
+	for i, ns := range s.namespaces {
+		namespaces[i] = &discovery.Namespace{
+			Namespace:      ns.DocumentHandler.Namespace(),
+			Aliases:        ns.Aliases,
+			ProtocolClient: ns.ProtocolClient,
+		}
+	}
+
+	discovered, err := discovery.Discover(namespaces...)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &sidetreepb.DiscoverProtocolsResponse{
+		Namespaces: make([]*sidetreepb.NamespaceInfo, len(discovered.Namespaces)),
+	}
+
+	for i, info := range discovered.Namespaces {
+		resp.Namespaces[i] = &sidetreepb.NamespaceInfo{
+			Namespace: info.Namespace,
+			Aliases:   info.Aliases,
+			Current:   toProtocolInfo(info.Current),
+			Protocols: make([]*sidetreepb.ProtocolInfo, len(info.Protocols)),
+		}
+
+		for j, p := range info.Protocols {
+			resp.Namespaces[i].Protocols[j] = toProtocolInfo(p)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Service) authenticate(ctx context.Context, operation []byte) (*auth.Principal, error) {
+	if s.authenticator == nil {
+		return nil, nil
+	}
+
+	principal, err := s.authenticator.Authenticate(newHTTPRequest(ctx, operation))
+	if err != nil {
+		logger.Warnf("authentication failed: %s", err.Error())
+
+		return nil, err
+	}
+
+	return principal, nil
+}
+
+func (s *Service) authorize(principal *auth.Principal, ns *Namespace, operation []byte) error {
+	if s.authorizer == nil {
+		return nil
+	}
+
+	op, err := auth.ParseOperation(ns.DocumentHandler.Namespace(), operation)
+	if err != nil {
+		return err
+	}
+
+	err = s.authorizer.Authorize(principal, op)
+	if err != nil {
+		logger.Warnf("authorization failed: %s", err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// newHTTPRequest returns an HTTP request which carries the credentials of the gRPC call (metadata and
+// peer TLS connection state) so that the call may be authenticated by the authenticators of the REST API.
+func newHTTPRequest(ctx context.Context, body []byte) *http.Request {
+	method, _ := grpc.Method(ctx)
+
+	req := &http.Request{
+		Method:        http.MethodPost,
+		URL:           &url.URL{Path: method},
+		RequestURI:    method,
+		Proto:         "HTTP/2.0",
+		ProtoMajor:    2,
+		Header:        make(http.Header),
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for name, values := range md {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			req.RemoteAddr = p.Addr.String()
+		}
+
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state := tlsInfo.State
+			req.TLS = &state
+		}
+	}
+
+	return req.WithContext(ctx)
+}
+
+// getNamespace returns the namespace with the given name or alias (nil if not found).
+func (s *Service) getNamespace(name string) *Namespace {
+	for _, ns := range s.namespaces {
+		if ns.DocumentHandler.Namespace() == name || contains(ns.Aliases, name) {
+			return ns
+		}
+	}
+
+	return nil
+}
+
+// getNamespaceForDID returns the namespace that the DID belongs to (nil if not found).
+func (s *Service) getNamespaceForDID(did string) *Namespace {
+	for _, ns := range s.namespaces {
+		for _, name := range append([]string{ns.DocumentHandler.Namespace()}, ns.Aliases...) {
+			if strings.HasPrefix(did, name+docutil.NamespaceDelimiter) {
+				return ns
+			}
+		}
+	}
+
+	return nil
+}
+
+// ErrorCode returns the error code (see common.ErrorCode) of an error which was returned by the service.
+// An empty code is returned if the error doesn't contain an error code.
+func ErrorCode(err error) common.ErrorCode {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == ErrorDomain {
+			return common.ErrorCode(info.Reason)
+		}
+	}
+
+	return ""
+}
+
+// toStatusError maps the error to a gRPC status error in the same way that the REST API maps errors
+// to HTTP errors, i.e. the message is the public message of the error. The error code of the REST API is attached as the reason of an ErrorInfo detail.
+func toStatusError(err error) error {
+	httpErr := common.MapError(err)
+	if httpErr.Status() >= http.StatusInternalServerError {
+		logger.Errorf("server error: %s", err.Error())
+	}
+
+	st := status.New(codeFromHTTPStatus(httpErr.Status()), httpErr.PublicMessage())
+
+	withDetails, e := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(httpErr.Code()),
+		Domain: ErrorDomain,
+	})
+	if e != nil {
+		logger.Warnf("unable to add error details to status: %s", e.Error())
+
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+func codeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusGone:
+		return codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+func toResolutionResult(result *document.ResolutionResult) (*sidetreepb.ResolutionResult, error) {
+	doc, err := json.Marshal(result.Document)
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+
+	return &sidetreepb.ResolutionResult{
+		Context:  result.Context,
+		Document: doc,
+		MethodMetadata: &sidetreepb.MethodMetadata{
+			UpdateCommitment:   result.MethodMetadata.UpdateCommitment,
+			RecoveryCommitment: result.MethodMetadata.RecoveryCommitment,
+			Published:          result.MethodMetadata.Published,
+			CanonicalId:        result.MethodMetadata.CanonicalID,
+		},
+	}, nil
+}
+
+func toProtocolInfo(info *discovery.ProtocolInfo) *sidetreepb.ProtocolInfo {
+	return &sidetreepb.ProtocolInfo{
+		Version:              info.Version,
+		GenesisTime:          info.GenesisTime,
+		MultihashAlgorithm:   uint64(info.MultihashAlgorithm),
+		HashAlgorithm:        uint64(info.HashAlgorithm),
+		MaxOperationCount:    uint64(info.MaxOperationCount),
+		MaxOperationSize:     uint64(info.MaxOperationSize),
+		CompressionAlgorithm: info.CompressionAlgorithm,
+		MaxAnchorFileSize:    uint64(info.MaxAnchorFileSize),
+		MaxMapFileSize:       uint64(info.MaxMapFileSize),
+		MaxChunkFileSize:     uint64(info.MaxChunkFileSize),
+		Patches:              info.Patches,
+		SignatureAlgorithms:  info.SignatureAlgorithms,
+		KeyAlgorithms:        info.KeyAlgorithms,
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
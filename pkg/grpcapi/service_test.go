@@ -0,0 +1,491 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/grpcapi/sidetreepb"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
+)
+
+const (
+	namespace = "did:sidetree"
+	alias     = "did:alias"
+
+	sha2_256 = 18
+
+	validDoc = `{"publicKey":[{"id":"key1","type":"JsonWebKey2020","purpose":["authentication"],` +
+		`"jwk":{"kty":"EC","crv":"P-256","x":"PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",` +
+		`"y":"nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"}}]}`
+)
+
+func TestService_ProcessOperation(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	c := newClient(t, New([]*Namespace{{DocumentHandler: dh, ProtocolClient: pc, Aliases: []string{alias}}}))
+
+	recoveryKey, recoveryCommitment := newKey(t)
+	_, updateCommitment := newKey(t)
+
+	create, err := client.NewCreateRequest(&client.CreateRequestInfo{
+		OpaqueDocument:     validDoc,
+		RecoveryCommitment: recoveryCommitment,
+		UpdateCommitment:   updateCommitment,
+		MultihashCode:      sha2_256,
+	})
+	require.NoError(t, err)
+
+	did := namespace + docutil.NamespaceDelimiter + uniqueSuffix(t, create)
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Result)
+		require.NotNil(t, resp.Result.MethodMetadata)
+
+		doc := make(document.Document)
+		require.NoError(t, json.Unmarshal(resp.Result.Document, &doc))
+		require.Equal(t, did, doc.ID())
+		require.Len(t, doc.PublicKeys(), 1)
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		resp, err := c.ResolveDocument(context.Background(), &sidetreepb.ResolveDocumentRequest{Id: did})
+		require.NoError(t, err)
+
+		doc := make(document.Document)
+		require.NoError(t, json.Unmarshal(resp.Document, &doc))
+		require.Equal(t, did, doc.ID())
+	})
+
+	t.Run("deactivate using alias", func(t *testing.T) {
+		deactivate, err := client.NewDeactivateRequest(&client.DeactivateRequestInfo{
+			DidSuffix:   uniqueSuffix(t, create),
+			RecoveryKey: recoveryKey.jwk,
+			Signer:      recoveryKey.signer,
+		})
+		require.NoError(t, err)
+
+		resp, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: alias, Operation: deactivate})
+		require.NoError(t, err)
+		require.Nil(t, resp.Result)
+
+		_, err = c.ResolveDocument(context.Background(), &sidetreepb.ResolveDocumentRequest{Id: did})
+		requireError(t, err, codes.FailedPrecondition, common.ErrorCodeDeactivated, "document is no longer available")
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: []byte(`{"type":"other"}`)})
+		requireError(t, err, codes.InvalidArgument, common.ErrorCodeInvalidOperation, "operation type [other] not supported")
+	})
+
+	t.Run("unsupported namespace", func(t *testing.T) {
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: "did:other", Operation: create})
+		requireError(t, err, codes.InvalidArgument, common.ErrorCodeBadRequest, "unsupported namespace [did:other]")
+	})
+
+	t.Run("operation too large", func(t *testing.T) {
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{
+				Namespace: namespace,
+				Operation: []byte(strings.Repeat("a", mocks.MaxOperationByteSize+1)),
+			})
+		requireError(t, err, codes.ResourceExhausted, common.ErrorCodeRequestTooLarge, "exceeds maximum operation size")
+	})
+
+	t.Run("protocol error", func(t *testing.T) {
+		errPC := mocks.NewMockProtocolClient()
+		errPC.Err = errors.New("injected protocol error")
+
+		c := newClient(t, New([]*Namespace{{DocumentHandler: dh, ProtocolClient: errPC}}))
+
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.Unavailable, common.ErrorCodeProtocolUnavailable, "Service Unavailable")
+	})
+
+	t.Run("processor error", func(t *testing.T) {
+		errDH := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc).
+			WithError(errors.New("injected processor error"))
+
+		c := newClient(t, New([]*Namespace{{DocumentHandler: errDH, ProtocolClient: pc}}))
+
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.Internal, common.ErrorCodeInternal, "Internal Server Error")
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		errDH := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc).
+			WithError(auth.ErrForbidden)
+
+		c := newClient(t, New([]*Namespace{{DocumentHandler: errDH, ProtocolClient: pc}}))
+
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.PermissionDenied, common.ErrorCodeForbidden, "forbidden")
+	})
+}
+
+func TestService_ResolveDocument(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	c := newClient(t, New([]*Namespace{{DocumentHandler: dh, ProtocolClient: pc}}))
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.ResolveDocument(context.Background(), &sidetreepb.ResolveDocumentRequest{Id: namespace + ":unknown"})
+		requireError(t, err, codes.NotFound, common.ErrorCodeNotFound, "document not found")
+	})
+
+	t.Run("unsupported namespace", func(t *testing.T) {
+		_, err := c.ResolveDocument(context.Background(), &sidetreepb.ResolveDocumentRequest{Id: "did:other:123"})
+		requireError(t, err, codes.InvalidArgument, common.ErrorCodeInvalidDID, "did must start with a supported namespace")
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		_, err := toResolutionResult(&document.ResolutionResult{
+			Document: document.Document{"invalid": make(chan int)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal document")
+	})
+}
+
+func TestService_DiscoverProtocols(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	t.Run("success", func(t *testing.T) {
+		c := newClient(t, New([]*Namespace{{DocumentHandler: dh, ProtocolClient: pc, Aliases: []string{alias}}}))
+
+		resp, err := c.DiscoverProtocols(context.Background(), &sidetreepb.DiscoverProtocolsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Namespaces, 1)
+
+		info := resp.Namespaces[0]
+		require.Equal(t, namespace, info.Namespace)
+		require.Equal(t, []string{alias}, info.Aliases)
+		require.Equal(t, mocks.CurrentVersion, info.Current.Version)
+		require.Equal(t, uint64(mocks.MaxOperationByteSize), info.Current.MaxOperationSize)
+		require.Equal(t, uint64(sha2_256), info.Current.MultihashAlgorithm)
+		require.Equal(t, pc.Protocol.Patches, info.Current.Patches)
+		require.Equal(t, pc.Protocol.KeyAlgorithms, info.Current.KeyAlgorithms)
+		require.Len(t, info.Protocols, 1)
+	})
+
+	t.Run("protocol error", func(t *testing.T) {
+		errPC := mocks.NewMockProtocolClient()
+		errPC.Err = errors.New("injected protocol error")
+
+		c := newClient(t, New([]*Namespace{{DocumentHandler: dh, ProtocolClient: errPC}}))
+
+		_, err := c.DiscoverProtocols(context.Background(), &sidetreepb.DiscoverProtocolsRequest{})
+		requireError(t, err, codes.Unavailable, common.ErrorCodeProtocolUnavailable, "Service Unavailable")
+	})
+}
+
+func TestService_Auth(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	authenticator := auth.Chain(
+		auth.NewTokenAuthenticator(map[string]*auth.Principal{
+			"token1": {ID: "alice", Tenant: "tenant1"},
+			"token2": {ID: "bob", Tenant: "tenant2"},
+		}),
+		auth.NewClientCertAuthenticator(map[string]*auth.Principal{
+			"carol": {ID: "carol", Tenant: "tenant1"},
+		}),
+	)
+
+	s := New([]*Namespace{{DocumentHandler: dh, ProtocolClient: pc}},
+		WithAuthenticator(authenticator),
+		WithAuthorizer(auth.NewTenantPolicy(map[string][]operation.Type{
+			"tenant1": {operation.TypeCreate},
+		})),
+	)
+
+	c := newClient(t, s)
+
+	recoveryKey, recoveryCommitment := newKey(t)
+	_, updateCommitment := newKey(t)
+
+	create, err := client.NewCreateRequest(&client.CreateRequestInfo{
+		OpaqueDocument:     validDoc,
+		RecoveryCommitment: recoveryCommitment,
+		UpdateCommitment:   updateCommitment,
+		MultihashCode:      sha2_256,
+	})
+	require.NoError(t, err)
+
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+
+	t.Run("no credentials", func(t *testing.T) {
+		_, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.Unauthenticated, common.ErrorCodeUnauthorized, "no credentials")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := c.ProcessOperation(withToken("token3"),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.Unauthenticated, common.ErrorCodeUnauthorized, "unauthorized")
+	})
+
+	t.Run("tenant not permitted", func(t *testing.T) {
+		_, err := c.ProcessOperation(withToken("token2"),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.PermissionDenied, common.ErrorCodeForbidden, "forbidden")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp, err := c.ProcessOperation(withToken("token1"),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Result)
+	})
+
+	t.Run("operation type not permitted", func(t *testing.T) {
+		deactivate, err := client.NewDeactivateRequest(&client.DeactivateRequestInfo{
+			DidSuffix:   uniqueSuffix(t, create),
+			RecoveryKey: recoveryKey.jwk,
+			Signer:      recoveryKey.signer,
+		})
+		require.NoError(t, err)
+
+		_, err = c.ProcessOperation(withToken("token1"),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: deactivate})
+		requireError(t, err, codes.PermissionDenied, common.ErrorCodeForbidden, "may not submit deactivate operations")
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		_, err := c.ProcessOperation(withToken("token1"),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: []byte("{")})
+		requireError(t, err, codes.InvalidArgument, common.ErrorCodeInvalidOperation, "invalid operation")
+	})
+
+	t.Run("resolution is public", func(t *testing.T) {
+		_, err := c.ResolveDocument(context.Background(),
+			&sidetreepb.ResolveDocumentRequest{Id: namespace + docutil.NamespaceDelimiter + uniqueSuffix(t, create)})
+		require.NoError(t, err)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		c := newTLSClient(t, s, newCertificate(t, "carol"))
+
+		resp, err := c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Result)
+
+		c = newTLSClient(t, s, newCertificate(t, "dave"))
+
+		_, err = c.ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: namespace, Operation: create})
+		requireError(t, err, codes.Unauthenticated, common.ErrorCodeUnauthorized, "unknown client certificate subject [dave]")
+	})
+
+	t.Run("authorizer without authenticator", func(t *testing.T) {
+		var authorized *auth.Operation
+
+		s := New([]*Namespace{{DocumentHandler: dh, ProtocolClient: pc, Aliases: []string{alias}}},
+			WithAuthorizer(auth.AuthorizerFunc(func(principal *auth.Principal, op *auth.Operation) error {
+				require.Nil(t, principal)
+				authorized = op
+
+				return nil
+			})),
+		)
+
+		_, err := newClient(t, s).ProcessOperation(context.Background(),
+			&sidetreepb.ProcessOperationRequest{Namespace: alias, Operation: create})
+		require.NoError(t, err)
+		require.Equal(t, &auth.Operation{Namespace: namespace, Type: operation.TypeCreate}, authorized)
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	require.Equal(t, common.ErrorCodeNotFound, ErrorCode(toStatusError(document.ErrNotFound)))
+	require.Equal(t, common.ErrorCodeUnauthorized, ErrorCode(toStatusError(auth.ErrUnauthorized)))
+	require.Equal(t, common.ErrorCode(""), ErrorCode(status.Error(codes.Internal, "other")))
+	require.Equal(t, common.ErrorCode(""), ErrorCode(errors.New("other")))
+
+	require.Equal(t, codes.Unauthenticated, status.Code(toStatusError(auth.ErrUnauthorized)))
+}
+
+// newClient starts the service on an in-process bufconn listener and returns a client which is connected to it.
+func newClient(t *testing.T, s *Service) sidetreepb.SidetreeClient {
+	t.Helper()
+
+	return dial(t, s, nil, grpc.WithInsecure())
+}
+
+// newTLSClient is like newClient except that the client is connected using mutual TLS with the given certificate.
+func newTLSClient(t *testing.T, s *Service, cert tls.Certificate) sidetreepb.SidetreeClient {
+	t.Helper()
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	serverCreds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	clientCreds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   "bufnet",
+		MinVersion:   tls.VersionTLS12,
+	})
+
+	return dial(t, s, []grpc.ServerOption{grpc.Creds(serverCreds)}, grpc.WithTransportCredentials(clientCreds))
+}
+
+func dial(t *testing.T, s *Service, serverOpts []grpc.ServerOption, creds grpc.DialOption) sidetreepb.SidetreeClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(serverOpts...)
+	s.Register(server)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			t.Logf("server stopped: %s", err)
+		}
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		creds)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+		server.Stop()
+	})
+
+	return sidetreepb.NewSidetreeClient(conn)
+}
+
+func requireError(t *testing.T, err error, code codes.Code, errorCode common.ErrorCode, msg string) {
+	t.Helper()
+
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err))
+	require.Equal(t, errorCode, ErrorCode(err))
+	require.Contains(t, status.Convert(err).Message(), msg)
+	require.NotContains(t, status.Convert(err).Message(), "injected")
+}
+
+// newCertificate returns a self-signed certificate for the given common name which may be used by both
+// the server ("bufnet") and the client.
+func newCertificate(t *testing.T, commonName string) tls.Certificate {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{"bufnet"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: privateKey}
+}
+
+type key struct {
+	jwk    *jws.JWK
+	signer client.Signer
+}
+
+func newKey(t *testing.T) (*key, string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	c, err := commitment.Calculate(jwk, sha2_256, crypto.SHA256)
+	require.NoError(t, err)
+
+	return &key{jwk: jwk, signer: ecsigner.New(privateKey, "ES256", "")}, c
+}
+
+func uniqueSuffix(t *testing.T, create []byte) string {
+	t.Helper()
+
+	var createReq model.CreateRequest
+	require.NoError(t, json.Unmarshal(create, &createReq))
+
+	suffix, err := docutil.CalculateModelMultihash(createReq.SuffixData, sha2_256)
+	require.NoError(t, err)
+
+	return suffix
+}
@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package sidetreepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// SidetreeClient is the client API for Sidetree service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SidetreeClient interface {
+	// ProcessOperation processes a create, update, recover or deactivate operation.
+	ProcessOperation(ctx context.Context, in *ProcessOperationRequest, opts ...grpc.CallOption) (*ProcessOperationResponse, error)
+	// ResolveDocument resolves a short or long form DID.
+	ResolveDocument(ctx context.Context, in *ResolveDocumentRequest, opts ...grpc.CallOption) (*ResolutionResult, error)
+	// DiscoverProtocols returns the current and historical protocol parameters for each namespace.
+	DiscoverProtocols(ctx context.Context, in *DiscoverProtocolsRequest, opts ...grpc.CallOption) (*DiscoverProtocolsResponse, error)
+}
+
+type sidetreeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSidetreeClient(cc grpc.ClientConnInterface) SidetreeClient {
+	return &sidetreeClient{cc}
+}
+
+func (c *sidetreeClient) ProcessOperation(ctx context.Context, in *ProcessOperationRequest, opts ...grpc.CallOption) (*ProcessOperationResponse, error) {
+	out := new(ProcessOperationResponse)
+	err := c.cc.Invoke(ctx, "/sidetree.v1.Sidetree/ProcessOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidetreeClient) ResolveDocument(ctx context.Context, in *ResolveDocumentRequest, opts ...grpc.CallOption) (*ResolutionResult, error) {
+	out := new(ResolutionResult)
+	err := c.cc.Invoke(ctx, "/sidetree.v1.Sidetree/ResolveDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sidetreeClient) DiscoverProtocols(ctx context.Context, in *DiscoverProtocolsRequest, opts ...grpc.CallOption) (*DiscoverProtocolsResponse, error) {
+	out := new(DiscoverProtocolsResponse)
+	err := c.cc.Invoke(ctx, "/sidetree.v1.Sidetree/DiscoverProtocols", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SidetreeServer is the server API for Sidetree service.
+// All implementations must embed UnimplementedSidetreeServer
+// for forward compatibility
+type SidetreeServer interface {
+	// ProcessOperation processes a create, update, recover or deactivate operation.
+	ProcessOperation(context.Context, *ProcessOperationRequest) (*ProcessOperationResponse, error)
+	// ResolveDocument resolves a short or long form DID.
+	ResolveDocument(context.Context, *ResolveDocumentRequest) (*ResolutionResult, error)
+	// DiscoverProtocols returns the current and historical protocol parameters for each namespace.
+	DiscoverProtocols(context.Context, *DiscoverProtocolsRequest) (*DiscoverProtocolsResponse, error)
+	mustEmbedUnimplementedSidetreeServer()
+}
+
+// UnimplementedSidetreeServer must be embedded to have forward compatible implementations.
+type UnimplementedSidetreeServer struct {
+}
+
+func (UnimplementedSidetreeServer) ProcessOperation(context.Context, *ProcessOperationRequest) (*ProcessOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessOperation not implemented")
+}
+func (UnimplementedSidetreeServer) ResolveDocument(context.Context, *ResolveDocumentRequest) (*ResolutionResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDocument not implemented")
+}
+func (UnimplementedSidetreeServer) DiscoverProtocols(context.Context, *DiscoverProtocolsRequest) (*DiscoverProtocolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscoverProtocols not implemented")
+}
+func (UnimplementedSidetreeServer) mustEmbedUnimplementedSidetreeServer() {}
+
+// UnsafeSidetreeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SidetreeServer will
+// result in compilation errors.
+type UnsafeSidetreeServer interface {
+	mustEmbedUnimplementedSidetreeServer()
+}
+
+func RegisterSidetreeServer(s grpc.ServiceRegistrar, srv SidetreeServer) {
+	s.RegisterService(&_Sidetree_serviceDesc, srv)
+}
+
+func _Sidetree_ProcessOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidetreeServer).ProcessOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sidetree.v1.Sidetree/ProcessOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidetreeServer).ProcessOperation(ctx, req.(*ProcessOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidetree_ResolveDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidetreeServer).ResolveDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sidetree.v1.Sidetree/ResolveDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidetreeServer).ResolveDocument(ctx, req.(*ResolveDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sidetree_DiscoverProtocols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverProtocolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SidetreeServer).DiscoverProtocols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sidetree.v1.Sidetree/DiscoverProtocols",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SidetreeServer).DiscoverProtocols(ctx, req.(*DiscoverProtocolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Sidetree_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sidetree.v1.Sidetree",
+	HandlerType: (*SidetreeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessOperation",
+			Handler:    _Sidetree_ProcessOperation_Handler,
+		},
+		{
+			MethodName: "ResolveDocument",
+			Handler:    _Sidetree_ResolveDocument_Handler,
+		},
+		{
+			MethodName: "DiscoverProtocols",
+			Handler:    _Sidetree_DiscoverProtocols_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sidetree.proto",
+}
@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsoncanonicalizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// benchmarkServiceCounts are the numbers of services in the generated documents.
+var benchmarkServiceCounts = []int{1, 100, 1000}
+
+func BenchmarkTransform(b *testing.B) {
+	for _, numServices := range benchmarkServiceCounts {
+		docBytes := getDocumentBytes(b, numServices)
+
+		b.Run(fmt.Sprintf("%d services", numServices), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(docBytes)))
+
+			for i := 0; i < b.N; i++ {
+				if _, err := Transform(docBytes); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func getDocumentBytes(b *testing.B, numServices int) []byte {
+	b.Helper()
+
+	var services []map[string]interface{}
+
+	for i := 0; i < numServices; i++ {
+		services = append(services, map[string]interface{}{
+			"id":              fmt.Sprintf("service%d", i),
+			"type":            "service",
+			"serviceEndpoint": fmt.Sprintf("https://example.com/%d", i),
+			"priority":        i,
+		})
+	}
+
+	docBytes, err := json.Marshal(map[string]interface{}{
+		"publicKeys": []map[string]interface{}{{
+			"id":      "key1",
+			"type":    "JsonWebKey2020",
+			"purpose": []string{"auth", "general"},
+			"jwk": map[string]interface{}{
+				"kty": "EC",
+				"crv": "P-256",
+				"x":   "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+				"y":   "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc",
+			},
+		}},
+		"services": services,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return docBytes
+}
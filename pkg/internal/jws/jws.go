@@ -97,6 +97,11 @@ func (s JSONWebSignature) Signature() []byte {
 	return sCopy
 }
 
+// SigningInput returns the JWS signing input (https://tools.ietf.org/html/rfc7515#section-5.2).
+func (s JSONWebSignature) SigningInput() ([]byte, error) {
+	return signingInput(s.ProtectedHeaders, s.Payload)
+}
+
 func mergeHeaders(h1, h2 jws.Headers) jws.Headers {
 	h := make(jws.Headers, len(h1)+len(h2))
 
@@ -169,6 +174,13 @@ func VerifyJWS(jws string, jwk *jws.JWK, opts ...ParseOpt) (*JSONWebSignature, e
 		return nil, err
 	}
 
+	alg, _ := parsedJWS.ProtectedHeaders.Algorithm()
+
+	err = checkAlgorithm(alg, jwk)
+	if err != nil {
+		return nil, err
+	}
+
 	sInput, err := signingInput(parsedJWS.ProtectedHeaders, parsedJWS.Payload)
 	if err != nil {
 		return nil, fmt.Errorf("build signing input: %w", err)
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
@@ -253,6 +254,117 @@ func TestParseJWS_ED25519(t *testing.T) {
 	require.Equal(t, jws, parsedJWS)
 }
 
+func TestVerifyJWS_EllipticCurves(t *testing.T) {
+	tests := []struct {
+		curve elliptic.Curve
+		alg   string
+	}{
+		{elliptic.P256(), "ES256"},
+		{elliptic.P384(), "ES384"},
+		{elliptic.P521(), "ES512"},
+		{btcec.S256(), "ES256K"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.alg, func(t *testing.T) {
+			privateKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+			require.NoError(t, err)
+
+			jwk, err := getPublicKeyJWK(&privateKey.PublicKey)
+			require.NoError(t, err)
+
+			signer := ecsigner.New(privateKey, tc.alg, "key-1")
+			jws, err := NewJWS(signer.Headers(), nil, []byte("payload"), signer)
+			require.NoError(t, err)
+
+			jwsCompact, err := jws.SerializeCompact(false)
+			require.NoError(t, err)
+
+			parsedJWS, err := VerifyJWS(jwsCompact, jwk)
+			require.NoError(t, err)
+			require.Equal(t, jws, parsedJWS)
+
+			// the algorithm has to match the curve of the key
+			signer = ecsigner.New(privateKey, "ES256", "key-1")
+			if tc.alg == "ES256" {
+				signer = ecsigner.New(privateKey, "ES384", "key-1")
+			}
+
+			jws, err = NewJWS(signer.Headers(), nil, []byte("payload"), signer)
+			require.NoError(t, err)
+
+			jwsCompact, err = jws.SerializeCompact(false)
+			require.NoError(t, err)
+
+			parsedJWS, err = VerifyJWS(jwsCompact, jwk)
+			require.Error(t, err)
+			require.Nil(t, parsedJWS)
+			require.Contains(t, err.Error(), "cannot be used with '"+jwk.Crv+"' key; expected '"+tc.alg+"'")
+		})
+	}
+}
+
+// TestVerifyJWS_TestVectors verifies signatures which were created by other implementations.
+func TestVerifyJWS_TestVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		jwk  *jws.JWK
+		jws  string
+	}{
+		{
+			// RFC 7515, appendix A.4
+			name: "ES512",
+			jwk: &jws.JWK{
+				Kty: "EC",
+				Crv: "P-521",
+				X:   "AekpBQ8ST8a8VcfVOTNl353vSrDCLLJXmPk06wTjxrrjcBpXp5EOnYG_NjFZ6OvLFV1jSfS9tsz4qUxcWceqwQGk",
+				Y:   "ADSmRA43Z1DSNx_RvcLI87cdL07l6jQyyBXMoxVg_l2Th-x3S1WDhjDly79ajL4Kkd0AZMaZmh9ubmf63e3kyMj2",
+			},
+			jws: "eyJhbGciOiJFUzUxMiJ9.UGF5bG9hZA." +
+				"AdwMgeerwtHoh-l192l60hp9wAHZFVJbLfD_UxMi70cwnZOYaRI1bKPWROc-mZZqwqT2SI-KGDKB34XO0aw_7XdtAG8" +
+				"GaSwFKdCAPZgoXD2YBJZCPEX3xKpRwcdOO8KpEHwJjyqOgzDO7iKvU8vcnwNrmxYbSW9ERBXukOXolLzeO_Jn",
+		},
+		{
+			// signed with OpenSSL 3.0 (openssl dgst -sha384 -sign)
+			name: "ES384",
+			jwk: &jws.JWK{
+				Kty: "EC",
+				Crv: "P-384",
+				X:   "xh-auOSfoVQPKcJe1CAkpRPXb9xG0a-ZQMiZq7BNDHhujZ68vRInavOvN2NPXzVv",
+				Y:   "IRYp2_YgYkSWp11EDcDmP_3xYn0bNVqG2QFFj-MGOWZ8DEKQ8hdkk_0qx5i8I2H9",
+			},
+			jws: "eyJhbGciOiJFUzM4NCJ9." +
+				"eyJkaWRTdWZmaXgiOiJFaUR5T1FiYlpBYTNhaVJ6ZUNrVjdMT3gzU0VSampIOTNFWG9JTTNVb040b1dnIn0." +
+				"3ai_FP_vln9IavL7V0J5nTYxrmxdh8_KoBQU0t85QtsHWimXizWtkbyiaRJ2vRYJYvQrD5uktcra3xqUI7ModczdEMoM" +
+				"oUF3iibudrHCPGr1acmnTVe98gA0R0XY5k8k",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			parsedJWS, err := VerifyJWS(tc.jws, tc.jwk)
+			require.NoError(t, err)
+			require.NotNil(t, parsedJWS)
+
+			alg, ok := parsedJWS.ProtectedHeaders.Algorithm()
+			require.True(t, ok)
+			require.Equal(t, tc.name, alg)
+
+			// tampered payload
+			parts := strings.Split(tc.jws, ".")
+			tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte("tampered")) + "." + parts[2]
+
+			_, err = VerifyJWS(tampered, tc.jwk)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "ecdsa: invalid signature")
+		})
+	}
+}
+
 func TestIsCompactJWS(t *testing.T) {
 	require.True(t, IsCompactJWS("a.b.c"))
 	require.False(t, IsCompactJWS("a.b"))
@@ -26,6 +26,8 @@ const (
 	p384KeySize      = 48
 	p521KeySize      = 66
 	secp256k1KeySize = 32
+
+	edDSAAlgorithm = "EdDSA"
 )
 
 // VerifySignature verifies signature against public key in JWK format.
@@ -40,6 +42,32 @@ func VerifySignature(jwk *jws.JWK, signature, msg []byte) error {
 	}
 }
 
+// checkAlgorithm checks that the signature algorithm matches the key (RFC 7518 section 3.4 and RFC 8037 section 3.1),
+// e.g. ES384 signatures must be created with a P-384 key and SHA-384.
+func checkAlgorithm(alg string, jwk *jws.JWK) error {
+	var expected string
+
+	switch jwk.Kty {
+	case "EC":
+		ec := parseEllipticCurve(jwk.Crv)
+		if ec == nil {
+			return fmt.Errorf("ecdsa: unsupported elliptic curve '%s'", jwk.Crv)
+		}
+
+		expected = ec.alg
+	case "OKP":
+		expected = edDSAAlgorithm
+	default:
+		return fmt.Errorf("'%s' key type is not supported for verifying signature", jwk.Kty)
+	}
+
+	if alg != expected {
+		return fmt.Errorf("algorithm '%s' cannot be used with '%s' key; expected '%s'", alg, jwk.Crv, expected)
+	}
+
+	return nil
+}
+
 func verifyEd25519Signature(jwk *jws.JWK, signature, msg []byte) error {
 	pubKey, err := GetED25519PublicKey(jwk)
 	if err != nil {
@@ -134,6 +162,7 @@ type ellipticCurve struct {
 	curve   elliptic.Curve
 	keySize int
 	hash    crypto.Hash
+	alg     string
 }
 
 func parseEllipticCurve(curve string) *ellipticCurve {
@@ -143,24 +172,28 @@ func parseEllipticCurve(curve string) *ellipticCurve {
 			curve:   elliptic.P256(),
 			keySize: p256KeySize,
 			hash:    crypto.SHA256,
+			alg:     "ES256",
 		}
 	case "P-384":
 		return &ellipticCurve{
 			curve:   elliptic.P384(),
 			keySize: p384KeySize,
 			hash:    crypto.SHA384,
+			alg:     "ES384",
 		}
 	case "P-521":
 		return &ellipticCurve{
 			curve:   elliptic.P521(),
 			keySize: p521KeySize,
 			hash:    crypto.SHA512,
+			alg:     "ES512",
 		}
 	case "secp256k1":
 		return &ellipticCurve{
 			curve:   btcec.S256(),
 			keySize: secp256k1KeySize,
 			hash:    crypto.SHA256,
+			alg:     "ES256K",
 		}
 	default:
 		return nil
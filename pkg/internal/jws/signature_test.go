@@ -112,6 +112,34 @@ func TestVerifySignature(t *testing.T) {
 	})
 }
 
+func TestCheckAlgorithm(t *testing.T) {
+	tests := []struct {
+		crv string
+		kty string
+		alg string
+	}{
+		{"P-256", "EC", "ES256"},
+		{"P-384", "EC", "ES384"},
+		{"P-521", "EC", "ES512"},
+		{"secp256k1", "EC", "ES256K"},
+		{"Ed25519", "OKP", "EdDSA"},
+	}
+
+	for _, tc := range tests {
+		require.NoError(t, checkAlgorithm(tc.alg, &jws.JWK{Kty: tc.kty, Crv: tc.crv}))
+
+		err := checkAlgorithm("ES999", &jws.JWK{Kty: tc.kty, Crv: tc.crv})
+		require.EqualError(t, err,
+			fmt.Sprintf("algorithm 'ES999' cannot be used with '%s' key; expected '%s'", tc.crv, tc.alg))
+	}
+
+	err := checkAlgorithm("ES256", &jws.JWK{Kty: "EC", Crv: "P-512"})
+	require.EqualError(t, err, "ecdsa: unsupported elliptic curve 'P-512'")
+
+	err = checkAlgorithm("RS256", &jws.JWK{Kty: "RSA"})
+	require.EqualError(t, err, "'RSA' key type is not supported for verifying signature")
+}
+
 func TestVerifyECSignature(t *testing.T) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package signutil
 
 import (
+	"context"
 	"errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
@@ -23,6 +24,38 @@ type Signer interface {
 	Headers() jws.Headers
 }
 
+// ContextSigner is a signer which binds signing to a context (e.g. a remote signer whose requests can be
+// cancelled).
+type ContextSigner interface {
+	Signer
+
+	// SignContext signs data and returns signature value. Signing is abandoned when the context is done.
+	SignContext(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// SignModelWithContext signs model. If the signer is a ContextSigner then signing is bound to the given context.
+func SignModelWithContext(ctx context.Context, model interface{}, signer Signer) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	if cs, ok := signer.(ContextSigner); ok {
+		signer = &boundSigner{ContextSigner: cs, ctx: ctx}
+	}
+
+	return SignModel(model, signer)
+}
+
+// boundSigner signs using the context signer with the given context.
+type boundSigner struct {
+	ContextSigner
+	ctx context.Context
+}
+
+func (s *boundSigner) Sign(data []byte) ([]byte, error) {
+	return s.SignContext(s.ctx, data)
+}
+
 // SignModel signs model.
 func SignModel(model interface{}, signer Signer) (string, error) {
 	// first you normalize model
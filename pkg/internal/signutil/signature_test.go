@@ -1,6 +1,7 @@
 package signutil
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -41,6 +42,56 @@ func TestSignModel(t *testing.T) {
 	})
 }
 
+func TestSignModelWithContext(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer := ecsigner.New(privateKey, "ES256", "key-1")
+
+	t.Run("success", func(t *testing.T) {
+		request, err := SignModelWithContext(context.Background(), map[string]string{"message": "test"}, signer)
+		require.NoError(t, err)
+		require.NotEmpty(t, request)
+	})
+
+	t.Run("success - context signer", func(t *testing.T) {
+		cs := &contextSigner{Signer: signer}
+
+		request, err := SignModelWithContext(context.Background(), map[string]string{"message": "test"}, cs)
+		require.NoError(t, err)
+		require.NotEmpty(t, request)
+		require.Equal(t, 1, cs.calls)
+	})
+
+	t.Run("error - context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		cs := &contextSigner{Signer: signer}
+
+		request, err := SignModelWithContext(ctx, map[string]string{"message": "test"}, cs)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Empty(t, request)
+		require.Equal(t, 0, cs.calls)
+	})
+}
+
+// contextSigner counts the calls to SignContext.
+type contextSigner struct {
+	Signer
+	calls int
+}
+
+func (s *contextSigner) SignContext(ctx context.Context, data []byte) ([]byte, error) {
+	s.calls++
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.Sign(data)
+}
+
 func TestSignPayload(t *testing.T) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -6,7 +6,19 @@ SPDX-License-Identifier: Apache-2.0
 
 package jws
 
-import "errors"
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	keyTypeEC  = "EC"
+	keyTypeOKP = "OKP"
+	keyTypeRSA = "RSA"
+)
 
 // JWK contains public key in JWK format.
 type JWK struct {
@@ -14,10 +26,44 @@ type JWK struct {
 	Crv string `json:"crv"`
 	X   string `json:"x"`
 	Y   string `json:"y"`
+
+	// N and E are the modulus and exponent of an RSA public key.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// hasPrivateKey is set if the JWK was unmarshalled from JSON which contains the private key member (d).
+	// The private key itself is never retained.
+	hasPrivateKey bool
+}
+
+// UnmarshalJSON unmarshals the JWK and records whether it contains the private key member.
+func (jwk *JWK) UnmarshalJSON(data []byte) error {
+	type publicJWK JWK
+
+	raw := struct {
+		*publicJWK
+		D *json.RawMessage `json:"d"`
+	}{publicJWK: (*publicJWK)(jwk)}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	jwk.hasPrivateKey = raw.D != nil
+
+	return nil
 }
 
 // Validate validates JWK.
 func (jwk *JWK) Validate() error {
+	if jwk.hasPrivateKey {
+		return errors.New("JWK must not contain the private key (d)")
+	}
+
+	if jwk.Kty == keyTypeRSA {
+		return jwk.validateRSA()
+	}
+
 	if jwk.Crv == "" {
 		return errors.New("JWK crv is missing")
 	}
@@ -32,3 +78,55 @@ func (jwk *JWK) Validate() error {
 
 	return nil
 }
+
+func (jwk *JWK) validateRSA() error {
+	if jwk.N == "" {
+		return errors.New("JWK n is missing")
+	}
+
+	if jwk.E == "" {
+		return errors.New("JWK e is missing")
+	}
+
+	return nil
+}
+
+// Thumbprint returns the JWK thumbprint (RFC 7638) of the key, computed using SHA-256 and base64url encoded.
+func (jwk *JWK) Thumbprint() (string, error) {
+	input, err := jwk.ThumbprintInput()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(input)
+
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+// ThumbprintInput returns the JSON object of the required members of the key (in lexicographic order and
+// without whitespace) which is hashed to compute the JWK thumbprint (RFC 7638). EC (including secp256k1),
+// OKP and RSA keys are supported.
+func (jwk *JWK) ThumbprintInput() ([]byte, error) {
+	var members map[string]string
+
+	switch jwk.Kty {
+	case keyTypeEC:
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X, "y": jwk.Y}
+	case keyTypeOKP:
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X}
+	case keyTypeRSA:
+		members = map[string]string{"e": jwk.E, "kty": jwk.Kty, "n": jwk.N}
+	default:
+		return nil, fmt.Errorf("JWK thumbprint not supported for key type [%s]", jwk.Kty)
+	}
+
+	for name, value := range members {
+		if value == "" {
+			return nil, fmt.Errorf("JWK %s is missing", name)
+		}
+	}
+
+	// maps are marshalled with sorted keys and the member values are base64url or plain ASCII strings,
+	// so the result is the canonical form that is required by RFC 7638
+	return json.Marshal(members)
+}
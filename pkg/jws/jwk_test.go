@@ -1,8 +1,16 @@
 package jws
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,4 +59,117 @@ func TestValidate(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "x is missing")
 	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		jwk := JWK{
+			Kty: "RSA",
+			N:   "n",
+			E:   "AQAB",
+		}
+
+		require.NoError(t, jwk.Validate())
+
+		jwk.E = ""
+		require.EqualError(t, jwk.Validate(), "JWK e is missing")
+
+		jwk.N = ""
+		require.EqualError(t, jwk.Validate(), "JWK n is missing")
+	})
+}
+
+func TestValidate_PrivateKey(t *testing.T) {
+	jwk := &JWK{}
+	err := json.Unmarshal([]byte(`{"kty":"OKP","crv":"Ed25519","x":"x","d":"d"}`), jwk)
+	require.NoError(t, err)
+	require.EqualError(t, jwk.Validate(), "JWK must not contain the private key (d)")
+
+	// the private key is never marshalled
+	bytes, err := json.Marshal(jwk)
+	require.NoError(t, err)
+	require.NotContains(t, string(bytes), `"d"`)
+
+	jwk = &JWK{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kty":"OKP","crv":"Ed25519","x":"x"}`), jwk))
+	require.NoError(t, jwk.Validate())
+
+	require.Error(t, json.Unmarshal([]byte(`{"kty":1}`), jwk))
+}
+
+func TestThumbprint(t *testing.T) {
+	t.Run("EC", func(t *testing.T) {
+		for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+			privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+
+			requireThumbprint(t, &privateKey.PublicKey)
+		}
+	})
+
+	t.Run("secp256k1", func(t *testing.T) {
+		thumbprint, err := (&JWK{
+			Kty: "EC",
+			Crv: "secp256k1",
+			X:   "QxmTNJcS0h5HdwMLjm9oAJxjqvqWfZ1UqfkkKO-4w28",
+			Y:   "U2E3XpGKe4RQoeAr1_8jtgWBPHF1vYJx69cB2Gsx8fw",
+		}).Thumbprint()
+		require.NoError(t, err)
+		require.Equal(t, "HJapaVnQSmBCZjFsaMo4Vi_Lp-UPh0vU0PKJ9Kp_B2k", thumbprint)
+	})
+
+	t.Run("OKP", func(t *testing.T) {
+		// Test vector from RFC 8037 (Appendix A.3)
+		thumbprint, err := (&JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+		}).Thumbprint()
+		require.NoError(t, err)
+		require.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", thumbprint)
+	})
+
+	t.Run("RSA", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		requireThumbprint(t, &privateKey.PublicKey)
+	})
+
+	t.Run("extraneous members are ignored", func(t *testing.T) {
+		jwk := &JWK{Kty: "OKP", Crv: "Ed25519", X: "x", Y: "y"}
+
+		input, err := jwk.ThumbprintInput()
+		require.NoError(t, err)
+		require.Equal(t, `{"crv":"Ed25519","kty":"OKP","x":"x"}`, string(input))
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		thumbprint, err := (&JWK{Kty: "oct"}).Thumbprint()
+		require.EqualError(t, err, "JWK thumbprint not supported for key type [oct]")
+		require.Empty(t, thumbprint)
+	})
+
+	t.Run("missing member", func(t *testing.T) {
+		thumbprint, err := (&JWK{Kty: "EC", Crv: "P-256", X: "x"}).Thumbprint()
+		require.EqualError(t, err, "JWK y is missing")
+		require.Empty(t, thumbprint)
+	})
+}
+
+func requireThumbprint(t *testing.T, publicKey interface{}) {
+	t.Helper()
+
+	key := jose.JSONWebKey{Key: publicKey}
+
+	expected, err := key.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	jwkBytes, err := key.MarshalJSON()
+	require.NoError(t, err)
+
+	jwk := &JWK{}
+	require.NoError(t, json.Unmarshal(jwkBytes, jwk))
+
+	thumbprint, err := jwk.Thumbprint()
+	require.NoError(t, err)
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(expected), thumbprint)
 }
@@ -0,0 +1,191 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package verifier contains a registry of JWS signature verifiers which is keyed by the JWS algorithm ("alg")
+// and the key type and curve of the public key ("kty" and "crv").
+package verifier
+
+import (
+	"errors"
+	"fmt"
+
+	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+// ErrNotSupported is returned (wrapped) when no verifier is registered for the algorithm and key.
+var ErrNotSupported = errors.New("signature algorithm not supported")
+
+// Verifier verifies a signature with a public key.
+type Verifier interface {
+	Verify(jwk *jws.JWK, signature, msg []byte) error
+}
+
+// VerifierFunc is a function which implements the Verifier interface.
+type VerifierFunc func(jwk *jws.JWK, signature, msg []byte) error
+
+// Verify verifies the signature with the given public key.
+func (f VerifierFunc) Verify(jwk *jws.JWK, signature, msg []byte) error {
+	return f(jwk, signature, msg)
+}
+
+// Option is a registry instance option.
+type Option func(opts *Registry)
+
+// Registry contains the signature verifiers.
+type Registry struct {
+	verifiers map[key]Verifier
+}
+
+type key struct {
+	alg string
+	kty string
+	crv string
+}
+
+// New returns a new signature verifier registry.
+func New(opts ...Option) *Registry {
+	registry := &Registry{
+		verifiers: make(map[key]Verifier),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(registry)
+	}
+
+	return registry
+}
+
+// Get returns the verifier for the given JWS algorithm and public key. A verifier which is registered for the
+// key's curve takes precedence over a verifier which is registered for any curve of the key type.
+func (r *Registry) Get(alg string, jwk *jws.JWK) (Verifier, error) {
+	if v, ok := r.verifiers[key{alg: alg, kty: jwk.Kty, crv: jwk.Crv}]; ok {
+		return v, nil
+	}
+
+	if v, ok := r.verifiers[key{alg: alg, kty: jwk.Kty}]; ok {
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("%w: no verifier for algorithm [%s] and key [%s]", ErrNotSupported, alg, KeyAlgorithm(jwk))
+}
+
+// Verify verifies the signature of the compact JWS with the given public key.
+func (r *Registry) Verify(compactJWS string, jwk *jws.JWK) error {
+	parsed, err := internal.ParseJWS(compactJWS)
+	if err != nil {
+		return err
+	}
+
+	alg, _ := parsed.ProtectedHeaders.Algorithm()
+
+	v, err := r.Get(alg, jwk)
+	if err != nil {
+		return err
+	}
+
+	msg, err := parsed.SigningInput()
+	if err != nil {
+		return fmt.Errorf("build signing input: %w", err)
+	}
+
+	return v.Verify(jwk, parsed.Signature(), msg)
+}
+
+// SupportsKeyAlgorithm returns true if at least one verifier is registered for the given key algorithm
+// (see KeyAlgorithm).
+func (r *Registry) SupportsKeyAlgorithm(keyAlg string) bool {
+	for k := range r.verifiers {
+		if k.keyAlgorithm() == keyAlg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SupportsSignatureAlgorithm returns true if at least one verifier is registered for the given JWS algorithm.
+func (r *Registry) SupportsSignatureAlgorithm(alg string) bool {
+	for k := range r.verifiers {
+		if k.alg == alg {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns a registry which only contains the verifiers for the given signature and key algorithms
+// (i.e. the SignatureAlgorithms and KeyAlgorithms of a protocol).
+func (r *Registry) Filter(signatureAlgorithms, keyAlgorithms []string) *Registry {
+	filtered := New()
+
+	for k, v := range r.verifiers {
+		if contains(signatureAlgorithms, k.alg) && contains(keyAlgorithms, k.keyAlgorithm()) {
+			filtered.verifiers[k] = v
+		}
+	}
+
+	return filtered
+}
+
+// KeyAlgorithm returns the algorithm of the key as it appears in the protocol's KeyAlgorithms,
+// i.e. the curve (e.g. P-256, Ed25519) or the key type if the key has no curve (e.g. RSA).
+func KeyAlgorithm(jwk *jws.JWK) string {
+	if jwk.Crv != "" {
+		return jwk.Crv
+	}
+
+	return jwk.Kty
+}
+
+func (k key) keyAlgorithm() string {
+	if k.crv != "" {
+		return k.crv
+	}
+
+	return k.kty
+}
+
+// WithVerifier registers the verifier for the given JWS algorithm, key type and curve. If the curve is empty
+// then the verifier is used for all keys of the key type. An existing verifier for the same algorithm, key type
+// and curve is replaced.
+func WithVerifier(alg, kty, crv string, v Verifier) Option {
+	return func(opts *Registry) {
+		opts.verifiers[key{alg: alg, kty: kty, crv: crv}] = v
+	}
+}
+
+// WithDefaultVerifiers registers the built-in verifiers: EdDSA (Ed25519), ES256 (P-256), ES384 (P-384),
+// ES512 (P-521) and ES256K (secp256k1).
+func WithDefaultVerifiers() Option {
+	return func(opts *Registry) {
+		builtIn := VerifierFunc(internal.VerifySignature)
+
+		for _, k := range defaultKeys {
+			opts.verifiers[k] = builtIn
+		}
+	}
+}
+
+var defaultKeys = []key{
+	{alg: "EdDSA", kty: "OKP", crv: "Ed25519"},
+	{alg: "ES256", kty: "EC", crv: "P-256"},
+	{alg: "ES384", kty: "EC", crv: "P-384"},
+	{alg: "ES512", kty: "EC", crv: "P-521"},
+	{alg: "ES256K", kty: "EC", crv: "secp256k1"},
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
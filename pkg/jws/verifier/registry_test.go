@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifier
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+)
+
+const algPS256 = "PS256"
+
+func TestRegistry_Verify(t *testing.T) {
+	registry := New(WithDefaultVerifiers())
+
+	t.Run("success - built-in verifiers", func(t *testing.T) {
+		for alg, curve := range map[string]elliptic.Curve{
+			"ES256": elliptic.P256(),
+			"ES384": elliptic.P384(),
+			"ES512": elliptic.P521(),
+		} {
+			privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+
+			jwk, err := pubkey.GetPublicKeyJWK(&privateKey.PublicKey)
+			require.NoError(t, err)
+
+			compactJWS := sign(t, ecsigner.New(privateKey, alg, ""))
+
+			require.NoError(t, registry.Verify(compactJWS, jwk), alg)
+		}
+
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Verify(sign(t, edsigner.New(privateKey, "EdDSA", "")), jwk))
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := pubkey.GetPublicKeyJWK(&otherKey.PublicKey)
+		require.NoError(t, err)
+
+		err = registry.Verify(sign(t, ecsigner.New(privateKey, "ES256", "")), jwk)
+		require.EqualError(t, err, "ecdsa: invalid signature")
+	})
+
+	t.Run("error - algorithm doesn't match key", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := pubkey.GetPublicKeyJWK(&privateKey.PublicKey)
+		require.NoError(t, err)
+
+		err = registry.Verify(sign(t, ecsigner.New(privateKey, "ES256", "")), jwk)
+		require.True(t, errors.Is(err, ErrNotSupported))
+		require.EqualError(t, err,
+			"signature algorithm not supported: no verifier for algorithm [ES256] and key [P-384]")
+	})
+
+	t.Run("error - invalid JWS", func(t *testing.T) {
+		err := registry.Verify("invalid", &jws.JWK{Kty: "EC", Crv: "P-256"})
+		require.EqualError(t, err, "invalid JWS compact format")
+	})
+}
+
+func TestRegistry_CustomVerifier(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk := &jws.JWK{
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+	}
+
+	compactJWS := sign(t, &rsaPSSSigner{privateKey: privateKey})
+
+	err = New(WithDefaultVerifiers()).Verify(compactJWS, jwk)
+	require.True(t, errors.Is(err, ErrNotSupported))
+
+	registry := New(WithDefaultVerifiers(), WithVerifier(algPS256, "RSA", "", VerifierFunc(verifyRSAPSS)))
+	require.NoError(t, registry.Verify(compactJWS, jwk))
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	err = registry.Verify(sign(t, &rsaPSSSigner{privateKey: otherKey}), jwk)
+	require.EqualError(t, err, "crypto/rsa: verification error")
+}
+
+func TestRegistry_Get(t *testing.T) {
+	anyCurve := VerifierFunc(func(*jws.JWK, []byte, []byte) error { return errors.New("any curve") })
+	p256 := VerifierFunc(func(*jws.JWK, []byte, []byte) error { return errors.New("P-256") })
+
+	registry := New(
+		WithVerifier("alg", "EC", "", anyCurve),
+		WithVerifier("alg", "EC", "P-256", p256),
+	)
+
+	v, err := registry.Get("alg", &jws.JWK{Kty: "EC", Crv: "P-256"})
+	require.NoError(t, err)
+	require.EqualError(t, v.Verify(nil, nil, nil), "P-256")
+
+	v, err = registry.Get("alg", &jws.JWK{Kty: "EC", Crv: "P-384"})
+	require.NoError(t, err)
+	require.EqualError(t, v.Verify(nil, nil, nil), "any curve")
+
+	v, err = registry.Get("alg", &jws.JWK{Kty: "OKP", Crv: "Ed25519"})
+	require.True(t, errors.Is(err, ErrNotSupported))
+	require.Nil(t, v)
+}
+
+func TestRegistry_Filter(t *testing.T) {
+	registry := New(WithDefaultVerifiers(), WithVerifier(algPS256, "RSA", "", VerifierFunc(verifyRSAPSS)))
+
+	require.True(t, registry.SupportsKeyAlgorithm("P-384"))
+	require.True(t, registry.SupportsKeyAlgorithm("RSA"))
+	require.False(t, registry.SupportsKeyAlgorithm("X25519"))
+	require.True(t, registry.SupportsSignatureAlgorithm(algPS256))
+	require.False(t, registry.SupportsSignatureAlgorithm("RS256"))
+
+	filtered := registry.Filter([]string{"ES256", "ES384", "EdDSA"}, []string{"P-256", "P-384", "RSA"})
+
+	require.True(t, filtered.SupportsKeyAlgorithm("P-256"))
+	require.True(t, filtered.SupportsKeyAlgorithm("P-384"))
+	require.False(t, filtered.SupportsKeyAlgorithm("Ed25519"))
+	require.False(t, filtered.SupportsKeyAlgorithm("RSA"))
+	require.False(t, filtered.SupportsSignatureAlgorithm("EdDSA"))
+	require.True(t, filtered.SupportsSignatureAlgorithm("ES384"))
+	require.False(t, filtered.SupportsSignatureAlgorithm(algPS256))
+
+	_, err := filtered.Get("ES384", &jws.JWK{Kty: "EC", Crv: "P-384"})
+	require.NoError(t, err)
+
+	_, err = filtered.Get("ES512", &jws.JWK{Kty: "EC", Crv: "P-521"})
+	require.True(t, errors.Is(err, ErrNotSupported))
+
+	// the original registry isn't modified
+	_, err = registry.Get("ES512", &jws.JWK{Kty: "EC", Crv: "P-521"})
+	require.NoError(t, err)
+}
+
+func TestKeyAlgorithm(t *testing.T) {
+	require.Equal(t, "P-256", KeyAlgorithm(&jws.JWK{Kty: "EC", Crv: "P-256"}))
+	require.Equal(t, "Ed25519", KeyAlgorithm(&jws.JWK{Kty: "OKP", Crv: "Ed25519"}))
+	require.Equal(t, "RSA", KeyAlgorithm(&jws.JWK{Kty: "RSA"}))
+}
+
+func sign(t *testing.T, signer internal.Signer) string {
+	t.Helper()
+
+	signature, err := internal.NewJWS(signer.Headers(), nil, []byte(`{"didSuffix":"abc"}`), signer)
+	require.NoError(t, err)
+
+	compactJWS, err := signature.SerializeCompact(false)
+	require.NoError(t, err)
+
+	return compactJWS
+}
+
+type rsaPSSSigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+func (s *rsaPSSSigner) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	return rsa.SignPSS(rand.Reader, s.privateKey, crypto.SHA256, digest[:], nil)
+}
+
+func (s *rsaPSSSigner) Headers() jws.Headers {
+	return jws.Headers{jws.HeaderAlgorithm: algPS256}
+}
+
+func verifyRSAPSS(jwk *jws.JWK, signature, msg []byte) error {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return err
+	}
+
+	publicKey := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}
+
+	digest := sha256.Sum256(msg)
+
+	return rsa.VerifyPSS(publicKey, crypto.SHA256, digest[:], signature, nil)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keystore
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+)
+
+// ErrDecrypt is returned (wrapped) when the keys of a DID cannot be decrypted (e.g. wrong passphrase).
+var ErrDecrypt = errors.New("unable to decrypt keys")
+
+const (
+	saltSize = 16
+	keySize  = 32
+
+	defaultScryptN = 1 << 15
+	defaultScryptR = 8
+	defaultScryptP = 1
+)
+
+type scryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// envelope is the encrypted form of the keys of a DID. The encryption key is derived from the passphrase
+// with scrypt and the keys are encrypted with AES-256-GCM. The DID suffix is used as additional data
+// so that the keys of one DID cannot be substituted for the keys of another DID.
+type envelope struct {
+	Scrypt     scryptParams `json:"scrypt"`
+	Salt       []byte       `json:"salt"`
+	Nonce      []byte       `json:"nonce"`
+	Ciphertext []byte       `json:"ciphertext"`
+}
+
+func encrypt(passphrase []byte, params scryptParams, didSuffix string, plaintext []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, params, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	return json.Marshal(&envelope{
+		Scrypt:     params,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, []byte(didSuffix)),
+	})
+}
+
+func decrypt(passphrase []byte, didSuffix string, data []byte) ([]byte, error) {
+	e := &envelope{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDecrypt, err.Error())
+	}
+
+	aead, err := newAEAD(passphrase, e.Scrypt, e.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDecrypt, err.Error())
+	}
+
+	if len(e.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrDecrypt)
+	}
+
+	plaintext, err := aead.Open(nil, e.Nonce, e.Ciphertext, []byte(didSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("%w: wrong passphrase or corrupted data", ErrDecrypt)
+	}
+
+	return plaintext, nil
+}
+
+func newAEAD(passphrase []byte, params scryptParams, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, params.N, params.R, params.P, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// keyPair is a private key and the JWS algorithm that it's used with.
+type keyPair struct {
+	Alg        string `json:"alg"`
+	PrivateKey []byte `json:"privateKey"` // PKCS #8, DER
+}
+
+var curves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(),
+	"ES384": elliptic.P384(),
+	"ES512": elliptic.P521(),
+}
+
+const algEdDSA = "EdDSA"
+
+func generateKeyPair(alg string) (*keyPair, error) {
+	var privateKey crypto.Signer
+
+	var err error
+
+	if alg == algEdDSA {
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	} else {
+		curve, ok := curves[alg]
+		if !ok {
+			return nil, fmt.Errorf("algorithm [%s] is not supported", alg)
+		}
+
+		privateKey, err = ecdsa.GenerateKey(curve, rand.Reader)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key: %w", err)
+	}
+
+	return &keyPair{Alg: alg, PrivateKey: der}, nil
+}
+
+// open returns the signer and the public key (JWK) of the key pair. If thumbprintKID is set, the key ID
+// of the signer is the JWK thumbprint of the public key.
+func (k *keyPair) open(thumbprintKID bool) (client.Signer, *jws.JWK, error) {
+	key, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse private key: %w", err)
+	}
+
+	privateKey, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected private key type %T", key)
+	}
+
+	jwk, err := pubkey.GetPublicKeyJWK(privateKey.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var kid string
+
+	if thumbprintKID {
+		if kid, err = jwk.Thumbprint(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	switch privateKey := key.(type) {
+	case *ecdsa.PrivateKey:
+		return ecsigner.New(privateKey, k.Alg, kid), jwk, nil
+	case ed25519.PrivateKey:
+		return edsigner.New(privateKey, k.Alg, kid), jwk, nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected private key type %T", key)
+	}
+}
@@ -0,0 +1,394 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package keystore generates, stores (encrypted) and rotates the update and recovery keys of DIDs, and builds
+// the Sidetree requests for the DIDs with the right signer, reveal key and commitments.
+//
+// The keys for the next commitments are generated when an update or recover request is built and they are kept
+// as pending keys until Commit is called (i.e. once the operation has been anchored). Building another request
+// before Commit reuses the pending keys, so a request can be retried without losing the keys for its commitments.
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
+)
+
+const defaultAlgorithm = "ES256"
+
+// record contains the keys of a DID.
+type record struct {
+	UpdateKey       *keyPair `json:"updateKey"`
+	RecoveryKey     *keyPair `json:"recoveryKey"`
+	NextUpdateKey   *keyPair `json:"nextUpdateKey,omitempty"`
+	NextRecoveryKey *keyPair `json:"nextRecoveryKey,omitempty"`
+}
+
+// Keystore manages the update and recovery keys of DIDs.
+type Keystore struct {
+	storage    Storage
+	passphrase []byte
+	pc         protocol.Client
+	alg        string
+	scrypt     scryptParams
+	mutex      sync.Mutex
+}
+
+// Option is a keystore instance option.
+type Option func(opts *Keystore)
+
+// WithAlgorithm sets the JWS algorithm of the generated keys (default ES256). Supported algorithms are
+// ES256, ES384, ES512 and EdDSA.
+func WithAlgorithm(alg string) Option {
+	return func(opts *Keystore) {
+		opts.alg = alg
+	}
+}
+
+// WithScryptParams sets the scrypt cost parameters which are used to derive the encryption key from
+// the passphrase (default N=32768, r=8, p=1).
+func WithScryptParams(n, r, p int) Option {
+	return func(opts *Keystore) {
+		opts.scrypt = scryptParams{N: n, R: r, P: p}
+	}
+}
+
+// New returns a new keystore. The keys are encrypted with a key which is derived from the passphrase.
+func New(storage Storage, passphrase []byte, pc protocol.Client, opts ...Option) (*Keystore, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("missing passphrase")
+	}
+
+	ks := &Keystore{
+		storage:    storage,
+		passphrase: passphrase,
+		pc:         pc,
+		alg:        defaultAlgorithm,
+		scrypt:     scryptParams{N: defaultScryptN, R: defaultScryptR, P: defaultScryptP},
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(ks)
+	}
+
+	if _, err := generateKeyPair(ks.alg); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// NewCreateRequest generates the update and recovery keys of a new DID, stores them and returns the create
+// request together with the unique suffix of the DID. The commitments and the multihash code of the request
+// info are set by the keystore.
+func (ks *Keystore) NewCreateRequest(info *client.CreateRequestInfo) ([]byte, string, error) {
+	p, err := ks.protocol()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rec := &record{}
+
+	if rec.UpdateKey, err = ks.generateKeyPair(p); err != nil {
+		return nil, "", err
+	}
+
+	if rec.RecoveryKey, err = ks.generateKeyPair(p); err != nil {
+		return nil, "", err
+	}
+
+	createInfo := *info
+	createInfo.MultihashCode = p.MultihashAlgorithm
+
+	if createInfo.UpdateCommitment, err = getCommitment(rec.UpdateKey, p); err != nil {
+		return nil, "", err
+	}
+
+	if createInfo.RecoveryCommitment, err = getCommitment(rec.RecoveryKey, p); err != nil {
+		return nil, "", err
+	}
+
+	request, err := client.NewCreateRequest(&createInfo)
+	if err != nil {
+		return nil, "", err
+	}
+
+	didSuffix, err := getUniqueSuffix(request, p.MultihashAlgorithm)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	if err := ks.put(didSuffix, rec); err != nil {
+		return nil, "", err
+	}
+
+	return request, didSuffix, nil
+}
+
+// NewUpdateRequest returns an update request for the DID which is signed with the current update key of the DID.
+// The update key, signer, update commitment and multihash code of the request info are set by the keystore.
+func (ks *Keystore) NewUpdateRequest(info *client.UpdateRequestInfo) ([]byte, error) {
+	p, err := ks.protocol()
+	if err != nil {
+		return nil, err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	rec, err := ks.get(info.DidSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.NextUpdateKey == nil {
+		if rec.NextUpdateKey, err = ks.generateKeyPair(p); err != nil {
+			return nil, err
+		}
+	}
+
+	updateInfo := *info
+	updateInfo.MultihashCode = p.MultihashAlgorithm
+
+	if updateInfo.Signer, updateInfo.UpdateKey, err = rec.UpdateKey.open(p.JWKThumbprints); err != nil {
+		return nil, err
+	}
+
+	if updateInfo.UpdateCommitment, err = getCommitment(rec.NextUpdateKey, p); err != nil {
+		return nil, err
+	}
+
+	request, err := client.NewUpdateRequest(&updateInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ks.put(info.DidSuffix, rec); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// NewRecoverRequest returns a recover request for the DID which is signed with the current recovery key
+// of the DID. The recovery key, signer, commitments and multihash code of the request info are set by the keystore.
+func (ks *Keystore) NewRecoverRequest(info *client.RecoverRequestInfo) ([]byte, error) {
+	p, err := ks.protocol()
+	if err != nil {
+		return nil, err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	rec, err := ks.get(info.DidSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.NextRecoveryKey == nil {
+		if rec.NextRecoveryKey, err = ks.generateKeyPair(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if rec.NextUpdateKey == nil {
+		if rec.NextUpdateKey, err = ks.generateKeyPair(p); err != nil {
+			return nil, err
+		}
+	}
+
+	recoverInfo := *info
+	recoverInfo.MultihashCode = p.MultihashAlgorithm
+
+	if recoverInfo.Signer, recoverInfo.RecoveryKey, err = rec.RecoveryKey.open(p.JWKThumbprints); err != nil {
+		return nil, err
+	}
+
+	if recoverInfo.RecoveryCommitment, err = getCommitment(rec.NextRecoveryKey, p); err != nil {
+		return nil, err
+	}
+
+	if recoverInfo.UpdateCommitment, err = getCommitment(rec.NextUpdateKey, p); err != nil {
+		return nil, err
+	}
+
+	request, err := client.NewRecoverRequest(&recoverInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ks.put(info.DidSuffix, rec); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// NewDeactivateRequest returns a deactivate request for the DID which is signed with the current recovery key
+// of the DID.
+func (ks *Keystore) NewDeactivateRequest(didSuffix string) ([]byte, error) {
+	p, err := ks.protocol()
+	if err != nil {
+		return nil, err
+	}
+
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	rec, err := ks.get(didSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, recoveryKey, err := rec.RecoveryKey.open(p.JWKThumbprints)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewDeactivateRequest(&client.DeactivateRequestInfo{
+		DidSuffix:   didSuffix,
+		RecoveryKey: recoveryKey,
+		Signer:      signer,
+	})
+}
+
+// Commit makes the pending keys of the given anchored operation the current keys of the DID. It should be called
+// once an update or recover operation for the DID has been anchored. An update only commits the pending update key;
+// a recover commits the pending recovery key and the pending update key (if any), since the update commitment of
+// the recover request is the commitment of the pending update key. Pending keys of requests which haven't been
+// anchored are kept.
+func (ks *Keystore) Commit(didSuffix string, opType operation.Type) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	rec, err := ks.get(didSuffix)
+	if err != nil {
+		return err
+	}
+
+	switch opType {
+	case operation.TypeUpdate:
+		if rec.NextUpdateKey == nil {
+			return fmt.Errorf("no pending update key for DID [%s]", didSuffix)
+		}
+
+		rec.UpdateKey, rec.NextUpdateKey = rec.NextUpdateKey, nil
+	case operation.TypeRecover:
+		if rec.NextRecoveryKey == nil {
+			return fmt.Errorf("no pending recovery key for DID [%s]", didSuffix)
+		}
+
+		rec.RecoveryKey, rec.NextRecoveryKey = rec.NextRecoveryKey, nil
+
+		if rec.NextUpdateKey != nil {
+			rec.UpdateKey, rec.NextUpdateKey = rec.NextUpdateKey, nil
+		}
+	default:
+		return fmt.Errorf("operation type [%s] has no keys to commit", opType)
+	}
+
+	return ks.put(didSuffix, rec)
+}
+
+// Delete deletes the keys of the DID (e.g. once the DID has been deactivated).
+func (ks *Keystore) Delete(didSuffix string) error {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	return ks.storage.Delete(didSuffix)
+}
+
+func (ks *Keystore) protocol() (protocol.Protocol, error) {
+	pv, err := ks.pc.Current()
+	if err != nil {
+		return protocol.Protocol{}, err
+	}
+
+	return pv.Protocol(), nil
+}
+
+func (ks *Keystore) generateKeyPair(p protocol.Protocol) (*keyPair, error) {
+	if !contains(p.SignatureAlgorithms, ks.alg) {
+		return nil, fmt.Errorf("algorithm [%s] is not supported by the current protocol", ks.alg)
+	}
+
+	return generateKeyPair(ks.alg)
+}
+
+func (ks *Keystore) get(didSuffix string) (*record, error) {
+	data, err := ks.storage.Get(didSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := decrypt(ks.passphrase, didSuffix, data)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &record{}
+	if err := json.Unmarshal(plaintext, rec); err != nil {
+		return nil, fmt.Errorf("unmarshal keys: %w", err)
+	}
+
+	return rec, nil
+}
+
+func (ks *Keystore) put(didSuffix string, rec *record) error {
+	plaintext, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal keys: %w", err)
+	}
+
+	data, err := encrypt(ks.passphrase, ks.scrypt, didSuffix, plaintext)
+	if err != nil {
+		return err
+	}
+
+	return ks.storage.Put(didSuffix, data)
+}
+
+func getCommitment(key *keyPair, p protocol.Protocol) (string, error) {
+	_, jwk, err := key.open(false)
+	if err != nil {
+		return "", err
+	}
+
+	return commitment.CalculateForProtocol(jwk, p)
+}
+
+func getUniqueSuffix(request []byte, multihashCode uint) (string, error) {
+	createRequest := &model.CreateRequest{}
+	if err := json.Unmarshal(request, createRequest); err != nil {
+		return "", err
+	}
+
+	return docutil.CalculateModelMultihash(createRequest.SuffixData, multihashCode)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
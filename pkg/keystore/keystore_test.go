@@ -0,0 +1,333 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keystore
+
+import (
+	"crypto"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+const (
+	sha2_256   = 18
+	passphrase = "passphrase"
+)
+
+func TestKeystore(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+
+	parser, err := operationparser.New(pc.Protocol)
+	require.NoError(t, err)
+
+	verifiers := verifier.New(verifier.WithDefaultVerifiers())
+
+	requireReveal := func(t *testing.T, key *jws.JWK, expected string) {
+		t.Helper()
+
+		c, err := commitment.Calculate(key, sha2_256, crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, expected, c)
+	}
+
+	for _, alg := range []string{"ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			ks := newTestKeystore(t, pc, WithAlgorithm(alg))
+
+			// create
+			request, didSuffix, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+			require.NoError(t, err)
+
+			createOp, err := parser.ParseCreateOperation(request, false)
+			require.NoError(t, err)
+			require.Equal(t, createOp.UniqueSuffix, didSuffix)
+
+			updateCommitment := createOp.Delta.UpdateCommitment
+			recoveryCommitment := createOp.SuffixData.RecoveryCommitment
+
+			// update
+			updateOp, updateSignedData, err := parser.ParseUpdateOperationWithSignedData(
+				newUpdateRequest(t, ks, didSuffix), false)
+			require.NoError(t, err)
+			require.NoError(t, verifiers.Verify(updateOp.SignedData, updateSignedData.UpdateKey))
+			requireReveal(t, updateSignedData.UpdateKey, updateCommitment)
+			require.NotEqual(t, updateCommitment, updateOp.Delta.UpdateCommitment)
+
+			// the pending update key is reused until the update is committed
+			updateOp2, err := parser.ParseUpdateOperation(newUpdateRequest(t, ks, didSuffix), false)
+			require.NoError(t, err)
+			require.Equal(t, updateOp.Delta.UpdateCommitment, updateOp2.Delta.UpdateCommitment)
+
+			require.NoError(t, ks.Commit(didSuffix, operation.TypeUpdate))
+			updateCommitment = updateOp.Delta.UpdateCommitment
+
+			_, updateSignedData, err = parser.ParseUpdateOperationWithSignedData(newUpdateRequest(t, ks, didSuffix), false)
+			require.NoError(t, err)
+			requireReveal(t, updateSignedData.UpdateKey, updateCommitment)
+
+			// recover (the pending update key becomes the next update key of the recovery)
+			request, err = ks.NewRecoverRequest(&client.RecoverRequestInfo{
+				DidSuffix:      didSuffix,
+				OpaqueDocument: `{"name":"John"}`,
+			})
+			require.NoError(t, err)
+
+			recoverOp, recoverSignedData, err := parser.ParseRecoverOperationWithSignedData(request, false)
+			require.NoError(t, err)
+			require.NoError(t, verifiers.Verify(recoverOp.SignedData, recoverSignedData.RecoveryKey))
+			requireReveal(t, recoverSignedData.RecoveryKey, recoveryCommitment)
+			require.NotEqual(t, recoveryCommitment, recoverSignedData.RecoveryCommitment)
+
+			require.NoError(t, ks.Commit(didSuffix, operation.TypeRecover))
+			recoveryCommitment = recoverSignedData.RecoveryCommitment
+			updateCommitment = recoverOp.Delta.UpdateCommitment
+
+			_, updateSignedData, err = parser.ParseUpdateOperationWithSignedData(newUpdateRequest(t, ks, didSuffix), false)
+			require.NoError(t, err)
+			requireReveal(t, updateSignedData.UpdateKey, updateCommitment)
+
+			// deactivate
+			request, err = ks.NewDeactivateRequest(didSuffix)
+			require.NoError(t, err)
+
+			deactivateOp, deactivateSignedData, err := parser.ParseDeactivateOperationWithSignedData(request, false)
+			require.NoError(t, err)
+			require.NoError(t, verifiers.Verify(deactivateOp.SignedData, deactivateSignedData.RecoveryKey))
+			requireReveal(t, deactivateSignedData.RecoveryKey, recoveryCommitment)
+
+			require.NoError(t, ks.Delete(didSuffix))
+
+			_, err = ks.NewDeactivateRequest(didSuffix)
+			require.True(t, errors.Is(err, ErrNotFound))
+		})
+	}
+
+	t.Run("JWK thumbprints", func(t *testing.T) {
+		pc := mocks.NewMockProtocolClient()
+		pc.Protocol.JWKThumbprints = true
+		pc.CurrentVersion = mocks.GetProtocolVersion(pc.Protocol)
+
+		parser, err := operationparser.New(pc.Protocol)
+		require.NoError(t, err)
+
+		ks := newTestKeystore(t, pc, WithAlgorithm("EdDSA"))
+
+		request, didSuffix, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.NoError(t, err)
+
+		createOp, err := parser.ParseCreateOperation(request, false)
+		require.NoError(t, err)
+
+		updateOp, updateSignedData, err := parser.ParseUpdateOperationWithSignedData(
+			newUpdateRequest(t, ks, didSuffix), false)
+		require.NoError(t, err)
+
+		c, err := commitment.CalculateFromThumbprint(updateSignedData.UpdateKey, sha2_256, crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, createOp.Delta.UpdateCommitment, c)
+
+		thumbprint, err := updateSignedData.UpdateKey.Thumbprint()
+		require.NoError(t, err)
+
+		signedData, err := internal.ParseJWS(updateOp.SignedData)
+		require.NoError(t, err)
+
+		kid, ok := signedData.ProtectedHeaders.KeyID()
+		require.True(t, ok)
+		require.Equal(t, thumbprint, kid)
+	})
+
+	t.Run("error - commit without pending keys", func(t *testing.T) {
+		ks := newTestKeystore(t, pc)
+
+		_, didSuffix, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.NoError(t, err)
+
+		err = ks.Commit(didSuffix, operation.TypeUpdate)
+		require.EqualError(t, err, "no pending update key for DID ["+didSuffix+"]")
+
+		err = ks.Commit(didSuffix, operation.TypeRecover)
+		require.EqualError(t, err, "no pending recovery key for DID ["+didSuffix+"]")
+
+		err = ks.Commit(didSuffix, operation.TypeDeactivate)
+		require.EqualError(t, err, "operation type [deactivate] has no keys to commit")
+	})
+
+	t.Run("pending recovery key is kept when only the update is anchored", func(t *testing.T) {
+		ks := newTestKeystore(t, pc)
+
+		request, didSuffix, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.NoError(t, err)
+
+		createOp, err := parser.ParseCreateOperation(request, false)
+		require.NoError(t, err)
+
+		recoveryCommitment := createOp.SuffixData.RecoveryCommitment
+
+		updateOp, err := parser.ParseUpdateOperation(newUpdateRequest(t, ks, didSuffix), false)
+		require.NoError(t, err)
+
+		// a recover request is built but it is never anchored
+		_, err = ks.NewRecoverRequest(&client.RecoverRequestInfo{
+			DidSuffix:      didSuffix,
+			OpaqueDocument: `{"name":"John"}`,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ks.Commit(didSuffix, operation.TypeUpdate))
+
+		// the recovery key is still the key of the anchored recovery commitment
+		request, err = ks.NewDeactivateRequest(didSuffix)
+		require.NoError(t, err)
+
+		_, deactivateSignedData, err := parser.ParseDeactivateOperationWithSignedData(request, false)
+		require.NoError(t, err)
+		requireReveal(t, deactivateSignedData.RecoveryKey, recoveryCommitment)
+
+		// the update key is the key of the anchored update commitment
+		_, updateSignedData, err := parser.ParseUpdateOperationWithSignedData(newUpdateRequest(t, ks, didSuffix), false)
+		require.NoError(t, err)
+		requireReveal(t, updateSignedData.UpdateKey, updateOp.Delta.UpdateCommitment)
+	})
+
+	t.Run("error - keys not found", func(t *testing.T) {
+		ks := newTestKeystore(t, pc)
+
+		_, err := ks.NewUpdateRequest(&client.UpdateRequestInfo{DidSuffix: "unknown"})
+		require.True(t, errors.Is(err, ErrNotFound))
+
+		_, err = ks.NewRecoverRequest(&client.RecoverRequestInfo{DidSuffix: "unknown"})
+		require.True(t, errors.Is(err, ErrNotFound))
+
+		err = ks.Commit("unknown", operation.TypeUpdate)
+		require.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("error - wrong passphrase", func(t *testing.T) {
+		storage, err := NewFileStorage(t.TempDir())
+		require.NoError(t, err)
+
+		ks, err := New(storage, []byte(passphrase), pc, WithScryptParams(1<<4, 8, 1))
+		require.NoError(t, err)
+
+		_, didSuffix, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.NoError(t, err)
+
+		ks, err = New(storage, []byte("other"), pc)
+		require.NoError(t, err)
+
+		_, err = ks.NewDeactivateRequest(didSuffix)
+		require.True(t, errors.Is(err, ErrDecrypt))
+	})
+
+	t.Run("error - keys of another DID", func(t *testing.T) {
+		storage, err := NewFileStorage(t.TempDir())
+		require.NoError(t, err)
+
+		ks, err := New(storage, []byte(passphrase), pc, WithScryptParams(1<<4, 8, 1))
+		require.NoError(t, err)
+
+		_, didSuffix1, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.NoError(t, err)
+
+		_, didSuffix2, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"John"}`})
+		require.NoError(t, err)
+
+		data, err := storage.Get(didSuffix1)
+		require.NoError(t, err)
+		require.NoError(t, storage.Put(didSuffix2, data))
+
+		_, err = ks.NewDeactivateRequest(didSuffix2)
+		require.True(t, errors.Is(err, ErrDecrypt))
+	})
+
+	t.Run("error - algorithm not supported by protocol", func(t *testing.T) {
+		ks := newTestKeystore(t, pc, WithAlgorithm("ES384"))
+
+		_, _, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.EqualError(t, err, "algorithm [ES384] is not supported by the current protocol")
+	})
+
+	t.Run("error - protocol client error", func(t *testing.T) {
+		pcWithErr := mocks.NewMockProtocolClient()
+		pcWithErr.Err = errors.New("protocol error")
+
+		ks := newTestKeystore(t, pcWithErr)
+
+		_, _, err := ks.NewCreateRequest(&client.CreateRequestInfo{OpaqueDocument: `{"name":"Jane"}`})
+		require.EqualError(t, err, "protocol error")
+
+		_, err = ks.NewUpdateRequest(&client.UpdateRequestInfo{DidSuffix: "suffix"})
+		require.EqualError(t, err, "protocol error")
+
+		_, err = ks.NewRecoverRequest(&client.RecoverRequestInfo{DidSuffix: "suffix"})
+		require.EqualError(t, err, "protocol error")
+	})
+
+	t.Run("error - invalid request info", func(t *testing.T) {
+		ks := newTestKeystore(t, pc)
+
+		_, _, err := ks.NewCreateRequest(&client.CreateRequestInfo{})
+		require.EqualError(t, err, "either opaque document or patches have to be supplied")
+	})
+}
+
+func TestNew(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	t.Run("error - missing passphrase", func(t *testing.T) {
+		ks, err := New(storage, nil, mocks.NewMockProtocolClient())
+		require.EqualError(t, err, "missing passphrase")
+		require.Nil(t, ks)
+	})
+
+	t.Run("error - unsupported algorithm", func(t *testing.T) {
+		ks, err := New(storage, []byte(passphrase), mocks.NewMockProtocolClient(), WithAlgorithm("RS256"))
+		require.EqualError(t, err, "algorithm [RS256] is not supported")
+		require.Nil(t, ks)
+	})
+}
+
+func newTestKeystore(t *testing.T, pc *mocks.MockProtocolClient, opts ...Option) *Keystore {
+	t.Helper()
+
+	storage, err := NewFileStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// use a low scrypt cost to keep the tests fast
+	ks, err := New(storage, []byte(passphrase), pc, append([]Option{WithScryptParams(1<<4, 8, 1)}, opts...)...)
+	require.NoError(t, err)
+
+	return ks
+}
+
+func newUpdateRequest(t *testing.T, ks *Keystore, didSuffix string) []byte {
+	t.Helper()
+
+	replace, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/name", "value": "Jane"}]`)
+	require.NoError(t, err)
+
+	request, err := ks.NewUpdateRequest(&client.UpdateRequestInfo{
+		DidSuffix: didSuffix,
+		Patches:   []patch.Patch{replace},
+	})
+	require.NoError(t, err)
+
+	return request
+}
@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned (wrapped) when there are no keys for a DID.
+var ErrNotFound = errors.New("keys not found")
+
+const (
+	fileExtension = ".keys"
+	fileMode      = 0600
+	dirMode       = 0700
+)
+
+// Storage stores the (encrypted) keys of the DIDs.
+type Storage interface {
+	Put(didSuffix string, data []byte) error
+	// Get returns the data for the DID. The returned error wraps ErrNotFound if there's no data for the DID.
+	Get(didSuffix string) ([]byte, error)
+	Delete(didSuffix string) error
+}
+
+// FileStorage stores the keys of each DID in a separate file in a directory.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage returns a new file storage for the given directory. The directory is created if it doesn't exist.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, fmt.Errorf("create keystore directory: %w", err)
+	}
+
+	return &FileStorage{dir: dir}, nil
+}
+
+// Put stores the data for the DID. The file is replaced atomically so that the keys are never lost if
+// the process is stopped while the file is being written.
+func (s *FileStorage) Put(didSuffix string, data []byte) error {
+	path, err := s.path(didSuffix)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(s.dir, didSuffix+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), fileMode); err != nil {
+		return fmt.Errorf("set file mode: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the data for the DID.
+func (s *FileStorage) Get(didSuffix string) ([]byte, error) {
+	path, err := s.path(didSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path) //nolint:gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: [%s]", ErrNotFound, didSuffix)
+		}
+
+		return nil, fmt.Errorf("read keys file: %w", err)
+	}
+
+	return data, nil
+}
+
+// Delete deletes the data for the DID.
+func (s *FileStorage) Delete(didSuffix string) error {
+	path, err := s.path(didSuffix)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete keys file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileStorage) path(didSuffix string) (string, error) {
+	if didSuffix == "" || filepath.Base(didSuffix) != didSuffix || didSuffix == "." || didSuffix == ".." {
+		return "", fmt.Errorf("invalid DID suffix [%s]", didSuffix)
+	}
+
+	return filepath.Join(s.dir, didSuffix+fileExtension), nil
+}
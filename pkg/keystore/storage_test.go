@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keystore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStorage(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+
+	storage, err := NewFileStorage(dir)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, storage.Put("suffix", []byte("data")))
+		require.NoError(t, storage.Put("suffix", []byte("new data")))
+
+		data, err := storage.Get("suffix")
+		require.NoError(t, err)
+		require.Equal(t, "new data", string(data))
+
+		info, err := os.Stat(filepath.Join(dir, "suffix.keys"))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(fileMode), info.Mode().Perm())
+
+		files, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		require.NoError(t, storage.Delete("suffix"))
+		require.NoError(t, storage.Delete("suffix"))
+
+		_, err = storage.Get("suffix")
+		require.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("error - invalid DID suffix", func(t *testing.T) {
+		for _, didSuffix := range []string{"", ".", "..", "../suffix", "dir/suffix"} {
+			require.EqualError(t, storage.Put(didSuffix, []byte("data")), "invalid DID suffix ["+didSuffix+"]")
+
+			_, err := storage.Get(didSuffix)
+			require.EqualError(t, err, "invalid DID suffix ["+didSuffix+"]")
+
+			require.EqualError(t, storage.Delete(didSuffix), "invalid DID suffix ["+didSuffix+"]")
+		}
+	})
+}
@@ -8,12 +8,12 @@ package mocks
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/docindex"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
@@ -91,7 +91,7 @@ func (m *MockDocumentHandler) ProcessOperation(operationBuffer []byte, _ uint64)
 	var op Operation
 	err := json.Unmarshal(operationBuffer, &op)
 	if err != nil {
-		return nil, fmt.Errorf("bad request: %s", err.Error())
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
 	}
 
 	var suffix string
@@ -104,7 +104,7 @@ func (m *MockDocumentHandler) ProcessOperation(operationBuffer []byte, _ uint64)
 	case operation.TypeUpdate, operation.TypeDeactivate, operation.TypeRecover:
 		suffix = op.DidSuffix
 	default:
-		return nil, fmt.Errorf("bad request: operation type [%s] not supported", op.Operation)
+		return nil, fmt.Errorf("%w: operation type [%s] not supported", operation.ErrInvalidOperation, op.Operation)
 	}
 
 	id := m.namespace + docutil.NamespaceDelimiter + suffix
@@ -140,9 +140,8 @@ func (m *MockDocumentHandler) ResolveDocument(didOrDocument string) (*document.R
 		return nil, m.err
 	}
 
-	const badRequest = "bad request"
 	if !strings.HasPrefix(didOrDocument, m.namespace) {
-		return nil, fmt.Errorf("%s: must start with supported namespace", badRequest)
+		return nil, fmt.Errorf("%w: must start with supported namespace", document.ErrInvalidDID)
 	}
 
 	pv, err := m.Protocol().Current()
@@ -152,7 +151,7 @@ func (m *MockDocumentHandler) ResolveDocument(didOrDocument string) (*document.R
 
 	did, initial, err := pv.OperationParser().ParseDID(m.namespace, didOrDocument)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", badRequest, err.Error())
+		return nil, fmt.Errorf("%w: %s", document.ErrInvalidDID, err.Error())
 	}
 
 	if initial != nil {
@@ -160,11 +159,11 @@ func (m *MockDocumentHandler) ResolveDocument(didOrDocument string) (*document.R
 	}
 
 	if _, ok := m.store[didOrDocument]; !ok {
-		return nil, errors.New("not found")
+		return nil, document.ErrNotFound
 	}
 
 	if m.store[didOrDocument] == nil {
-		return nil, errors.New("was deactivated")
+		return nil, document.ErrDeactivated
 	}
 
 	return &document.ResolutionResult{
@@ -172,6 +171,42 @@ func (m *MockDocumentHandler) ResolveDocument(didOrDocument string) (*document.R
 	}, nil
 }
 
+// ResolveDocuments mocks batch resolution by resolving each of the given DIDs sequentially.
+func (m *MockDocumentHandler) ResolveDocuments(didsOrDocuments []string) []*document.DIDResolution {
+	resolutions := make([]*document.DIDResolution, len(didsOrDocuments))
+
+	for i, did := range didsOrDocuments {
+		result, err := m.ResolveDocument(did)
+
+		resolutions[i] = &document.DIDResolution{
+			ID:     did,
+			Result: result,
+			Err:    err,
+		}
+	}
+
+	return resolutions
+}
+
+// FindDIDs mocks finding DIDs by indexing the current state of the stored documents.
+func (m *MockDocumentHandler) FindDIDs(property docindex.Property, value string) ([]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	index := docindex.New()
+
+	for id, doc := range m.store {
+		if doc != nil {
+			if err := index.Update(id, doc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return index.Find(property, value)
+}
+
 // helper function to insert ID into document.
 func applyID(doc document.Document, id string) document.Document {
 	// apply id to document
@@ -23,6 +23,20 @@ type OperationApplier struct {
 		result1 *protocol.ResolutionModel
 		result2 error
 	}
+	ApplyParsedStub        func(*protocol.ParsedOperation, *protocol.ResolutionModel) (*protocol.ResolutionModel, error)
+	applyParsedMutex       sync.RWMutex
+	applyParsedArgsForCall []struct {
+		arg1 *protocol.ParsedOperation
+		arg2 *protocol.ResolutionModel
+	}
+	applyParsedReturns struct {
+		result1 *protocol.ResolutionModel
+		result2 error
+	}
+	applyParsedReturnsOnCall map[int]struct {
+		result1 *protocol.ResolutionModel
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -91,11 +105,77 @@ func (fake *OperationApplier) ApplyReturnsOnCall(i int, result1 *protocol.Resolu
 	}{result1, result2}
 }
 
+func (fake *OperationApplier) ApplyParsed(arg1 *protocol.ParsedOperation, arg2 *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	fake.applyParsedMutex.Lock()
+	ret, specificReturn := fake.applyParsedReturnsOnCall[len(fake.applyParsedArgsForCall)]
+	fake.applyParsedArgsForCall = append(fake.applyParsedArgsForCall, struct {
+		arg1 *protocol.ParsedOperation
+		arg2 *protocol.ResolutionModel
+	}{arg1, arg2})
+	fake.recordInvocation("ApplyParsed", []interface{}{arg1, arg2})
+	fake.applyParsedMutex.Unlock()
+	if fake.ApplyParsedStub != nil {
+		return fake.ApplyParsedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.applyParsedReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OperationApplier) ApplyParsedCallCount() int {
+	fake.applyParsedMutex.RLock()
+	defer fake.applyParsedMutex.RUnlock()
+	return len(fake.applyParsedArgsForCall)
+}
+
+func (fake *OperationApplier) ApplyParsedCalls(stub func(*protocol.ParsedOperation, *protocol.ResolutionModel) (*protocol.ResolutionModel, error)) {
+	fake.applyParsedMutex.Lock()
+	defer fake.applyParsedMutex.Unlock()
+	fake.ApplyParsedStub = stub
+}
+
+func (fake *OperationApplier) ApplyParsedArgsForCall(i int) (*protocol.ParsedOperation, *protocol.ResolutionModel) {
+	fake.applyParsedMutex.RLock()
+	defer fake.applyParsedMutex.RUnlock()
+	argsForCall := fake.applyParsedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *OperationApplier) ApplyParsedReturns(result1 *protocol.ResolutionModel, result2 error) {
+	fake.applyParsedMutex.Lock()
+	defer fake.applyParsedMutex.Unlock()
+	fake.ApplyParsedStub = nil
+	fake.applyParsedReturns = struct {
+		result1 *protocol.ResolutionModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OperationApplier) ApplyParsedReturnsOnCall(i int, result1 *protocol.ResolutionModel, result2 error) {
+	fake.applyParsedMutex.Lock()
+	defer fake.applyParsedMutex.Unlock()
+	fake.ApplyParsedStub = nil
+	if fake.applyParsedReturnsOnCall == nil {
+		fake.applyParsedReturnsOnCall = make(map[int]struct {
+			result1 *protocol.ResolutionModel
+			result2 error
+		})
+	}
+	fake.applyParsedReturnsOnCall[i] = struct {
+		result1 *protocol.ResolutionModel
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *OperationApplier) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.applyMutex.RLock()
 	defer fake.applyMutex.RUnlock()
+	fake.applyParsedMutex.RLock()
+	defer fake.applyParsedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -50,6 +50,19 @@ type OperationParser struct {
 		result1 *operation.Operation
 		result2 error
 	}
+	ParseAnchoredOperationStub        func(*operation.AnchoredOperation) (*protocol.ParsedOperation, error)
+	parseAnchoredOperationMutex       sync.RWMutex
+	parseAnchoredOperationArgsForCall []struct {
+		arg1 *operation.AnchoredOperation
+	}
+	parseAnchoredOperationReturns struct {
+		result1 *protocol.ParsedOperation
+		result2 error
+	}
+	parseAnchoredOperationReturnsOnCall map[int]struct {
+		result1 *protocol.ParsedOperation
+		result2 error
+	}
 	ParseDIDStub        func(string, string) (string, []byte, error)
 	parseDIDMutex       sync.RWMutex
 	parseDIDArgsForCall []struct {
@@ -275,6 +288,69 @@ func (fake *OperationParser) ParseReturnsOnCall(i int, result1 *operation.Operat
 	}{result1, result2}
 }
 
+func (fake *OperationParser) ParseAnchoredOperation(arg1 *operation.AnchoredOperation) (*protocol.ParsedOperation, error) {
+	fake.parseAnchoredOperationMutex.Lock()
+	ret, specificReturn := fake.parseAnchoredOperationReturnsOnCall[len(fake.parseAnchoredOperationArgsForCall)]
+	fake.parseAnchoredOperationArgsForCall = append(fake.parseAnchoredOperationArgsForCall, struct {
+		arg1 *operation.AnchoredOperation
+	}{arg1})
+	fake.recordInvocation("ParseAnchoredOperation", []interface{}{arg1})
+	fake.parseAnchoredOperationMutex.Unlock()
+	if fake.ParseAnchoredOperationStub != nil {
+		return fake.ParseAnchoredOperationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.parseAnchoredOperationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OperationParser) ParseAnchoredOperationCallCount() int {
+	fake.parseAnchoredOperationMutex.RLock()
+	defer fake.parseAnchoredOperationMutex.RUnlock()
+	return len(fake.parseAnchoredOperationArgsForCall)
+}
+
+func (fake *OperationParser) ParseAnchoredOperationCalls(stub func(*operation.AnchoredOperation) (*protocol.ParsedOperation, error)) {
+	fake.parseAnchoredOperationMutex.Lock()
+	defer fake.parseAnchoredOperationMutex.Unlock()
+	fake.ParseAnchoredOperationStub = stub
+}
+
+func (fake *OperationParser) ParseAnchoredOperationArgsForCall(i int) *operation.AnchoredOperation {
+	fake.parseAnchoredOperationMutex.RLock()
+	defer fake.parseAnchoredOperationMutex.RUnlock()
+	argsForCall := fake.parseAnchoredOperationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *OperationParser) ParseAnchoredOperationReturns(result1 *protocol.ParsedOperation, result2 error) {
+	fake.parseAnchoredOperationMutex.Lock()
+	defer fake.parseAnchoredOperationMutex.Unlock()
+	fake.ParseAnchoredOperationStub = nil
+	fake.parseAnchoredOperationReturns = struct {
+		result1 *protocol.ParsedOperation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OperationParser) ParseAnchoredOperationReturnsOnCall(i int, result1 *protocol.ParsedOperation, result2 error) {
+	fake.parseAnchoredOperationMutex.Lock()
+	defer fake.parseAnchoredOperationMutex.Unlock()
+	fake.ParseAnchoredOperationStub = nil
+	if fake.parseAnchoredOperationReturnsOnCall == nil {
+		fake.parseAnchoredOperationReturnsOnCall = make(map[int]struct {
+			result1 *protocol.ParsedOperation
+			result2 error
+		})
+	}
+	fake.parseAnchoredOperationReturnsOnCall[i] = struct {
+		result1 *protocol.ParsedOperation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *OperationParser) ParseDID(arg1 string, arg2 string) (string, []byte, error) {
 	fake.parseDIDMutex.Lock()
 	ret, specificReturn := fake.parseDIDReturnsOnCall[len(fake.parseDIDArgsForCall)]
@@ -351,6 +427,8 @@ func (fake *OperationParser) Invocations() map[string][][]interface{} {
 	defer fake.getRevealValueMutex.RUnlock()
 	fake.parseMutex.RLock()
 	defer fake.parseMutex.RUnlock()
+	fake.parseAnchoredOperationMutex.RLock()
+	defer fake.parseAnchoredOperationMutex.RUnlock()
 	fake.parseDIDMutex.RLock()
 	defer fake.parseDIDMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -7,10 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package mocks
 
 import (
-	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
 )
 
 // MockOperationStore mocks store for testing purposes.
@@ -26,26 +27,23 @@ func NewMockOperationStore(err error) *MockOperationStore {
 	return &MockOperationStore{operations: make(map[string][]*operation.AnchoredOperation), Err: err, Validate: true}
 }
 
-// Put mocks storing operation.
-func (m *MockOperationStore) Put(op *operation.AnchoredOperation) error {
+// Put mocks storing operations.
+func (m *MockOperationStore) Put(ops []*operation.AnchoredOperation) error {
 	if m.Err != nil {
 		return m.Err
 	}
 
-	var opsSize int
-	m.RLock()
-	opsSize = len(m.operations[op.UniqueSuffix])
-	m.RUnlock()
-
-	if m.Validate && op.Type == operation.TypeCreate && opsSize > 0 {
-		// Nothing to do; already created
-		return nil
-	}
-
 	m.Lock()
 	defer m.Unlock()
 
-	m.operations[op.UniqueSuffix] = append(m.operations[op.UniqueSuffix], op)
+	for _, op := range ops {
+		if m.Validate && op.Type == operation.TypeCreate && len(m.operations[op.UniqueSuffix]) > 0 {
+			// Nothing to do; already created
+			continue
+		}
+
+		m.operations[op.UniqueSuffix] = append(m.operations[op.UniqueSuffix], op)
+	}
 
 	return nil
 }
@@ -63,5 +61,52 @@ func (m *MockOperationStore) Get(uniqueSuffix string) ([]*operation.AnchoredOper
 		return ops, nil
 	}
 
-	return nil, errors.New("uniqueSuffix not found in the store")
+	return nil, fmt.Errorf("%w: uniqueSuffix not found in the store", document.ErrNotFound)
+}
+
+// DeleteAfter mocks deleting operations anchored after the given transaction time.
+func (m *MockOperationStore) DeleteAfter(transactionTime uint64) error {
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for suffix, ops := range m.operations {
+		var remaining []*operation.AnchoredOperation
+
+		for _, op := range ops {
+			if op.TransactionTime <= transactionTime {
+				remaining = append(remaining, op)
+			}
+		}
+
+		if len(remaining) == 0 {
+			delete(m.operations, suffix)
+		} else {
+			m.operations[suffix] = remaining
+		}
+	}
+
+	return nil
+}
+
+// Iterate mocks iterating over the operations for the given suffix (in the order in which they were stored).
+func (m *MockOperationStore) Iterate(uniqueSuffix string, fn func(op *operation.AnchoredOperation) bool) error {
+	if m.Err != nil {
+		return m.Err
+	}
+
+	m.RLock()
+	ops := append([]*operation.AnchoredOperation(nil), m.operations[uniqueSuffix]...)
+	m.RUnlock()
+
+	for _, op := range ops {
+		if !fn(op) {
+			break
+		}
+	}
+
+	return nil
 }
@@ -21,11 +21,6 @@ type Ledger interface {
 	RegisterForSidetreeTxn() <-chan []txn.SidetreeTxn
 }
 
-// OperationStore interface to access operation store.
-type OperationStore interface {
-	Put(ops []*operation.AnchoredOperation) error
-}
-
 // OperationFilter filters out operations before they are persisted.
 type OperationFilter interface {
 	Filter(uniqueSuffix string, ops []*operation.AnchoredOperation) ([]*operation.AnchoredOperation, error)
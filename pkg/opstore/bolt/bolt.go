@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bolt implements an opstore.Backend using the embedded bbolt key-value store.
+package bolt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/opstore"
+)
+
+const (
+	// DBFileName is the name of the database file which is created in the given directory.
+	DBFileName = "operations.db"
+
+	dirPermissions  = 0700
+	filePermissions = 0600
+	openTimeout     = time.Second
+)
+
+var bucketName = []byte("operations")
+
+// Backend is a persistent opstore.Backend implemented with bbolt.
+type Backend struct {
+	db *bolt.DB
+}
+
+// New opens (or creates) the database in the given directory.
+func New(dir string) (*Backend, error) {
+	err := os.MkdirAll(dir, dirPermissions)
+	if err != nil {
+		return nil, fmt.Errorf("create directory [%s]: %s", dir, err.Error())
+	}
+
+	db, err := bolt.Open(filepath.Join(dir, DBFileName), filePermissions, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("open database in [%s]: %s", dir, err.Error())
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, e := tx.CreateBucketIfNotExists(bucketName)
+
+		return e
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create bucket: %s", closeOnError(db, err).Error())
+	}
+
+	return &Backend{db: db}, nil
+}
+
+// Put atomically stores the given key-value pairs.
+func (b *Backend) Put(kvs []*opstore.KV) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+
+		for _, kv := range kvs {
+			if err := bucket.Put(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete atomically deletes the given keys.
+func (b *Backend) Delete(keys [][]byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Iterate invokes the given function (in ascending key order) for each of the keys which start with the
+// given prefix and are greater than or equal to 'from'. The key and value are only valid for the
+// duration of the function call.
+func (b *Backend) Iterate(prefix, from []byte, fn func(key, value []byte) bool) error {
+	if from == nil {
+		from = prefix
+	}
+
+	return b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+
+		for k, v := c.Seek(from); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if !fn(k, v) {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+// Close closes the database.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func closeOnError(db *bolt.DB, err error) error {
+	if e := db.Close(); e != nil {
+		return fmt.Errorf("%s (close: %s)", err.Error(), e.Error())
+	}
+
+	return err
+}
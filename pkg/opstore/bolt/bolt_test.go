@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bolt
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/opstore"
+)
+
+const suffix = "suffix"
+
+func TestNew(t *testing.T) {
+	dir := newTempDir(t)
+
+	t.Run("success", func(t *testing.T) {
+		b, err := New(filepath.Join(dir, "db"))
+		require.NoError(t, err)
+		require.NoError(t, b.Close())
+
+		_, err = os.Stat(filepath.Join(dir, "db", DBFileName))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid directory", func(t *testing.T) {
+		file := filepath.Join(dir, "file")
+		require.NoError(t, ioutil.WriteFile(file, []byte("data"), filePermissions))
+
+		b, err := New(file)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create directory")
+		require.Nil(t, b)
+	})
+}
+
+func TestBackend(t *testing.T) {
+	dir := newTempDir(t)
+
+	b, err := New(dir)
+	require.NoError(t, err)
+
+	s := opstore.New(b)
+
+	require.NoError(t, s.Put([]*operation.AnchoredOperation{
+		newOp(operation.TypeUpdate, 11),
+		newOp(operation.TypeCreate, 10),
+		newOp(operation.TypeUpdate, 12),
+	}))
+
+	ops, err := s.Get(suffix)
+	require.NoError(t, err)
+	requireTimes(t, ops, 10, 11, 12)
+
+	var iterated []*operation.AnchoredOperation
+
+	require.NoError(t, s.Iterate(suffix, func(op *operation.AnchoredOperation) bool {
+		iterated = append(iterated, op)
+
+		return false
+	}))
+	requireTimes(t, iterated, 10)
+
+	require.NoError(t, s.DeleteAfter(11))
+	require.NoError(t, s.Close())
+
+	t.Run("operations are persisted", func(t *testing.T) {
+		b, err := New(dir)
+		require.NoError(t, err)
+
+		s := opstore.New(b)
+		defer func() {
+			require.NoError(t, s.Close())
+		}()
+
+		ops, err := s.Get(suffix)
+		require.NoError(t, err)
+		requireTimes(t, ops, 10, 11)
+
+		_, err = s.Get("other")
+		require.True(t, errors.Is(err, document.ErrNotFound))
+	})
+}
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "opstore")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	return dir
+}
+
+func newOp(opType operation.Type, txnTime uint64) *operation.AnchoredOperation {
+	return &operation.AnchoredOperation{
+		Type:            opType,
+		UniqueSuffix:    suffix,
+		TransactionTime: txnTime,
+	}
+}
+
+func requireTimes(t *testing.T, ops []*operation.AnchoredOperation, expected ...uint64) {
+	t.Helper()
+
+	var times []uint64
+	for _, op := range ops {
+		times = append(times, op.TransactionTime)
+	}
+
+	require.Equal(t, expected, times)
+}
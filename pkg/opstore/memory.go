@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package opstore
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// MemBackend is an in-memory implementation of Backend. It is not persistent and is mainly useful for testing.
+type MemBackend struct {
+	mutex sync.RWMutex
+	data  map[string][]byte
+}
+
+// NewMemBackend returns a new in-memory backend.
+func NewMemBackend() *MemBackend {
+	return &MemBackend{data: make(map[string][]byte)}
+}
+
+// Put stores the given key-value pairs.
+func (m *MemBackend) Put(kvs []*KV) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, kv := range kvs {
+		m.data[string(kv.Key)] = copyBytes(kv.Value)
+	}
+
+	return nil
+}
+
+// Delete deletes the given keys.
+func (m *MemBackend) Delete(keys [][]byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(m.data, string(key))
+	}
+
+	return nil
+}
+
+// Iterate invokes the given function (in ascending key order) for each of the keys which start with the
+// given prefix and are greater than or equal to 'from'.
+func (m *MemBackend) Iterate(prefix, from []byte, fn func(key, value []byte) bool) error {
+	m.mutex.RLock()
+
+	var kvs []*KV
+
+	for k, v := range m.data {
+		key := []byte(k)
+
+		if !bytes.HasPrefix(key, prefix) || (from != nil && bytes.Compare(key, from) < 0) {
+			continue
+		}
+
+		kvs = append(kvs, &KV{Key: key, Value: v})
+	}
+
+	m.mutex.RUnlock()
+
+	sort.Slice(kvs, func(i, j int) bool {
+		return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0
+	})
+
+	for _, kv := range kvs {
+		if !fn(kv.Key, kv.Value) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Close does nothing.
+func (m *MemBackend) Close() error {
+	return nil
+}
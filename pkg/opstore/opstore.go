@@ -0,0 +1,346 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package opstore implements the operation store (see api/store.OperationStore) on top of an embedded, ordered
+// key-value storage engine (Backend).
+//
+// Operations are stored under the key 'op/<suffix>/<transaction time><transaction number><operation index>' so that
+// iterating over the operations of a suffix returns the operations in the order in which they were anchored.
+// In addition, the following secondary index entries (whose value is the key of the operation) are stored
+// for each operation:
+//
+//   - time/<transaction time>/<suffix>/<position> - used for querying and deleting operations by transaction time
+//   - type/<operation type>/<transaction time>/<suffix>/<position> - used for querying operations by type
+//   - anchor/<anchor string>/<position>/<suffix> - used for retrieving the operations in a transaction
+package opstore
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+var logger = log.New("sidetree-core-opstore")
+
+const (
+	opPrefix     = "op/"
+	timePrefix   = "time/"
+	typePrefix   = "type/"
+	anchorPrefix = "anchor/"
+	separator    = "/"
+)
+
+// KV is a key-value pair.
+type KV struct {
+	Key   []byte
+	Value []byte
+}
+
+// Backend is an embedded, ordered key-value storage engine.
+type Backend interface {
+	// Put atomically stores the given key-value pairs.
+	Put(kvs []*KV) error
+	// Delete atomically deletes the given keys.
+	Delete(keys [][]byte) error
+	// Iterate invokes the given function (in ascending key order) for each of the keys which start with the
+	// given prefix and are greater than or equal to 'from' (if 'from' is nil then iteration starts at the prefix).
+	// Iteration stops if the function returns false.
+	Iterate(prefix, from []byte, fn func(key, value []byte) bool) error
+	// Close closes the backend.
+	Close() error
+}
+
+// Store implements an operation store on top of a key-value storage backend.
+type Store struct {
+	backend Backend
+}
+
+// New returns a new operation store using the given backend.
+func New(backend Backend) *Store {
+	return &Store{backend: backend}
+}
+
+// Put stores the given anchored operations.
+func (s *Store) Put(ops []*operation.AnchoredOperation) error {
+	var kvs []*KV
+
+	for _, op := range ops {
+		opBytes, err := json.Marshal(op)
+		if err != nil {
+			return fmt.Errorf("marshal operation for suffix[%s]: %s", op.UniqueSuffix, err.Error())
+		}
+
+		key := opKey(op)
+
+		kvs = append(kvs, &KV{Key: key, Value: opBytes})
+
+		for _, indexKey := range indexKeys(op) {
+			kvs = append(kvs, &KV{Key: indexKey, Value: key})
+		}
+	}
+
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	logger.Debugf("Storing %d operations", len(ops))
+
+	return s.backend.Put(kvs)
+}
+
+// Get retrieves all anchored operations for the given unique suffix (in the order in which they were anchored).
+func (s *Store) Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error) {
+	var ops []*operation.AnchoredOperation
+
+	err := s.Iterate(uniqueSuffix, func(op *operation.AnchoredOperation) bool {
+		ops = append(ops, op)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: no operations found for suffix[%s]", document.ErrNotFound, uniqueSuffix)
+	}
+
+	return ops, nil
+}
+
+// Iterate invokes the given function for each of the anchored operations for the given unique suffix
+// in the order in which they were anchored. Iteration stops if the function returns false.
+func (s *Store) Iterate(uniqueSuffix string, fn func(op *operation.AnchoredOperation) bool) error {
+	var unmarshalErr error
+
+	err := s.backend.Iterate(suffixPrefix(uniqueSuffix), nil, func(key, value []byte) bool {
+		op := &operation.AnchoredOperation{}
+
+		unmarshalErr = json.Unmarshal(value, op)
+		if unmarshalErr != nil {
+			unmarshalErr = fmt.Errorf("unmarshal operation [%s]: %s", key, unmarshalErr.Error())
+
+			return false
+		}
+
+		return fn(op)
+	})
+	if err != nil {
+		return fmt.Errorf("iterate operations for suffix[%s]: %s", uniqueSuffix, err.Error())
+	}
+
+	return unmarshalErr
+}
+
+// DeleteAfter deletes all operations (along with their index entries) which were anchored after the given
+// transaction time.
+func (s *Store) DeleteAfter(transactionTime uint64) error {
+	if transactionTime == math.MaxUint64 {
+		return nil
+	}
+
+	var opKeys [][]byte
+
+	from := []byte(timePrefix + uint64ToKey(transactionTime+1))
+
+	err := s.backend.Iterate([]byte(timePrefix), from, func(key, value []byte) bool {
+		opKeys = append(opKeys, copyBytes(value))
+
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("find operations anchored after transaction time [%d]: %s", transactionTime, err.Error())
+	}
+
+	if len(opKeys) == 0 {
+		return nil
+	}
+
+	var keys [][]byte
+
+	for _, opKey := range opKeys {
+		op, err := s.get(opKey)
+		if err != nil {
+			return err
+		}
+
+		keys = append(append(keys, opKey), indexKeys(op)...)
+	}
+
+	logger.Infof("Deleting %d operations anchored after transaction time [%d]", len(opKeys), transactionTime)
+
+	return s.backend.Delete(keys)
+}
+
+// Query returns a page of anchored operations (ordered by transaction time) which match the given query.
+func (s *Store) Query(query *store.Query) (*store.QueryResult, error) {
+	if query.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be greater than zero", store.ErrInvalidQuery)
+	}
+
+	prefix := []byte(timePrefix)
+	if query.OperationType != "" {
+		prefix = []byte(typePrefix + string(query.OperationType) + separator)
+	}
+
+	result := &store.QueryResult{}
+
+	if query.AnchoredAfter == math.MaxUint64 {
+		return result, nil
+	}
+
+	from := append(copyBytes(prefix), []byte(uint64ToKey(query.AnchoredAfter+1))...)
+
+	if query.Cursor != "" {
+		cursorKey, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+		if err != nil || !bytes.HasPrefix(cursorKey, prefix) {
+			return nil, fmt.Errorf("%w: malformed cursor [%s]", store.ErrInvalidQuery, query.Cursor)
+		}
+
+		// Start at the key which immediately follows the cursor.
+		if cursorKey = append(cursorKey, 0); bytes.Compare(cursorKey, from) > 0 {
+			from = cursorKey
+		}
+	}
+
+	var indexKeys, opKeys [][]byte
+
+	err := s.backend.Iterate(prefix, from, func(key, value []byte) bool {
+		if len(opKeys) == query.Limit {
+			// There's at least one more operation so return a cursor.
+			result.Cursor = base64.RawURLEncoding.EncodeToString(indexKeys[len(indexKeys)-1])
+
+			return false
+		}
+
+		indexKeys = append(indexKeys, copyBytes(key))
+		opKeys = append(opKeys, copyBytes(value))
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query operations: %s", err.Error())
+	}
+
+	for _, opKey := range opKeys {
+		op, err := s.get(opKey)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Operations = append(result.Operations, op)
+	}
+
+	return result, nil
+}
+
+// GetByAnchorString returns the anchored operations which were batched in the transaction with the given
+// anchor string (in the order in which they appear in the batch).
+func (s *Store) GetByAnchorString(anchorString string) ([]*operation.AnchoredOperation, error) {
+	var opKeys [][]byte
+
+	err := s.backend.Iterate([]byte(anchorPrefix+anchorString+separator), nil, func(key, value []byte) bool {
+		opKeys = append(opKeys, copyBytes(value))
+
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find operations for anchor string [%s]: %s", anchorString, err.Error())
+	}
+
+	var ops []*operation.AnchoredOperation
+
+	for _, opKey := range opKeys {
+		op, err := s.get(opKey)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+// Close closes the underlying backend.
+func (s *Store) Close() error {
+	return s.backend.Close()
+}
+
+func suffixPrefix(uniqueSuffix string) []byte {
+	return []byte(opPrefix + uniqueSuffix + separator)
+}
+
+func opKey(op *operation.AnchoredOperation) []byte {
+	return append(suffixPrefix(op.UniqueSuffix), []byte(positionKey(op))...)
+}
+
+// get returns the operation stored under the given key.
+func (s *Store) get(key []byte) (*operation.AnchoredOperation, error) {
+	var value []byte
+
+	err := s.backend.Iterate(key, nil, func(k, v []byte) bool {
+		if bytes.Equal(k, key) {
+			value = copyBytes(v)
+		}
+
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get operation [%s]: %s", key, err.Error())
+	}
+
+	if value == nil {
+		return nil, fmt.Errorf("operation [%s] referenced by index not found", key)
+	}
+
+	op := &operation.AnchoredOperation{}
+
+	err = json.Unmarshal(value, op)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal operation [%s]: %s", key, err.Error())
+	}
+
+	return op, nil
+}
+
+// indexKeys returns the keys of the secondary index entries for the given operation.
+func indexKeys(op *operation.AnchoredOperation) [][]byte {
+	txnTime := uint64ToKey(op.TransactionTime)
+	position := positionKey(op)
+
+	keys := [][]byte{
+		[]byte(timePrefix + txnTime + separator + op.UniqueSuffix + separator + position),
+		[]byte(typePrefix + string(op.Type) + separator + txnTime + separator + op.UniqueSuffix + separator + position),
+	}
+
+	if op.AnchorString != "" {
+		keys = append(keys, []byte(anchorPrefix+op.AnchorString+separator+position+separator+op.UniqueSuffix))
+	}
+
+	return keys
+}
+
+// positionKey returns the position of the operation in the ledger in a format which sorts lexicographically.
+func positionKey(op *operation.AnchoredOperation) string {
+	return uint64ToKey(op.TransactionTime) + uint64ToKey(op.TransactionNumber) + uint64ToKey(uint64(op.OperationIndex))
+}
+
+func uint64ToKey(v uint64) string {
+	return fmt.Sprintf("%016x", v)
+}
+
+func copyBytes(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
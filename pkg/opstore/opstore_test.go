@@ -0,0 +1,346 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package opstore
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const (
+	suffix1 = "suffix1"
+	suffix2 = "suffix2"
+	suffix3 = "suffix3"
+
+	anchor1 = "1.anchor1"
+	anchor2 = "1.anchor2"
+)
+
+var (
+	_ store.OperationStore = (*Store)(nil)
+	_ store.OperationIndex = (*Store)(nil)
+)
+
+func TestStore(t *testing.T) {
+	runStoreTests(t, func(t *testing.T) Backend {
+		return NewMemBackend()
+	})
+}
+
+func TestStore_Errors(t *testing.T) {
+	t.Run("backend error", func(t *testing.T) {
+		s := New(&errBackend{err: errors.New("injected backend error")})
+
+		_, err := s.Get(suffix1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "injected backend error")
+
+		err = s.DeleteAfter(0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "injected backend error")
+	})
+
+	t.Run("dangling index entry", func(t *testing.T) {
+		b := NewMemBackend()
+		require.NoError(t, b.Put([]*KV{{Key: []byte(timePrefix + uint64ToKey(1)), Value: []byte("op/missing")}}))
+
+		_, err := New(b).Query(&store.Query{Limit: 1})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "referenced by index not found")
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		b := NewMemBackend()
+		require.NoError(t, b.Put([]*KV{{Key: suffixPrefix(suffix1), Value: []byte("{")}}))
+
+		_, err := New(b).Get(suffix1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal operation")
+	})
+}
+
+// runStoreTests runs the operation store tests against the backend returned by the given function.
+func runStoreTests(t *testing.T, newBackend func(t *testing.T) Backend) {
+	t.Run("not found", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		ops, err := s.Get(suffix1)
+		require.True(t, errors.Is(err, document.ErrNotFound))
+		require.Nil(t, ops)
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		require.NoError(t, s.Put(nil))
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{
+			newOp(suffix1, operation.TypeUpdate, 20, 1, 0),
+			newOp(suffix2, operation.TypeCreate, 10, 1, 1),
+			newOp(suffix1, operation.TypeCreate, 10, 1, 0),
+			newOp(suffix1, operation.TypeUpdate, 20, 0, 3),
+		}))
+
+		ops, err := s.Get(suffix1)
+		require.NoError(t, err)
+		requireOps(t, ops, "10-1-0", "20-0-3", "20-1-0")
+
+		ops, err = s.Get(suffix2)
+		require.NoError(t, err)
+		requireOps(t, ops, "10-1-1")
+	})
+
+	t.Run("put same operation twice", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		op := newOp(suffix1, operation.TypeCreate, 10, 1, 0)
+
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{op}))
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{op}))
+
+		ops, err := s.Get(suffix1)
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		require.Equal(t, op, ops[0])
+	})
+
+	t.Run("iterate", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{
+			newOp(suffix1, operation.TypeCreate, 10, 1, 0),
+			newOp(suffix1, operation.TypeUpdate, 11, 1, 0),
+			newOp(suffix1, operation.TypeUpdate, 12, 1, 0),
+		}))
+
+		var ops []*operation.AnchoredOperation
+
+		require.NoError(t, s.Iterate(suffix1, func(op *operation.AnchoredOperation) bool {
+			ops = append(ops, op)
+
+			return len(ops) < 2
+		}))
+		requireOps(t, ops, "10-1-0", "11-1-0")
+
+		ops = nil
+
+		require.NoError(t, s.Iterate(suffix2, func(op *operation.AnchoredOperation) bool {
+			ops = append(ops, op)
+
+			return true
+		}))
+		require.Empty(t, ops)
+	})
+
+	t.Run("delete after", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{
+			newOp(suffix1, operation.TypeCreate, 10, 1, 0),
+			newOp(suffix1, operation.TypeUpdate, 11, 1, 0),
+			newOp(suffix1, operation.TypeUpdate, 12, 1, 0),
+			newOp(suffix2, operation.TypeCreate, 12, 1, 1),
+		}))
+
+		require.NoError(t, s.DeleteAfter(12))
+
+		ops, err := s.Get(suffix1)
+		require.NoError(t, err)
+		requireOps(t, ops, "10-1-0", "11-1-0", "12-1-0")
+
+		require.NoError(t, s.DeleteAfter(10))
+
+		ops, err = s.Get(suffix1)
+		require.NoError(t, err)
+		requireOps(t, ops, "10-1-0")
+
+		_, err = s.Get(suffix2)
+		require.True(t, errors.Is(err, document.ErrNotFound))
+
+		require.NoError(t, s.DeleteAfter(10))
+		require.NoError(t, s.Close())
+	})
+
+	t.Run("query", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{
+			newOp(suffix1, operation.TypeCreate, 10, 1, 0),
+			newOp(suffix2, operation.TypeCreate, 10, 1, 1),
+			newOp(suffix1, operation.TypeUpdate, 11, 1, 0),
+			newOp(suffix2, operation.TypeDeactivate, 12, 1, 0),
+			newOp(suffix3, operation.TypeCreate, 13, 1, 0),
+		}))
+
+		t.Run("all types", func(t *testing.T) {
+			result, err := s.Query(&store.Query{Limit: 10})
+			require.NoError(t, err)
+			requireOps(t, result.Operations, "10-1-0", "10-1-1", "11-1-0", "12-1-0", "13-1-0")
+			require.Empty(t, result.Cursor)
+		})
+
+		t.Run("by type", func(t *testing.T) {
+			result, err := s.Query(&store.Query{OperationType: operation.TypeCreate, Limit: 10})
+			require.NoError(t, err)
+			requireOps(t, result.Operations, "10-1-0", "10-1-1", "13-1-0")
+
+			result, err = s.Query(&store.Query{OperationType: operation.TypeDeactivate, Limit: 10})
+			require.NoError(t, err)
+			requireOps(t, result.Operations, "12-1-0")
+			require.Equal(t, suffix2, result.Operations[0].UniqueSuffix)
+
+			result, err = s.Query(&store.Query{OperationType: operation.TypeRecover, Limit: 10})
+			require.NoError(t, err)
+			require.Empty(t, result.Operations)
+		})
+
+		t.Run("anchored after", func(t *testing.T) {
+			result, err := s.Query(&store.Query{OperationType: operation.TypeCreate, AnchoredAfter: 10, Limit: 10})
+			require.NoError(t, err)
+			requireOps(t, result.Operations, "13-1-0")
+
+			result, err = s.Query(&store.Query{AnchoredAfter: math.MaxUint64, Limit: 10})
+			require.NoError(t, err)
+			require.Empty(t, result.Operations)
+		})
+
+		t.Run("paging", func(t *testing.T) {
+			query := &store.Query{AnchoredAfter: 9, Limit: 2}
+
+			var pages [][]string
+
+			for {
+				result, err := s.Query(query)
+				require.NoError(t, err)
+
+				var page []string
+				for _, op := range result.Operations {
+					page = append(page, position(op))
+				}
+
+				pages = append(pages, page)
+
+				if result.Cursor == "" {
+					break
+				}
+
+				query.Cursor = result.Cursor
+			}
+
+			require.Equal(t, [][]string{{"10-1-0", "10-1-1"}, {"11-1-0", "12-1-0"}, {"13-1-0"}}, pages)
+		})
+
+		t.Run("invalid query", func(t *testing.T) {
+			_, err := s.Query(&store.Query{})
+			require.True(t, errors.Is(err, store.ErrInvalidQuery))
+
+			_, err = s.Query(&store.Query{Limit: 1, Cursor: "!!!"})
+			require.True(t, errors.Is(err, store.ErrInvalidQuery))
+
+			result, err := s.Query(&store.Query{Limit: 1})
+			require.NoError(t, err)
+			require.NotEmpty(t, result.Cursor)
+
+			// A cursor from a different index may not be used.
+			_, err = s.Query(&store.Query{OperationType: operation.TypeCreate, Limit: 1, Cursor: result.Cursor})
+			require.True(t, errors.Is(err, store.ErrInvalidQuery))
+		})
+	})
+
+	t.Run("get by anchor string", func(t *testing.T) {
+		s := New(newBackend(t))
+
+		op1 := newOp(suffix2, operation.TypeCreate, 10, 1, 0)
+		op1.AnchorString = anchor1
+
+		op2 := newOp(suffix1, operation.TypeCreate, 10, 1, 1)
+		op2.AnchorString = anchor1
+
+		op3 := newOp(suffix1, operation.TypeUpdate, 11, 1, 0)
+		op3.AnchorString = anchor2
+
+		require.NoError(t, s.Put([]*operation.AnchoredOperation{op3, op2, op1}))
+
+		ops, err := s.GetByAnchorString(anchor1)
+		require.NoError(t, err)
+		require.Equal(t, []*operation.AnchoredOperation{op1, op2}, ops)
+
+		ops, err = s.GetByAnchorString("unknown")
+		require.NoError(t, err)
+		require.Empty(t, ops)
+
+		t.Run("index entries are deleted", func(t *testing.T) {
+			require.NoError(t, s.DeleteAfter(10))
+
+			ops, err = s.GetByAnchorString(anchor2)
+			require.NoError(t, err)
+			require.Empty(t, ops)
+
+			result, err := s.Query(&store.Query{OperationType: operation.TypeUpdate, Limit: 10})
+			require.NoError(t, err)
+			require.Empty(t, result.Operations)
+		})
+	})
+}
+
+func newOp(suffix string, opType operation.Type, txnTime, txnNumber uint64, index uint) *operation.AnchoredOperation {
+	return &operation.AnchoredOperation{
+		Type:              opType,
+		UniqueSuffix:      suffix,
+		TransactionTime:   txnTime,
+		TransactionNumber: txnNumber,
+		OperationIndex:    index,
+	}
+}
+
+func requireOps(t *testing.T, ops []*operation.AnchoredOperation, expected ...string) {
+	t.Helper()
+
+	var positions []string
+	for _, op := range ops {
+		positions = append(positions, position(op))
+	}
+
+	require.Equal(t, expected, positions)
+}
+
+func position(op *operation.AnchoredOperation) string {
+	return fmtUint(op.TransactionTime) + "-" + fmtUint(op.TransactionNumber) + "-" + fmtUint(uint64(op.OperationIndex))
+}
+
+func fmtUint(v uint64) string {
+	const base = 10
+
+	return strconv.FormatUint(v, base)
+}
+
+type errBackend struct {
+	err error
+}
+
+func (b *errBackend) Put([]*KV) error {
+	return b.err
+}
+
+func (b *errBackend) Delete([][]byte) error {
+	return b.err
+}
+
+func (b *errBackend) Iterate([]byte, []byte, func(key, value []byte) bool) error {
+	return b.err
+}
+
+func (b *errBackend) Close() error {
+	return nil
+}
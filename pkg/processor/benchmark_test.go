@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+)
+
+// benchmarkOperationCounts are the numbers of operations (including the create operation) of the generated DIDs.
+var benchmarkOperationCounts = []int{1, 100, 1000}
+
+func BenchmarkResolve(b *testing.B) {
+	for _, numOps := range benchmarkOperationCounts {
+		store, uniqueSuffix := getStoreWithOperations(b, numOps)
+		p := New("test", store, newMockProtocolClient())
+
+		b.Run(fmt.Sprintf("%d operations", numOps), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := p.Resolve(uniqueSuffix); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// getStoreWithOperations returns a store which contains a create operation followed by (numOps - 1)
+// update operations. Each update operation adds a service to the document.
+func getStoreWithOperations(b *testing.B, numOps int) (*mocks.MockOperationStore, string) {
+	b.Helper()
+
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+	var ops []*operation.AnchoredOperation
+
+	for i := 1; i < numOps; i++ {
+		servicePatch, err := patch.NewAddServiceEndpointsPatch(fmt.Sprintf(
+			`[{"id": "service%d", "type": "service", "serviceEndpoint": "https://example.com/%d"}]`, i, i))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		op, nextUpdateKey, err := getUpdateOperationWithPatch(ecsigner.New(updateKey, "ES256", updateKeyID),
+			updateKey, uniqueSuffix, defaultBlockNumber+1, servicePatch)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		anchoredOp := getAnchoredOperation(op, defaultBlockNumber+1)
+		anchoredOp.TransactionNumber = uint64(i)
+
+		ops = append(ops, anchoredOp)
+		updateKey = nextUpdateKey
+	}
+
+	if err := store.Put(ops); err != nil {
+		b.Fatal(err)
+	}
+
+	return store, uniqueSuffix
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+// The tests in this file verify the tie-breaking policy that is applied when several anchored operations
+// reveal the same commitment: operations are ordered by transaction time, transaction number and position
+// within the batch. Each scenario resolves the document from stores that contain the same operations in every
+// possible order (as if they had been stored by different nodes) and checks that all resolutions are identical.
+
+type anchoringPosition struct {
+	txnTime   uint64
+	txnNumber uint64
+	index     uint
+}
+
+func TestConformance_CommitmentTieBreaking(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []anchoringPosition
+		winner    int
+	}{
+		{
+			name:      "earlier transaction time wins over lower transaction number",
+			positions: []anchoringPosition{{txnTime: 2, txnNumber: 0}, {txnTime: 1, txnNumber: 5}},
+			winner:    1,
+		},
+		{
+			name:      "same transaction time - lower transaction number wins",
+			positions: []anchoringPosition{{txnTime: 1, txnNumber: 2}, {txnTime: 1, txnNumber: 1}},
+			winner:    1,
+		},
+		{
+			name:      "same transaction - lower position within batch wins",
+			positions: []anchoringPosition{{txnTime: 1, txnNumber: 1, index: 3}, {txnTime: 1, txnNumber: 1, index: 1}},
+			winner:    1,
+		},
+		{
+			name: "three candidates",
+			positions: []anchoringPosition{
+				{txnTime: 1, txnNumber: 1, index: 2},
+				{txnTime: 1, txnNumber: 1, index: 0},
+				{txnTime: 1, txnNumber: 0, index: 4},
+			},
+			winner: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			createOp, updateOps, expected := getCompetingUpdates(t, tc.positions)
+
+			docs := resolveAllPermutations(t, append([]*operation.AnchoredOperation{createOp}, updateOps...), createOp.UniqueSuffix)
+
+			for _, doc := range docs {
+				require.Equal(t, expected[tc.winner], doc["test"])
+				require.Equal(t, docs[0], doc)
+			}
+		})
+	}
+}
+
+func TestConformance_IdenticalAnchoringPosition(t *testing.T) {
+	// operations at the same anchoring position can only be stored by a misbehaving node; the resolved document
+	// must still be deterministic
+	positions := []anchoringPosition{{txnTime: 1, txnNumber: 1}, {txnTime: 1, txnNumber: 1}}
+
+	createOp, updateOps, _ := getCompetingUpdates(t, positions)
+
+	docs := resolveAllPermutations(t, append([]*operation.AnchoredOperation{createOp}, updateOps...), createOp.UniqueSuffix)
+
+	for _, doc := range docs {
+		require.Equal(t, docs[0], doc)
+	}
+}
+
+func TestCompareOperations(t *testing.T) {
+	op := &operation.AnchoredOperation{TransactionTime: 1, TransactionNumber: 1, OperationIndex: 1, OperationBuffer: []byte("b")}
+
+	require.Equal(t, 0, compareOperations(op, op))
+	require.Equal(t, -1, compareOperations(&operation.AnchoredOperation{TransactionTime: 0, TransactionNumber: 5}, op))
+	require.Equal(t, 1, compareOperations(&operation.AnchoredOperation{TransactionTime: 1, TransactionNumber: 2}, op))
+	require.Equal(t, -1, compareOperations(&operation.AnchoredOperation{TransactionTime: 1, TransactionNumber: 1}, op))
+	require.Equal(t, 1, compareOperations(&operation.AnchoredOperation{TransactionTime: 1, TransactionNumber: 1, OperationIndex: 2}, op))
+	require.Equal(t, -1, compareOperations(&operation.AnchoredOperation{TransactionTime: 1, TransactionNumber: 1, OperationIndex: 1, OperationBuffer: []byte("a")}, op))
+}
+
+// getCompetingUpdates returns a create operation and update operations (one for each of the given positions) that
+// all reveal the same update commitment. The expected value of the 'test' field is returned for each update.
+func getCompetingUpdates(t *testing.T, positions []anchoringPosition) (*operation.AnchoredOperation, []*operation.AnchoredOperation, []string) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	var updateOps []*operation.AnchoredOperation
+
+	var expected []string
+
+	for i, pos := range positions {
+		// block number is only used to generate a distinct patch value for each update
+		op, _, err := getAnchoredUpdateOperation(updateKey, createOp.UniqueSuffix, uint64(i+1))
+		require.NoError(t, err)
+
+		op.TransactionTime = pos.txnTime
+		op.TransactionNumber = pos.txnNumber
+		op.OperationIndex = pos.index
+		op.ProtocolGenesisTime = defaultBlockNumber
+
+		updateOps = append(updateOps, op)
+		expected = append(expected, "special"+uintToStr(uint(i+1)))
+	}
+
+	return createOp, updateOps, expected
+}
+
+func resolveAllPermutations(t *testing.T, ops []*operation.AnchoredOperation, uniqueSuffix string) []document.Document {
+	var docs []document.Document
+
+	for _, perm := range permutations(ops) {
+		store := mocks.NewMockOperationStore(nil)
+		store.Validate = false
+
+		for _, op := range perm {
+			require.NoError(t, store.Put([]*operation.AnchoredOperation{op}))
+		}
+
+		result, err := New("test", store, newMockProtocolClient()).Resolve(uniqueSuffix)
+		require.NoError(t, err)
+
+		docs = append(docs, result.Document)
+	}
+
+	return docs
+}
+
+func permutations(ops []*operation.AnchoredOperation) [][]*operation.AnchoredOperation {
+	if len(ops) <= 1 {
+		return [][]*operation.AnchoredOperation{ops}
+	}
+
+	var result [][]*operation.AnchoredOperation
+
+	for i, op := range ops {
+		rest := make([]*operation.AnchoredOperation, 0, len(ops)-1)
+		rest = append(rest, ops[:i]...)
+		rest = append(rest, ops[i+1:]...)
+
+		for _, perm := range permutations(rest) {
+			result = append(result, append([]*operation.AnchoredOperation{op}, perm...))
+		}
+	}
+
+	return result
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package processor
 
 import (
+	"bytes"
 	"crypto"
 	"errors"
 	"fmt"
@@ -32,9 +33,11 @@ type OperationProcessor struct {
 	pc    protocol.Client
 }
 
-// OperationStoreClient defines interface for retrieving all operations related to document.
+// OperationStoreClient defines interface for retrieving all operations related to document
+// (a subset of api/store.OperationStore).
 type OperationStoreClient interface {
-	// Get retrieves all operations related to document
+	// Get retrieves all operations related to document. If there are no operations for the given suffix
+	// then the returned error should wrap document.ErrNotFound.
 	Get(uniqueSuffix string) ([]*operation.AnchoredOperation, error)
 }
 
@@ -58,25 +61,28 @@ func (s *OperationProcessor) Resolve(uniqueSuffix string) (*document.ResolutionR
 
 	rm := &protocol.ResolutionModel{}
 
+	// each operation is parsed at most once during the resolution
+	parsed := make(parsedOperations)
+
 	// split operations into 'create', 'update' and 'full' operations
 	createOps, updateOps, fullOps := splitOperations(ops)
 	if len(createOps) == 0 {
-		return nil, errors.New("missing create operation")
+		return nil, fmt.Errorf("%w: missing create operation", document.ErrNotFound)
 	}
 
 	// apply 'create' operations first
-	rm = s.applyFirstValidCreateOperation(createOps, rm)
+	rm = s.applyFirstValidCreateOperation(createOps, rm, parsed)
 	if rm == nil {
-		return nil, errors.New("valid create operation not found")
+		return nil, fmt.Errorf("%w: valid create operation not found", document.ErrNotFound)
 	}
 
 	// apply 'full' operations first
 	if len(fullOps) > 0 {
 		logger.Debugf("[%s] Applying %d full operations for unique suffix [%s]", s.name, len(fullOps), uniqueSuffix)
 
-		rm = s.applyOperations(fullOps, rm, getRecoveryCommitment)
+		rm = s.applyOperations(fullOps, rm, getRecoveryCommitment, parsed)
 		if rm.Doc == nil {
-			return nil, errors.New("document was deactivated")
+			return nil, document.ErrDeactivated
 		}
 	}
 
@@ -84,7 +90,7 @@ func (s *OperationProcessor) Resolve(uniqueSuffix string) (*document.ResolutionR
 	filteredUpdateOps := getOpsWithTxnGreaterThan(updateOps, rm.LastOperationTransactionTime, rm.LastOperationTransactionNumber)
 	if len(filteredUpdateOps) > 0 {
 		logger.Debugf("[%s] Applying %d update operations after last full operation for unique suffix [%s]", s.name, len(filteredUpdateOps), uniqueSuffix)
-		rm = s.applyOperations(filteredUpdateOps, rm, getUpdateCommitment)
+		rm = s.applyOperations(filteredUpdateOps, rm, getUpdateCommitment, parsed)
 	}
 
 	return &document.ResolutionResult{
@@ -96,34 +102,35 @@ func (s *OperationProcessor) Resolve(uniqueSuffix string) (*document.ResolutionR
 	}, nil
 }
 
-func (s *OperationProcessor) createOperationHashMap(ops []*operation.AnchoredOperation, params *commitmentParams) map[string][]*operation.AnchoredOperation {
-	const keyFormat = "%s_%s"
+func (s *OperationProcessor) createOperationHashMap(ops []*operation.AnchoredOperation, params *commitmentParams, parsed parsedOperations) map[string][]*operation.AnchoredOperation {
+	const keyFormat = "%s_%s_%t"
 
 	opMap := make(map[string][]*operation.AnchoredOperation)
 
 	previousVersions := make(map[string]*commitmentParams)
 	if params != nil {
-		previousVersions[fmt.Sprintf(keyFormat, uintToStr(params.MultihashCode), uintToStr(params.HashCode))] = params
+		previousVersions[fmt.Sprintf(keyFormat, uintToStr(params.MultihashCode), uintToStr(params.HashCode), params.JWKThumbprints)] = params
 	}
 
 	for _, op := range ops {
-		r, p, err := s.getRevealValue(op)
+		r, p, err := s.getRevealValue(op, parsed)
 		if err != nil {
 			logger.Infof("[%s] Skipped bad operation while creating operation hash map {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
 			continue
 		}
 
-		key := fmt.Sprintf(keyFormat, uintToStr(p.MultihashAlgorithm), uintToStr(p.HashAlgorithm))
+		key := fmt.Sprintf(keyFormat, uintToStr(p.MultihashAlgorithm), uintToStr(p.HashAlgorithm), p.JWKThumbprints)
 
 		if _, ok := previousVersions[key]; !ok {
 			previousVersions[key] = &commitmentParams{
-				HashCode:      p.HashAlgorithm,
-				MultihashCode: p.MultihashAlgorithm,
+				HashCode:       p.HashAlgorithm,
+				MultihashCode:  p.MultihashAlgorithm,
+				JWKThumbprints: p.JWKThumbprints,
 			}
 		}
 		for _, val := range previousVersions {
-			c, err := commitment.Calculate(r, val.MultihashCode, crypto.Hash(val.HashCode))
+			c, err := val.calculate(r)
 			if err != nil {
 				logger.Infof("[%s] Skipped calculating commitment while creating operation hash map {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
@@ -177,7 +184,7 @@ func getOpsWithTxnGreaterThan(ops []*operation.AnchoredOperation, txnTime, txnNu
 	return nil
 }
 
-func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, commitmentFnc fnc) *protocol.ResolutionModel {
+func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, commitmentFnc fnc, parsed parsedOperations) *protocol.ResolutionModel {
 	// suffix for logging
 	uniqueSuffix := ops[0].UniqueSuffix
 
@@ -191,9 +198,10 @@ func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation,
 	}
 
 	opMap := s.createOperationHashMap(ops, &commitmentParams{
-		HashCode:      p.Protocol().HashAlgorithm,
-		MultihashCode: p.Protocol().MultihashAlgorithm,
-	})
+		HashCode:       p.Protocol().HashAlgorithm,
+		MultihashCode:  p.Protocol().MultihashAlgorithm,
+		JWKThumbprints: p.Protocol().JWKThumbprints,
+	}, parsed)
 
 	// holds applied commitments
 	commitmentMap := make(map[string]bool)
@@ -205,7 +213,7 @@ func (s *OperationProcessor) applyOperations(ops []*operation.AnchoredOperation,
 	for ok {
 		logger.Debugf("[%s] Found %d operation(s) for commitment '%s' {UniqueSuffix: %s}", s.name, len(commitmentOps), c, uniqueSuffix)
 
-		newState := s.applyFirstValidOperation(commitmentOps, state, c, commitmentMap)
+		newState := s.applyFirstValidOperation(commitmentOps, state, c, commitmentMap, parsed)
 
 		// can't find a valid operation to apply
 		if newState == nil {
@@ -250,12 +258,12 @@ func getRecoveryCommitment(rm *protocol.ResolutionModel) string {
 	return rm.RecoveryCommitment
 }
 
-func (s *OperationProcessor) applyFirstValidCreateOperation(createOps []*operation.AnchoredOperation, rm *protocol.ResolutionModel) *protocol.ResolutionModel {
+func (s *OperationProcessor) applyFirstValidCreateOperation(createOps []*operation.AnchoredOperation, rm *protocol.ResolutionModel, parsed parsedOperations) *protocol.ResolutionModel {
 	for _, op := range createOps {
 		var state *protocol.ResolutionModel
 		var err error
 
-		if state, err = s.applyOperation(op, rm); err != nil {
+		if state, err = s.applyOperation(op, rm, parsed); err != nil {
 			logger.Infof("[%s] Skipped bad operation {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
 			continue
@@ -270,12 +278,12 @@ func (s *OperationProcessor) applyFirstValidCreateOperation(createOps []*operati
 }
 
 // this function should be used for update, recover and deactivate operations (create is handled differently).
-func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, currCommitment string, processedCommitments map[string]bool) *protocol.ResolutionModel {
+func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredOperation, rm *protocol.ResolutionModel, currCommitment string, processedCommitments map[string]bool, parsed parsedOperations) *protocol.ResolutionModel {
 	for _, op := range ops {
 		var state *protocol.ResolutionModel
 		var err error
 
-		nextCommitment, err := s.getCommitment(op)
+		nextCommitment, err := s.getCommitment(op, parsed)
 		if err != nil {
 			logger.Infof("[%s] Skipped bad operation {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
@@ -298,7 +306,7 @@ func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredO
 			}
 		}
 
-		if state, err = s.applyOperation(op, rm); err != nil {
+		if state, err = s.applyOperation(op, rm, parsed); err != nil {
 			logger.Infof("[%s] Skipped bad operation {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", s.name, op.UniqueSuffix, op.Type, op.TransactionTime, op.TransactionNumber, err)
 
 			continue
@@ -312,58 +320,116 @@ func (s *OperationProcessor) applyFirstValidOperation(ops []*operation.AnchoredO
 	return nil
 }
 
-func (s *OperationProcessor) applyOperation(op *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
-	p, err := s.pc.Get(op.ProtocolGenesisTime)
+func (s *OperationProcessor) applyOperation(op *operation.AnchoredOperation, rm *protocol.ResolutionModel, parsed parsedOperations) (*protocol.ResolutionModel, error) {
+	parsedOp, p, err := s.parse(op, parsed)
 	if err != nil {
 		return nil, fmt.Errorf("apply '%s' operation: %s", op.Type, err.Error())
 	}
 
-	return p.OperationApplier().Apply(op, rm)
+	return p.OperationApplier().ApplyParsed(parsedOp, rm)
 }
 
+// sortOperations sorts operations in the order in which they were anchored. The order is fully deterministic
+// (independent of the order in which the operations were returned by the operation store) so that all nodes
+// select the same operation when several operations reveal the same commitment. Operations are ordered by:
+// 1) transaction time
+// 2) transaction number
+// 3) position of the operation within the batch
+// 4) operation buffer (byte-wise) - only relevant if the store contains different operations at the same position.
 func sortOperations(ops []*operation.AnchoredOperation) {
 	sort.Slice(ops, func(i, j int) bool {
-		if ops[i].TransactionTime < ops[j].TransactionTime {
-			return true
-		}
-
-		return ops[i].TransactionNumber < ops[j].TransactionNumber
+		return compareOperations(ops[i], ops[j]) < 0
 	})
 }
 
-func (s *OperationProcessor) getRevealValue(op *operation.AnchoredOperation) (*jws.JWK, protocol.Protocol, error) {
+func compareOperations(op1, op2 *operation.AnchoredOperation) int {
+	switch {
+	case op1.TransactionTime != op2.TransactionTime:
+		return compareUint64(op1.TransactionTime, op2.TransactionTime)
+	case op1.TransactionNumber != op2.TransactionNumber:
+		return compareUint64(op1.TransactionNumber, op2.TransactionNumber)
+	case op1.OperationIndex != op2.OperationIndex:
+		return compareUint64(uint64(op1.OperationIndex), uint64(op2.OperationIndex))
+	default:
+		return bytes.Compare(op1.OperationBuffer, op2.OperationBuffer)
+	}
+}
+
+func compareUint64(v1, v2 uint64) int {
+	switch {
+	case v1 < v2:
+		return -1
+	case v1 > v2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (s *OperationProcessor) getRevealValue(op *operation.AnchoredOperation, parsed parsedOperations) (*jws.JWK, protocol.Protocol, error) {
 	if op.Type == operation.TypeCreate {
 		return nil, protocol.Protocol{}, errors.New("create operation doesn't have reveal value")
 	}
 
-	p, err := s.pc.Get(op.ProtocolGenesisTime)
+	parsedOp, p, err := s.parse(op, parsed)
 	if err != nil {
-		return nil, protocol.Protocol{}, fmt.Errorf("get operation reveal value - retrieve protocol: %s", err.Error())
+		return nil, protocol.Protocol{}, fmt.Errorf("get operation reveal value: %s", err.Error())
+	}
+
+	return parsedOp.RevealValue, p.Protocol(), nil
+}
+
+func (s *OperationProcessor) getCommitment(op *operation.AnchoredOperation, parsed parsedOperations) (string, error) {
+	if op.Type == operation.TypeCreate {
+		return "", fmt.Errorf("operation type '%s' not supported for getting next operation commitment", op.Type)
 	}
 
-	commitmentKey, err := p.OperationParser().GetRevealValue(op.OperationBuffer)
+	parsedOp, _, err := s.parse(op, parsed)
 	if err != nil {
-		return nil, protocol.Protocol{}, fmt.Errorf("get operation reveal value from operation parser: %s", err.Error())
+		return "", fmt.Errorf("get next operation commitment: %s", err.Error())
 	}
 
-	return commitmentKey, p.Protocol(), nil
+	return parsedOp.NextCommitment, nil
 }
 
-func (s *OperationProcessor) getCommitment(op *operation.AnchoredOperation) (string, error) {
-	p, err := s.pc.Get(op.ProtocolGenesisTime)
-	if err != nil {
-		return "", fmt.Errorf("get next operation commitment: %s", err.Error())
+// parsedOperations contains the results of parsing the operations of a single resolution.
+type parsedOperations map[*operation.AnchoredOperation]*parseResult
+
+type parseResult struct {
+	op  *protocol.ParsedOperation
+	pv  protocol.Version
+	err error
+}
+
+// parse returns the parsed operation together with the protocol version which parsed it. The operation is only
+// parsed if it hasn't been parsed yet during the resolution (the parse error is also kept).
+func (s *OperationProcessor) parse(op *operation.AnchoredOperation, parsed parsedOperations) (*protocol.ParsedOperation, protocol.Version, error) {
+	if r, ok := parsed[op]; ok {
+		return r.op, r.pv, r.err
 	}
 
-	nextCommitment, err := p.OperationParser().GetCommitment(op.OperationBuffer)
-	if err != nil {
-		return "", fmt.Errorf("get commitment from operation parser: %s", err.Error())
+	r := &parseResult{}
+
+	r.pv, r.err = s.pc.Get(op.ProtocolGenesisTime)
+	if r.err == nil {
+		r.op, r.err = r.pv.OperationParser().ParseAnchoredOperation(op)
 	}
 
-	return nextCommitment, nil
+	parsed[op] = r
+
+	return r.op, r.pv, r.err
 }
 
 type commitmentParams struct {
-	MultihashCode uint
-	HashCode      uint
+	MultihashCode  uint
+	HashCode       uint
+	JWKThumbprints bool
+}
+
+func (p *commitmentParams) calculate(jwk *jws.JWK) (string, error) {
+	if p.JWKThumbprints {
+		return commitment.CalculateFromThumbprint(jwk, p.MultihashCode, crypto.Hash(p.HashCode))
+	}
+
+	return commitment.Calculate(jwk, p.MultihashCode, crypto.Hash(p.HashCode))
 }
@@ -71,7 +71,8 @@ func TestResolve(t *testing.T) {
 		doc, err := op.Resolve(dummyUniqueSuffix)
 		require.Nil(t, doc)
 		require.Error(t, err)
-		require.Equal(t, "uniqueSuffix not found in the store", err.Error())
+		require.True(t, errors.Is(err, document.ErrNotFound))
+		require.Contains(t, err.Error(), "uniqueSuffix not found in the store")
 	})
 
 	t.Run("store error", func(t *testing.T) {
@@ -95,7 +96,7 @@ func TestResolve(t *testing.T) {
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
 
-		doc, err := op.applyOperation(createOp, &protocol.ResolutionModel{})
+		doc, err := op.applyOperation(createOp, &protocol.ResolutionModel{}, make(parsedOperations))
 		require.Nil(t, doc)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "apply 'create' operation: protocol parameters are not defined for blockchain time")
@@ -109,13 +110,14 @@ func TestResolve(t *testing.T) {
 
 		createOp.SuffixData = &model.SuffixDataModel{}
 
-		err = store.Put(getAnchoredOperation(createOp, defaultBlockNumber))
+		err = store.Put([]*operation.AnchoredOperation{getAnchoredOperation(createOp, defaultBlockNumber)})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
 		doc, err := p.Resolve(createOp.UniqueSuffix)
 		require.Error(t, err)
 		require.Nil(t, doc)
+		require.True(t, errors.Is(err, document.ErrNotFound))
 		require.Contains(t, err.Error(), "valid create operation not found")
 	})
 }
@@ -136,7 +138,7 @@ func TestUpdateDocument(t *testing.T) {
 		updateOp, nextUpdateKey, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.Nil(t, err)
 
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -150,7 +152,7 @@ func TestUpdateDocument(t *testing.T) {
 		// test consecutive update
 		updateOp, nextUpdateKey, err = getAnchoredUpdateOperation(nextUpdateKey, uniqueSuffix, 2)
 		require.Nil(t, err)
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -168,7 +170,7 @@ func TestUpdateDocument(t *testing.T) {
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 200)
 		require.Nil(t, err)
 
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -186,7 +188,7 @@ func TestUpdateDocument(t *testing.T) {
 		updateOp, nextUpdateKey, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 50)
 		require.Nil(t, err)
 
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -200,7 +202,7 @@ func TestUpdateDocument(t *testing.T) {
 		// protocol value for hashing algorithm changed at block 100
 		updateOp, nextUpdateKey, err = getAnchoredUpdateOperation(nextUpdateKey, uniqueSuffix, 500)
 		require.Nil(t, err)
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -212,7 +214,7 @@ func TestUpdateDocument(t *testing.T) {
 		// test consecutive update within new protocol value
 		updateOp, nextUpdateKey, err = getAnchoredUpdateOperation(nextUpdateKey, uniqueSuffix, 700)
 		require.Nil(t, err)
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -233,7 +235,7 @@ func TestUpdateDocument(t *testing.T) {
 
 		delta1 := updateOp.Delta
 
-		err = store.Put(getAnchoredOperation(updateOp, 1))
+		err = store.Put([]*operation.AnchoredOperation{getAnchoredOperation(updateOp, 1)})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -248,7 +250,7 @@ func TestUpdateDocument(t *testing.T) {
 		updateOp, nextUpdateKey, err = getUpdateOperation(nextUpdateKey, uniqueSuffix, 2)
 		require.Nil(t, err)
 
-		err = store.Put(getAnchoredOperation(updateOp, 2))
+		err = store.Put([]*operation.AnchoredOperation{getAnchoredOperation(updateOp, 2)})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -265,7 +267,7 @@ func TestUpdateDocument(t *testing.T) {
 		delta3 := updateOp.Delta
 		delta3.UpdateCommitment = delta1.UpdateCommitment
 
-		err = store.Put(getAnchoredOperation(updateOp, 1))
+		err = store.Put([]*operation.AnchoredOperation{getAnchoredOperation(updateOp, 1)})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -287,7 +289,7 @@ func TestUpdateDocument(t *testing.T) {
 		delta1 := updateOp.Delta
 		require.NoError(t, err)
 
-		err = store.Put(getAnchoredOperation(updateOp, 1))
+		err = store.Put([]*operation.AnchoredOperation{getAnchoredOperation(updateOp, 1)})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -305,7 +307,7 @@ func TestUpdateDocument(t *testing.T) {
 		delta2 := updateOp.Delta
 		delta2.UpdateCommitment = delta1.UpdateCommitment
 
-		err = store.Put(getAnchoredOperation(updateOp, 1))
+		err = store.Put([]*operation.AnchoredOperation{getAnchoredOperation(updateOp, 1)})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -325,7 +327,8 @@ func TestProcessOperation(t *testing.T) {
 	require.NoError(t, err)
 
 	pc := newMockProtocolClient()
-	parser := operationparser.New(pc.Protocol)
+	parser, err := operationparser.New(pc.Protocol)
+	require.NoError(t, err)
 
 	t.Run("update is first operation error", func(t *testing.T) {
 		store := mocks.NewMockOperationStore(nil)
@@ -333,14 +336,15 @@ func TestProcessOperation(t *testing.T) {
 		const uniqueSuffix = "uniqueSuffix"
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.Nil(t, err)
-		err = store.Put(updateOp)
+		err = store.Put([]*operation.AnchoredOperation{updateOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
 		doc, err := p.Resolve(uniqueSuffix)
 		require.Error(t, err)
 		require.Nil(t, doc)
-		require.Equal(t, "missing create operation", err.Error())
+		require.True(t, errors.Is(err, document.ErrNotFound))
+		require.Contains(t, err.Error(), "missing create operation")
 	})
 
 	t.Run("create is second operation error", func(t *testing.T) {
@@ -350,7 +354,9 @@ func TestProcessOperation(t *testing.T) {
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
 
-		a := operationapplier.New(pc.Protocol, parser, &mockDocComposer{})
+		a, err := operationapplier.New(pc.Protocol, parser, &mockDocComposer{})
+		require.NoError(t, err)
+
 		doc, err := a.Apply(createOp, &protocol.ResolutionModel{
 			Doc: make(document.Document),
 		})
@@ -363,11 +369,11 @@ func TestProcessOperation(t *testing.T) {
 		store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
 		recoverOp, _, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 2)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
-		doc, err := p.applyOperation(recoverOp, &protocol.ResolutionModel{})
+		doc, err := p.applyOperation(recoverOp, &protocol.ResolutionModel{}, make(parsedOperations))
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "recover can only be applied to an existing document")
 		require.Nil(t, doc)
@@ -376,10 +382,15 @@ func TestProcessOperation(t *testing.T) {
 	t.Run("invalid operation type error", func(t *testing.T) {
 		store, _ := getDefaultStore(recoveryKey, updateKey)
 
+		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+		require.NoError(t, err)
+
+		createOp.Type = "invalid"
+
 		p := New("test", store, pc)
-		doc, err := p.applyOperation(&operation.AnchoredOperation{Type: "invalid"}, &protocol.ResolutionModel{Doc: make(document.Document)})
+		doc, err := p.applyOperation(createOp, &protocol.ResolutionModel{Doc: make(document.Document)}, make(parsedOperations))
 		require.Error(t, err)
-		require.Equal(t, "operation type not supported for process operation", err.Error())
+		require.Contains(t, err.Error(), "operation type [create] doesn't match anchored operation type [invalid]")
 		require.Nil(t, doc)
 	})
 }
@@ -399,13 +410,13 @@ func TestDeactivate(t *testing.T) {
 		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, uniqueSuffix)
 		require.NoError(t, err)
 
-		err = store.Put(deactivateOp)
+		err = store.Put([]*operation.AnchoredOperation{deactivateOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
 		doc, err := p.Resolve(uniqueSuffix)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "document was deactivated")
+		require.True(t, errors.Is(err, document.ErrDeactivated))
 		require.Nil(t, doc)
 	})
 }
@@ -424,7 +435,7 @@ func TestRecover(t *testing.T) {
 
 		recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -439,7 +450,7 @@ func TestRecover(t *testing.T) {
 		// apply recover again - consecutive recoveries are valid
 		recoverOp, _, err = getAnchoredRecoverOperation(nextRecoveryKey, updateKey, uniqueSuffix, 2)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -457,7 +468,7 @@ func TestRecover(t *testing.T) {
 		// hashing algorithm changed at block 100
 		recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 200)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -472,7 +483,7 @@ func TestRecover(t *testing.T) {
 		// apply recover again - consecutive recoveries within new protocol version
 		recoverOp, _, err = getAnchoredRecoverOperation(nextRecoveryKey, updateKey, uniqueSuffix, 300)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -490,7 +501,7 @@ func TestRecover(t *testing.T) {
 
 		recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 50)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		p := New("test", store, pc)
@@ -505,7 +516,7 @@ func TestRecover(t *testing.T) {
 		// apply recover again - there was a protocol change at 100 (new hashing algorithm)
 		recoverOp, _, err = getAnchoredRecoverOperation(nextRecoveryKey, updateKey, uniqueSuffix, 200)
 		require.NoError(t, err)
-		err = store.Put(recoverOp)
+		err = store.Put([]*operation.AnchoredOperation{recoverOp})
 		require.Nil(t, err)
 
 		result, err = p.Resolve(uniqueSuffix)
@@ -519,6 +530,141 @@ func TestRecover(t *testing.T) {
 	})
 }
 
+func TestResolve_HistorySpansProtocolVersions(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	// the hash algorithm changes from sha256 to sha512 at block 100
+	pc := newMockProtocolClient()
+	require.NotEqual(t, getProtocol(0).HashAlgorithm, getProtocol(100).HashAlgorithm)
+
+	store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+	// update commitment from create (sha256) revealed in new version
+	updateOp, nextUpdateKey, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 150)
+	require.NoError(t, err)
+
+	// recovery commitment from create (sha256) revealed in new version
+	recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, nextUpdateKey, uniqueSuffix, 200)
+	require.NoError(t, err)
+
+	// update commitment from recover (sha512) revealed in new version
+	secondUpdateOp, _, err := getAnchoredUpdateOperation(nextUpdateKey, uniqueSuffix, 250)
+	require.NoError(t, err)
+
+	// recovery commitment from recover (sha512) revealed in new version
+	secondRecoverOp, _, err := getAnchoredRecoverOperation(nextRecoveryKey, updateKey, uniqueSuffix, 300)
+	require.NoError(t, err)
+
+	err = store.Put([]*operation.AnchoredOperation{updateOp, recoverOp, secondUpdateOp})
+	require.NoError(t, err)
+
+	p := New("test", store, pc)
+
+	result, err := p.Resolve(uniqueSuffix)
+	require.NoError(t, err)
+
+	didDoc := document.DidDocumentFromJSONLDObject(result.Document)
+	require.Equal(t, "special250", didDoc["test"])
+	require.Equal(t, "recovered200", didDoc.PublicKeys()[0].ID())
+
+	err = store.Put([]*operation.AnchoredOperation{secondRecoverOp})
+	require.NoError(t, err)
+
+	result, err = p.Resolve(uniqueSuffix)
+	require.NoError(t, err)
+
+	didDoc = document.DidDocumentFromJSONLDObject(result.Document)
+	require.Equal(t, "recovered300", didDoc.PublicKeys()[0].ID())
+	require.Nil(t, didDoc["test"])
+}
+
+func TestCreateOperationHashMap_JWKThumbprints(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+	p := New("test", store, newMockProtocolClient())
+
+	updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
+	require.NoError(t, err)
+
+	reveal, err := pubkey.GetPublicKeyJWK(&updateKey.PublicKey)
+	require.NoError(t, err)
+
+	jwkCommitment, err := commitment.Calculate(reveal, sha2_256, crypto.SHA256)
+	require.NoError(t, err)
+
+	thumbprintCommitment, err := commitment.CalculateFromThumbprint(reveal, sha2_256, crypto.SHA512)
+	require.NoError(t, err)
+
+	// the operation is found by the commitment of the protocol of the operation (JWK, sha256) and by the commitment
+	// of the protocol of the previous operation (JWK thumbprint, sha512)
+	opMap := p.createOperationHashMap([]*operation.AnchoredOperation{updateOp}, &commitmentParams{
+		MultihashCode:  sha2_256,
+		HashCode:       uint(crypto.SHA512),
+		JWKThumbprints: true,
+	}, make(parsedOperations))
+	require.Len(t, opMap, 2)
+	require.Equal(t, []*operation.AnchoredOperation{updateOp}, opMap[jwkCommitment])
+	require.Equal(t, []*operation.AnchoredOperation{updateOp}, opMap[thumbprintCommitment])
+}
+
+func TestResolve_ParseOperationsOnce(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pc := newMockProtocolClient()
+
+	var parsers []*mocks.OperationParser
+
+	for _, v := range pc.Versions {
+		parser := &mocks.OperationParser{}
+		parser.ParseAnchoredOperationStub = v.OperationParser().ParseAnchoredOperation
+
+		v.OperationParserReturns(parser)
+
+		parsers = append(parsers, parser)
+	}
+
+	store, uniqueSuffix := getDefaultStore(recoveryKey, updateKey)
+
+	const numUpdates = 3
+
+	for i := uint64(1); i <= numUpdates; i++ {
+		var updateOp *operation.AnchoredOperation
+
+		updateOp, updateKey, err = getAnchoredUpdateOperation(updateKey, uniqueSuffix, i)
+		require.NoError(t, err)
+		require.NoError(t, store.Put([]*operation.AnchoredOperation{updateOp}))
+	}
+
+	result, err := New("test", store, pc).Resolve(uniqueSuffix)
+	require.NoError(t, err)
+	require.Equal(t, "special3", result.Document["test"])
+
+	parseCount := 0
+
+	for _, parser := range parsers {
+		parseCount += parser.ParseAnchoredOperationCallCount()
+
+		require.Zero(t, parser.GetRevealValueCallCount())
+		require.Zero(t, parser.GetCommitmentCallCount())
+	}
+
+	// the create operation and each of the update operations are parsed exactly once
+	require.Equal(t, numUpdates+1, parseCount)
+}
+
 func TestGetOperationCommitment(t *testing.T) {
 	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -535,7 +681,7 @@ func TestGetOperationCommitment(t *testing.T) {
 		recoverOp, _, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		reveal, p, err := p.getRevealValue(recoverOp)
+		reveal, p, err := p.getRevealValue(recoverOp, make(parsedOperations))
 		require.NoError(t, err)
 		require.NotNil(t, reveal)
 		require.NotEmpty(t, p)
@@ -552,7 +698,7 @@ func TestGetOperationCommitment(t *testing.T) {
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		reveal, p, err := p.getRevealValue(updateOp)
+		reveal, p, err := p.getRevealValue(updateOp, make(parsedOperations))
 		require.NoError(t, err)
 		require.NotNil(t, reveal)
 
@@ -568,7 +714,7 @@ func TestGetOperationCommitment(t *testing.T) {
 		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, uniqueSuffix)
 		require.NoError(t, err)
 
-		reveal, p, err := p.getRevealValue(deactivateOp)
+		reveal, p, err := p.getRevealValue(deactivateOp, make(parsedOperations))
 		require.NoError(t, err)
 		require.NotNil(t, reveal)
 
@@ -588,7 +734,7 @@ func TestGetOperationCommitment(t *testing.T) {
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		value, _, err := New("test", store, pcWithoutProtocols).getRevealValue(updateOp)
+		value, _, err := New("test", store, pcWithoutProtocols).getRevealValue(updateOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Contains(t, err.Error(), "protocol parameters are not defined for blockchain time")
@@ -598,7 +744,7 @@ func TestGetOperationCommitment(t *testing.T) {
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
 
-		value, p, err := p.getRevealValue(createOp)
+		value, p, err := p.getRevealValue(createOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Equal(t, p, protocol.Protocol{})
@@ -613,7 +759,7 @@ func TestGetOperationCommitment(t *testing.T) {
 
 		anchoredOp := getAnchoredOperation(recoverOp, 1)
 
-		value, p, err := p.getRevealValue(anchoredOp)
+		value, p, err := p.getRevealValue(anchoredOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Equal(t, p, protocol.Protocol{})
@@ -633,7 +779,7 @@ func TestGetOperationCommitment(t *testing.T) {
 
 		anchoredOp := getAnchoredOperation(recoverOp, 1)
 
-		value, pv, err := p.getRevealValue(anchoredOp)
+		value, pv, err := p.getRevealValue(anchoredOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Equal(t, pv, protocol.Protocol{})
@@ -647,7 +793,7 @@ func TestGetOperationCommitment(t *testing.T) {
 
 		anchoredOp = getAnchoredOperation(deactivateOp, 1)
 
-		value, pv, err = p.getRevealValue(anchoredOp)
+		value, pv, err = p.getRevealValue(anchoredOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Equal(t, pv, protocol.Protocol{})
@@ -665,7 +811,7 @@ func TestGetOperationCommitment(t *testing.T) {
 
 		anchoredOp = getAnchoredOperation(updateOp, 1)
 
-		value, pv, err = p.getRevealValue(anchoredOp)
+		value, pv, err = p.getRevealValue(anchoredOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Equal(t, pv, protocol.Protocol{})
@@ -689,7 +835,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 		recoverOp, nextRecoveryKey, err := getAnchoredRecoverOperation(recoveryKey, updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		value, err := p.getCommitment(recoverOp)
+		value, err := p.getCommitment(recoverOp, make(parsedOperations))
 		require.NoError(t, err)
 		require.NotEmpty(t, value)
 
@@ -702,7 +848,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 		updateOp, nextUpdateKey, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		value, err := p.getCommitment(updateOp)
+		value, err := p.getCommitment(updateOp, make(parsedOperations))
 		require.NoError(t, err)
 		require.NotEmpty(t, value)
 
@@ -715,7 +861,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, uniqueSuffix)
 		require.NoError(t, err)
 
-		value, err := p.getCommitment(deactivateOp)
+		value, err := p.getCommitment(deactivateOp, make(parsedOperations))
 		require.NoError(t, err)
 		require.Empty(t, value)
 	})
@@ -728,7 +874,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		value, err := New("test", store, pcWithoutProtocols).getCommitment(updateOp)
+		value, err := New("test", store, pcWithoutProtocols).getCommitment(updateOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Contains(t, err.Error(), "protocol parameters are not defined for blockchain time")
@@ -738,7 +884,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
 
-		value, err := p.getCommitment(createOp)
+		value, err := p.getCommitment(createOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Contains(t, err.Error(), "operation type 'create' not supported for getting next operation commitment")
@@ -752,7 +898,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 
 		anchoredOp := getAnchoredOperation(recoverOp, 1)
 
-		value, err := p.getCommitment(anchoredOp)
+		value, err := p.getCommitment(anchoredOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Contains(t, err.Error(), "missing signed data")
@@ -764,10 +910,10 @@ func TestGetNextOperationCommitment(t *testing.T) {
 
 		updateOp.Delta = &model.DeltaModel{}
 
-		value, err := p.getCommitment(getAnchoredOperation(updateOp, 1))
+		value, err := p.getCommitment(getAnchoredOperation(updateOp, 1), make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
-		require.Contains(t, err.Error(), "get commitment - parse operation error: missing patches")
+		require.Contains(t, err.Error(), "get next operation commitment: parse anchored operation: missing patches")
 	})
 
 	t.Run("error - operation type not supported", func(t *testing.T) {
@@ -778,7 +924,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 		bytes, err := canonicalizer.MarshalCanonical(request)
 		require.NoError(t, err)
 
-		value, err := p.getCommitment(&operation.AnchoredOperation{OperationBuffer: bytes})
+		value, err := p.getCommitment(&operation.AnchoredOperation{OperationBuffer: bytes}, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Contains(t, err.Error(), "operation type [other] not supported")
@@ -797,7 +943,7 @@ func TestGetNextOperationCommitment(t *testing.T) {
 
 		anchoredOp := getAnchoredOperation(recoverOp, 1)
 
-		value, err := p.getCommitment(anchoredOp)
+		value, err := p.getCommitment(anchoredOp, make(parsedOperations))
 		require.Error(t, err)
 		require.Empty(t, value)
 		require.Contains(t, err.Error(), "failed to unmarshal signed data model for recover")
@@ -859,6 +1005,10 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 		return nil, nil, err
 	}
 
+	return getUpdateOperationWithPatch(s, privateKey, uniqueSuffix, blockNumber, jsonPatch)
+}
+
+func getUpdateOperationWithPatch(s client.Signer, privateKey *ecdsa.PrivateKey, uniqueSuffix string, blockNumber uint64, p patch.Patch) (*model.Operation, *ecdsa.PrivateKey, error) {
 	nextUpdateKey, updateCommitment, err := generateKeyAndCommitment(getProtocol(blockNumber))
 	if err != nil {
 		return nil, nil, err
@@ -866,7 +1016,7 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 
 	delta := &model.DeltaModel{
 		UpdateCommitment: updateCommitment,
-		Patches:          []patch.Patch{jsonPatch},
+		Patches:          []patch.Patch{p},
 	}
 
 	deltaHash, err := docutil.CalculateModelMultihash(delta, getProtocol(blockNumber).MultihashAlgorithm)
@@ -1069,7 +1219,7 @@ func getDefaultStore(recoveryKey, updateKey *ecdsa.PrivateKey) (*mocks.MockOpera
 	}
 
 	// store default create operation
-	err = store.Put(createOp)
+	err = store.Put([]*operation.AnchoredOperation{createOp})
 	if err != nil {
 		panic(err)
 	}
@@ -1286,9 +1436,17 @@ func newMockProtocolClient() *mocks.MockProtocolClient {
 	pc.CurrentVersion = latestVersion
 
 	for _, v := range pc.Versions {
-		parser := operationparser.New(v.Protocol())
+		parser, err := operationparser.New(v.Protocol())
+		if err != nil {
+			panic(err)
+		}
+
 		dc := doccomposer.New()
-		oa := operationapplier.New(v.Protocol(), parser, dc)
+		oa, err := operationapplier.New(v.Protocol(), parser, dc)
+		if err != nil {
+			panic(err)
+		}
+
 		v.OperationParserReturns(parser)
 		v.OperationApplierReturns(oa)
 		v.DocumentComposerReturns(dc)
@@ -0,0 +1,64 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// Client implements protocol.Client for a fixed set of protocol versions.
+type Client struct {
+	// versions sorted by genesis time
+	versions []protocol.Version
+}
+
+// New returns a new protocol client for the given protocol versions. The versions may be provided
+// in any order but each version must have a unique genesis time.
+func New(versions []protocol.Version) (*Client, error) {
+	if len(versions) == 0 {
+		return nil, errors.New("at least one protocol version is required")
+	}
+
+	sorted := make([]protocol.Version, len(versions))
+	copy(sorted, versions)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Protocol().GenesisTime < sorted[j].Protocol().GenesisTime
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Protocol().GenesisTime == sorted[i-1].Protocol().GenesisTime {
+			return nil, fmt.Errorf("duplicate protocol genesis time: %d", sorted[i].Protocol().GenesisTime)
+		}
+	}
+
+	return &Client{versions: sorted}, nil
+}
+
+// Current returns the version of the protocol with the latest genesis time.
+func (c *Client) Current() (protocol.Version, error) {
+	return c.versions[len(c.versions)-1], nil
+}
+
+// Get returns the version of the protocol that applies at the given transaction time, i.e. the
+// version with the latest genesis time that is less than or equal to the transaction time.
+func (c *Client) Get(transactionTime uint64) (protocol.Version, error) {
+	// index of the first version that doesn't apply yet
+	i := sort.Search(len(c.versions), func(i int) bool {
+		return c.versions[i].Protocol().GenesisTime > transactionTime
+	})
+
+	if i == 0 {
+		return nil, fmt.Errorf("protocol parameters are not defined for transaction time: %d", transactionTime)
+	}
+
+	return c.versions[i-1], nil
+}
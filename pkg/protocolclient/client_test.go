@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, err := New([]protocol.Version{newVersion(0)})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("error - no versions", func(t *testing.T) {
+		client, err := New(nil)
+		require.EqualError(t, err, "at least one protocol version is required")
+		require.Nil(t, client)
+	})
+
+	t.Run("error - duplicate genesis time", func(t *testing.T) {
+		client, err := New([]protocol.Version{newVersion(0), newVersion(100), newVersion(100)})
+		require.EqualError(t, err, "duplicate protocol genesis time: 100")
+		require.Nil(t, client)
+	})
+}
+
+func TestClient_Current(t *testing.T) {
+	// versions are provided out of order
+	client, err := New([]protocol.Version{newVersion(100), newVersion(500), newVersion(0)})
+	require.NoError(t, err)
+
+	v, err := client.Current()
+	require.NoError(t, err)
+	require.Equal(t, uint64(500), v.Protocol().GenesisTime)
+}
+
+func TestClient_Get(t *testing.T) {
+	client, err := New([]protocol.Version{newVersion(500), newVersion(10), newVersion(100)})
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		tests := []struct {
+			transactionTime uint64
+			genesisTime     uint64
+		}{
+			{transactionTime: 10, genesisTime: 10},
+			{transactionTime: 99, genesisTime: 10},
+			{transactionTime: 100, genesisTime: 100},
+			{transactionTime: 101, genesisTime: 100},
+			{transactionTime: 499, genesisTime: 100},
+			{transactionTime: 500, genesisTime: 500},
+			{transactionTime: 1000000, genesisTime: 500},
+		}
+
+		for _, test := range tests {
+			v, err := client.Get(test.transactionTime)
+			require.NoError(t, err)
+			require.Equalf(t, test.genesisTime, v.Protocol().GenesisTime, "transaction time %d", test.transactionTime)
+		}
+	})
+
+	t.Run("error - transaction time before first genesis time", func(t *testing.T) {
+		v, err := client.Get(9)
+		require.EqualError(t, err, "protocol parameters are not defined for transaction time: 9")
+		require.Nil(t, v)
+	})
+}
+
+func newVersion(genesisTime uint64) protocol.Version {
+	return mocks.GetProtocolVersion(protocol.Protocol{GenesisTime: genesisTime})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// Config contains the protocol definitions for one or more namespaces.
+type Config struct {
+	Namespaces []*NamespaceConfig `json:"namespaces"`
+}
+
+// NamespaceConfig contains the protocol definitions for a namespace. Each protocol definition
+// applies from its genesis time until the genesis time of the next protocol definition.
+type NamespaceConfig struct {
+	Namespace string            `json:"namespace"`
+	Protocols []*ProtocolConfig `json:"protocols"`
+}
+
+// ProtocolConfig contains the protocol parameters along with the name of the protocol version
+// (as registered in the version registry) which implements them.
+type ProtocolConfig struct {
+	Version string `json:"version"`
+
+	protocol.Protocol
+}
+
+// LoadConfig reads the protocol configuration (JSON or YAML) from the given file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("read protocol config: %w", err)
+	}
+
+	return ParseConfig(data)
+}
+
+// ParseConfig parses the given protocol configuration (JSON or YAML). The field names are the same
+// for both formats (e.g. 'genesisTime') and unknown fields are rejected.
+func ParseConfig(data []byte) (*Config, error) {
+	jsonData, err := yamlToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse protocol config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+
+	cfg := &Config{}
+
+	err = decoder.Decode(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("parse protocol config: %w", err)
+	}
+
+	err = cfg.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid protocol config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if len(c.Namespaces) == 0 {
+		return errors.New("no namespaces defined")
+	}
+
+	namespaces := make(map[string]bool)
+
+	for _, ns := range c.Namespaces {
+		if ns.Namespace == "" {
+			return errors.New("missing namespace")
+		}
+
+		if namespaces[ns.Namespace] {
+			return fmt.Errorf("duplicate namespace [%s]", ns.Namespace)
+		}
+
+		namespaces[ns.Namespace] = true
+
+		if len(ns.Protocols) == 0 {
+			return fmt.Errorf("no protocols defined for namespace [%s]", ns.Namespace)
+		}
+
+		genesisTimes := make(map[uint64]bool)
+
+		for _, p := range ns.Protocols {
+			if p.Version == "" {
+				return fmt.Errorf("missing version for protocol with genesis time [%d] for namespace [%s]",
+					p.GenesisTime, ns.Namespace)
+			}
+
+			if err := p.Protocol.Validate(); err != nil {
+				return fmt.Errorf("protocol with genesis time [%d] for namespace [%s]: %w",
+					p.GenesisTime, ns.Namespace, err)
+			}
+
+			if genesisTimes[p.GenesisTime] {
+				return fmt.Errorf("duplicate protocol genesis time [%d] for namespace [%s]", p.GenesisTime, ns.Namespace)
+			}
+
+			genesisTimes[p.GenesisTime] = true
+		}
+	}
+
+	return nil
+}
+
+// yamlToJSON converts the given YAML (which may also be JSON) to JSON so that the JSON field names
+// of the protocol definitions can be used for both formats.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var value interface{}
+
+	err := yaml.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err = convertYAMLValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// convertYAMLValue converts the maps unmarshalled by the YAML decoder (which have interface{} keys)
+// to maps with string keys so that they can be marshalled to JSON.
+func convertYAMLValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+
+		for key, val := range v {
+			strKey, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported key type [%T]", key)
+			}
+
+			converted, err := convertYAMLValue(val)
+			if err != nil {
+				return nil, err
+			}
+
+			m[strKey] = converted
+		}
+
+		return m, nil
+
+	case []interface{}:
+		s := make([]interface{}, len(v))
+
+		for i, val := range v {
+			converted, err := convertYAMLValue(val)
+			if err != nil {
+				return nil, err
+			}
+
+			s[i] = converted
+		}
+
+		return s, nil
+
+	default:
+		return v, nil
+	}
+}
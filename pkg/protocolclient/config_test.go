@@ -0,0 +1,278 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+const jsonConfig = `{
+  "namespaces": [
+    {
+      "namespace": "did:sidetree",
+      "protocols": [
+        {
+          "version": "0.1",
+          "genesisTime": 0,
+          "multihashAlgorithm": 18,
+          "hashAlgorithm": 5,
+          "maxOperationCount": 2,
+          "maxOperationSize": 2000,
+          "compressionAlgorithm": "GZIP",
+          "maxAnchorFileSize": 20000,
+          "maxMapFileSize": 20000,
+          "maxChunkFileSize": 20000,
+          "patches": ["add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"],
+          "signatureAlgorithms": ["EdDSA", "ES256"],
+          "keyAlgorithms": ["Ed25519", "P-256"]
+        },
+        {
+          "version": "0.1",
+          "genesisTime": 100,
+          "multihashAlgorithm": 18,
+          "hashAlgorithm": 5,
+          "maxOperationCount": 10,
+          "maxOperationSize": 2000,
+          "compressionAlgorithm": "GZIP",
+          "maxAnchorFileSize": 20000,
+          "maxMapFileSize": 20000,
+          "maxChunkFileSize": 20000,
+          "patches": ["add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"],
+          "signatureAlgorithms": ["EdDSA", "ES256"],
+          "keyAlgorithms": ["Ed25519", "P-256"]
+        }
+      ]
+    },
+    {
+      "namespace": "did:other",
+      "protocols": [
+        {
+          "version": "0.1",
+          "genesisTime": 0,
+          "multihashAlgorithm": 18,
+          "hashAlgorithm": 5,
+          "maxOperationCount": 5,
+          "maxOperationSize": 2000,
+          "compressionAlgorithm": "GZIP",
+          "maxAnchorFileSize": 20000,
+          "maxMapFileSize": 20000,
+          "maxChunkFileSize": 20000,
+          "patches": ["add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"],
+          "signatureAlgorithms": ["EdDSA", "ES256"],
+          "keyAlgorithms": ["Ed25519", "P-256"]
+        }
+      ]
+    }
+  ]
+}`
+
+const yamlConfig = `
+namespaces:
+  - namespace: did:sidetree
+    protocols:
+      - version: "0.1"
+        genesisTime: 0
+        multihashAlgorithm: 18
+        hashAlgorithm: 5
+        maxOperationCount: 2
+        maxOperationSize: 2000
+        compressionAlgorithm: GZIP
+        maxAnchorFileSize: 20000
+        maxMapFileSize: 20000
+        maxChunkFileSize: 20000
+        patches: [add-public-keys, remove-public-keys, add-services, remove-services, ietf-json-patch]
+        signatureAlgorithms: [EdDSA, ES256]
+        keyAlgorithms: [Ed25519, P-256]
+      - version: "0.1"
+        genesisTime: 100
+        multihashAlgorithm: 18
+        hashAlgorithm: 5
+        maxOperationCount: 10
+        maxOperationSize: 2000
+        compressionAlgorithm: GZIP
+        maxAnchorFileSize: 20000
+        maxMapFileSize: 20000
+        maxChunkFileSize: 20000
+        patches: [add-public-keys, remove-public-keys, add-services, remove-services, ietf-json-patch]
+        signatureAlgorithms: [EdDSA, ES256]
+        keyAlgorithms: [Ed25519, P-256]
+  - namespace: did:other
+    protocols:
+      - version: "0.1"
+        genesisTime: 0
+        multihashAlgorithm: 18
+        hashAlgorithm: 5
+        maxOperationCount: 5
+        maxOperationSize: 2000
+        compressionAlgorithm: GZIP
+        maxAnchorFileSize: 20000
+        maxMapFileSize: 20000
+        maxChunkFileSize: 20000
+        patches: [add-public-keys, remove-public-keys, add-services, remove-services, ietf-json-patch]
+        signatureAlgorithms: [EdDSA, ES256]
+        keyAlgorithms: [Ed25519, P-256]
+`
+
+func TestParseConfig(t *testing.T) {
+	t.Run("success - JSON", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(jsonConfig))
+		require.NoError(t, err)
+		require.Len(t, cfg.Namespaces, 2)
+
+		ns := cfg.Namespaces[0]
+		require.Equal(t, "did:sidetree", ns.Namespace)
+		require.Len(t, ns.Protocols, 2)
+		require.Equal(t, "0.1", ns.Protocols[1].Version)
+		require.Equal(t, uint64(100), ns.Protocols[1].GenesisTime)
+		require.Equal(t, uint(10), ns.Protocols[1].MaxOperationCount)
+		require.Equal(t, uint(18), ns.Protocols[1].MultihashAlgorithm)
+		require.Equal(t, []string{"Ed25519", "P-256"}, ns.Protocols[1].KeyAlgorithms)
+	})
+
+	t.Run("success - YAML", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(yamlConfig))
+		require.NoError(t, err)
+
+		expected, err := ParseConfig([]byte(jsonConfig))
+		require.NoError(t, err)
+
+		require.Equal(t, expected, cfg)
+	})
+
+	t.Run("error - invalid YAML", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte("namespaces: ["))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse protocol config")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - unknown field", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"namespaces":[{"namespace":"did:sidetree","protocols":[{"genesis":0}]}]}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `unknown field "genesis"`)
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - invalid field type", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"namespaces":[{"namespace":"did:sidetree","protocols":[{"genesisTime":"zero"}]}]}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse protocol config")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - non-string key", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte("namespaces:\n  - 1: did:sidetree\n"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported key type [int]")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - no namespaces", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{}`))
+		require.EqualError(t, err, "invalid protocol config: no namespaces defined")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - missing namespace", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"namespaces":[{"protocols":[{"version":"0.1","genesisTime":0}]}]}`))
+		require.EqualError(t, err, "invalid protocol config: missing namespace")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - duplicate namespace", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(fmt.Sprintf(`{"namespaces":[
+			{"namespace":"did:sidetree","protocols":[%s]},
+			{"namespace":"did:sidetree","protocols":[%s]}
+		]}`, protocolJSON(0), protocolJSON(0))))
+		require.EqualError(t, err, "invalid protocol config: duplicate namespace [did:sidetree]")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - no protocols", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"namespaces":[{"namespace":"did:sidetree"}]}`))
+		require.EqualError(t, err, "invalid protocol config: no protocols defined for namespace [did:sidetree]")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - missing version", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"namespaces":[{"namespace":"did:sidetree","protocols":[{"genesisTime":10}]}]}`))
+		require.EqualError(t, err,
+			"invalid protocol config: missing version for protocol with genesis time [10] for namespace [did:sidetree]")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - duplicate genesis time", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(fmt.Sprintf(`{"namespaces":[{"namespace":"did:sidetree","protocols":[%s,%s]}]}`,
+			protocolJSON(10), protocolJSON(10))))
+		require.EqualError(t, err,
+			"invalid protocol config: duplicate protocol genesis time [10] for namespace [did:sidetree]")
+		require.Nil(t, cfg)
+	})
+
+	t.Run("error - invalid protocol", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"namespaces":[{"namespace":"did:sidetree","protocols":[{"version":"0.1","genesisTime":10}]}]}`))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+		require.EqualError(t, err,
+			"invalid protocol config: protocol with genesis time [10] for namespace [did:sidetree]: "+
+				"invalid protocol: maxOperationCount must be greater than 0")
+		require.Nil(t, cfg)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "protocolclient")
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		path := filepath.Join(dir, "protocol.yaml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(yamlConfig), 0600))
+
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+		require.Len(t, cfg.Namespaces, 2)
+	})
+
+	t.Run("error - file not found", func(t *testing.T) {
+		cfg, err := LoadConfig(filepath.Join(dir, "invalid.yaml"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read protocol config")
+		require.Nil(t, cfg)
+	})
+}
+
+// protocolJSON returns the JSON for a valid protocol config with the given genesis time.
+func protocolJSON(genesisTime uint64) string {
+	return fmt.Sprintf(`{
+		"version": "0.1",
+		"genesisTime": %d,
+		"multihashAlgorithm": 18,
+		"hashAlgorithm": 5,
+		"maxOperationCount": 2,
+		"maxOperationSize": 2000,
+		"compressionAlgorithm": "GZIP",
+		"maxAnchorFileSize": 20000,
+		"maxMapFileSize": 20000,
+		"maxChunkFileSize": 20000,
+		"patches": ["add-public-keys"],
+		"signatureAlgorithms": ["ES256"],
+		"keyAlgorithms": ["P-256"]
+	}`, genesisTime)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+)
+
+// VersionFactory creates a protocol version by version name (see protocol.Registry).
+type VersionFactory interface {
+	CreateVersion(version string, p protocol.Protocol, providers *protocol.Providers) (protocol.Version, error)
+}
+
+// ClientProvider implements protocol.ClientProvider. It provides a protocol client for each of the
+// namespaces in the protocol configuration.
+type ClientProvider struct {
+	clients map[string]*Client
+}
+
+// NewClientProvider returns a new client provider. The protocol versions defined in the given configuration
+// are created by the given version factory (typically a protocol.Registry) using the given shared providers.
+func NewClientProvider(cfg *Config, factory VersionFactory, providers *protocol.Providers) (*ClientProvider, error) {
+	clients := make(map[string]*Client)
+
+	for _, ns := range cfg.Namespaces {
+		var versions []protocol.Version
+
+		for _, p := range ns.Protocols {
+			v, err := factory.CreateVersion(p.Version, p.Protocol, providers)
+			if err != nil {
+				return nil, fmt.Errorf("create protocol version with genesis time [%d] for namespace [%s]: %w",
+					p.GenesisTime, ns.Namespace, err)
+			}
+
+			versions = append(versions, v)
+		}
+
+		client, err := New(versions)
+		if err != nil {
+			return nil, fmt.Errorf("create protocol client for namespace [%s]: %w", ns.Namespace, err)
+		}
+
+		clients[ns.Namespace] = client
+	}
+
+	return &ClientProvider{clients: clients}, nil
+}
+
+// ForNamespace returns the protocol client for the given namespace.
+func (p *ClientProvider) ForNamespace(namespace string) (protocol.Client, error) {
+	client, ok := p.clients[namespace]
+	if !ok {
+		return nil, fmt.Errorf("protocol client not found for namespace [%s]", namespace)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/compression"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/protocolversion"
+)
+
+func TestNewClientProvider(t *testing.T) {
+	cfg, err := ParseConfig([]byte(jsonConfig))
+	require.NoError(t, err)
+
+	registry := protocol.NewRegistry()
+	require.NoError(t, protocolversion.Register(registry))
+
+	providers := newProviders()
+
+	t.Run("success", func(t *testing.T) {
+		provider, err := NewClientProvider(cfg, registry, providers)
+		require.NoError(t, err)
+
+		pc, err := provider.ForNamespace("did:sidetree")
+		require.NoError(t, err)
+
+		v, err := pc.Current()
+		require.NoError(t, err)
+		require.Equal(t, protocolversion.VersionID, v.Version())
+		require.Equal(t, uint64(100), v.Protocol().GenesisTime)
+		require.Equal(t, uint(10), v.Protocol().MaxOperationCount)
+		require.NotNil(t, v.OperationParser())
+		require.NotNil(t, v.OperationApplier())
+
+		v, err = pc.Get(99)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), v.Protocol().GenesisTime)
+		require.Equal(t, uint(2), v.Protocol().MaxOperationCount)
+
+		pc, err = provider.ForNamespace("did:other")
+		require.NoError(t, err)
+
+		v, err = pc.Get(1000)
+		require.NoError(t, err)
+		require.Equal(t, uint(5), v.Protocol().MaxOperationCount)
+	})
+
+	t.Run("error - namespace not found", func(t *testing.T) {
+		provider, err := NewClientProvider(cfg, registry, providers)
+		require.NoError(t, err)
+
+		pc, err := provider.ForNamespace("did:unknown")
+		require.EqualError(t, err, "protocol client not found for namespace [did:unknown]")
+		require.Nil(t, pc)
+	})
+
+	t.Run("error - version not registered", func(t *testing.T) {
+		provider, err := NewClientProvider(cfg, protocol.NewRegistry(), providers)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "version factory not found for version [0.1]")
+		require.Nil(t, provider)
+	})
+
+	t.Run("error - create version", func(t *testing.T) {
+		provider, err := NewClientProvider(cfg, registry, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing providers")
+		require.Nil(t, provider)
+	})
+
+	t.Run("error - duplicate genesis time", func(t *testing.T) {
+		p := mocks.NewMockProtocolClient().Protocol
+		p.GenesisTime = 10
+
+		invalidCfg := &Config{
+			Namespaces: []*NamespaceConfig{{
+				Namespace: "did:sidetree",
+				Protocols: []*ProtocolConfig{
+					{Version: protocolversion.VersionID, Protocol: p},
+					{Version: protocolversion.VersionID, Protocol: p},
+				},
+			}},
+		}
+
+		provider, err := NewClientProvider(invalidCfg, registry, providers)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "duplicate protocol genesis time: 10")
+		require.Nil(t, provider)
+	})
+}
+
+func newProviders() *protocol.Providers {
+	return &protocol.Providers{
+		CasClient:           mocks.NewMockCasClient(nil),
+		CompressionProvider: compression.New(compression.WithDefaultAlgorithms()),
+		OperationStore:      mocks.NewMockOperationStore(nil),
+	}
+}
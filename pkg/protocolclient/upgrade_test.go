@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolclient
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/processor"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/protocolversion"
+)
+
+const (
+	namespace = "did:sidetree"
+
+	toyVersionID = "toy"
+
+	// toyProperty is added to the document by the toy version for each operation that it applies.
+	toyProperty = "toyOperations"
+)
+
+const upgradeConfig = `
+namespaces:
+  - namespace: did:sidetree
+    protocols:
+      - version: "0.1"
+        genesisTime: 0
+        multihashAlgorithm: 18
+        hashAlgorithm: 5
+        maxOperationCount: 10
+        maxOperationSize: 2000
+        compressionAlgorithm: GZIP
+        maxAnchorFileSize: 20000
+        maxMapFileSize: 20000
+        maxChunkFileSize: 20000
+        patches: [add-public-keys, remove-public-keys, add-services, remove-services, ietf-json-patch]
+        signatureAlgorithms: [EdDSA, ES256]
+        keyAlgorithms: [Ed25519, P-256]
+      - version: toy
+        genesisTime: 100
+        multihashAlgorithm: 18
+        hashAlgorithm: 5
+        maxOperationCount: 10
+        maxOperationSize: 2000
+        compressionAlgorithm: GZIP
+        maxAnchorFileSize: 20000
+        maxMapFileSize: 20000
+        maxChunkFileSize: 20000
+        patches: [add-public-keys, remove-public-keys, add-services, remove-services, ietf-json-patch]
+        signatureAlgorithms: [EdDSA, ES256]
+        keyAlgorithms: [Ed25519, P-256]
+`
+
+func TestProtocolUpgrade(t *testing.T) {
+	cfg, err := ParseConfig([]byte(upgradeConfig))
+	require.NoError(t, err)
+
+	registry := protocol.NewRegistry()
+	require.NoError(t, protocolversion.Register(registry))
+	require.NoError(t, registry.Register(toyVersionID, &toyFactory{}))
+
+	providers := newProviders()
+
+	clientProvider, err := NewClientProvider(cfg, registry, providers)
+	require.NoError(t, err)
+
+	pc, err := clientProvider.ForNamespace(namespace)
+	require.NoError(t, err)
+
+	current, err := pc.Current()
+	require.NoError(t, err)
+	require.Equal(t, toyVersionID, current.Version())
+
+	d := newTestDID(t, pc, providers.OperationStore)
+
+	// operations anchored before the toy version's genesis time are applied by version 0.1
+	d.create(t, 10)
+	d.update(t, 50, "service1")
+
+	p := processor.New(namespace, providers.OperationStore, pc)
+
+	result, err := p.Resolve(d.uniqueSuffix)
+	require.NoError(t, err)
+	require.Len(t, result.Document.Services(), 2)
+	require.NotContains(t, result.Document, toyProperty)
+
+	// operations anchored at or after the toy version's genesis time are applied by the toy version
+	d.update(t, 100, "service2")
+	d.update(t, 150, "service3")
+
+	result, err = p.Resolve(d.uniqueSuffix)
+	require.NoError(t, err)
+	require.Len(t, result.Document.Services(), 4)
+	require.Equal(t, float64(2), result.Document[toyProperty])
+}
+
+type testDID struct {
+	pc           protocol.Client
+	store        protocol.OperationStore
+	updateKey    *ecdsa.PrivateKey
+	uniqueSuffix string
+}
+
+func newTestDID(t *testing.T, pc protocol.Client, store protocol.OperationStore) *testDID {
+	t.Helper()
+
+	return &testDID{pc: pc, store: store, updateKey: newKey(t)}
+}
+
+func (d *testDID) create(t *testing.T, transactionTime uint64) {
+	t.Helper()
+
+	v, err := d.pc.Get(transactionTime)
+	require.NoError(t, err)
+
+	recoveryCommitment := getCommitment(t, newKey(t), v.Protocol())
+
+	request, err := client.NewCreateRequest(&client.CreateRequestInfo{
+		Patches:            []patch.Patch{newServicePatch(t, "service0")},
+		RecoveryCommitment: recoveryCommitment,
+		UpdateCommitment:   getCommitment(t, d.updateKey, v.Protocol()),
+		MultihashCode:      v.Protocol().MultihashAlgorithm,
+	})
+	require.NoError(t, err)
+
+	d.uniqueSuffix = d.anchor(t, v, request, transactionTime)
+}
+
+func (d *testDID) update(t *testing.T, transactionTime uint64, serviceID string) {
+	t.Helper()
+
+	v, err := d.pc.Get(transactionTime)
+	require.NoError(t, err)
+
+	updatePubKey, err := pubkey.GetPublicKeyJWK(&d.updateKey.PublicKey)
+	require.NoError(t, err)
+
+	nextUpdateKey := newKey(t)
+
+	request, err := client.NewUpdateRequest(&client.UpdateRequestInfo{
+		DidSuffix:        d.uniqueSuffix,
+		Patches:          []patch.Patch{newServicePatch(t, serviceID)},
+		UpdateCommitment: getCommitment(t, nextUpdateKey, v.Protocol()),
+		UpdateKey:        updatePubKey,
+		MultihashCode:    v.Protocol().MultihashAlgorithm,
+		Signer:           ecsigner.New(d.updateKey, "ES256", "update-key"),
+	})
+	require.NoError(t, err)
+
+	d.anchor(t, v, request, transactionTime)
+	d.updateKey = nextUpdateKey
+}
+
+// anchor stores the operation as if it had been anchored at the given transaction time (using the
+// protocol version that applies at that time) and returns the unique suffix of the operation.
+func (d *testDID) anchor(t *testing.T, v protocol.Version, request []byte, transactionTime uint64) string {
+	t.Helper()
+
+	op, err := v.OperationParser().Parse(namespace, request)
+	require.NoError(t, err)
+
+	err = d.store.Put([]*operation.AnchoredOperation{{
+		Type:                op.Type,
+		UniqueSuffix:        op.UniqueSuffix,
+		OperationBuffer:     request,
+		TransactionTime:     transactionTime,
+		ProtocolGenesisTime: v.Protocol().GenesisTime,
+	}})
+	require.NoError(t, err)
+
+	return op.UniqueSuffix
+}
+
+func newServicePatch(t *testing.T, serviceID string) patch.Patch {
+	t.Helper()
+
+	servicePatch, err := patch.NewAddServiceEndpointsPatch(fmt.Sprintf(
+		`[{"id": "%s", "type": "service", "serviceEndpoint": "https://example.com"}]`, serviceID))
+	require.NoError(t, err)
+
+	return servicePatch
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func getCommitment(t *testing.T, key *ecdsa.PrivateKey, p protocol.Protocol) string {
+	t.Helper()
+
+	pubKey, err := pubkey.GetPublicKeyJWK(&key.PublicKey)
+	require.NoError(t, err)
+
+	c, err := commitment.Calculate(pubKey, p.MultihashAlgorithm, crypto.Hash(p.HashAlgorithm))
+	require.NoError(t, err)
+
+	return c
+}
+
+// toyFactory creates a toy protocol version which applies operations in the same way as version 0.1
+// but records the number of operations that it applied in the document, so that tests can verify
+// which version applied each operation.
+type toyFactory struct{}
+
+func (f *toyFactory) Create(p protocol.Protocol, providers *protocol.Providers) (protocol.Version, error) {
+	v, err := protocolversion.NewFactory().Create(p, providers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &toyVersion{baseVersion: v}, nil
+}
+
+type baseVersion = protocol.Version
+
+type toyVersion struct {
+	baseVersion
+}
+
+func (v *toyVersion) Version() string {
+	return toyVersionID
+}
+
+func (v *toyVersion) OperationApplier() protocol.OperationApplier {
+	return &toyApplier{applier: v.baseVersion.OperationApplier()}
+}
+
+type toyApplier struct {
+	applier protocol.OperationApplier
+}
+
+func (a *toyApplier) Apply(op *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	return a.count(a.applier.Apply(op, rm))
+}
+
+func (a *toyApplier) ApplyParsed(op *protocol.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	return a.count(a.applier.ApplyParsed(op, rm))
+}
+
+func (a *toyApplier) count(result *protocol.ResolutionModel, err error) (*protocol.ResolutionModel, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Doc != nil {
+		// numbers are float64 since the document is copied via JSON
+		count, _ := result.Doc[toyProperty].(float64) //nolint:errcheck
+		result.Doc[toyProperty] = count + 1
+	}
+
+	return result, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+var (
+	// ErrUnauthorized is returned if the request could not be authenticated.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden is returned if the authenticated principal is not permitted to perform the request.
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNoCredentials is returned by an authenticator if the request doesn't contain the credentials
+	// that it handles. It wraps ErrUnauthorized.
+	ErrNoCredentials = fmt.Errorf("%w: no credentials", ErrUnauthorized)
+)
+
+// Principal is the authenticated caller.
+type Principal struct {
+	// ID identifies the caller.
+	ID string
+
+	// Tenant is the tenant that the caller belongs to (may be empty).
+	Tenant string
+}
+
+// Authenticator authenticates HTTP requests.
+type Authenticator interface {
+	// Authenticate returns the principal for the given request. ErrNoCredentials is returned if
+	// the request contains no credentials for this authenticator, and an error wrapping ErrUnauthorized
+	// is returned if the credentials are invalid.
+	Authenticate(req *http.Request) (*Principal, error)
+}
+
+// Challenger is implemented by authenticators which provide a WWW-Authenticate challenge for 401 responses.
+type Challenger interface {
+	Challenge() string
+}
+
+// Operation contains the information about an operation request that is used for authorization.
+type Operation struct {
+	Namespace    string
+	Type         operation.Type
+	UniqueSuffix string
+}
+
+// ParseOperation returns the operation (type and unique suffix) of the given operation request in the given
+// namespace. An error wrapping operation.ErrInvalidOperation is returned if the request can't be parsed.
+func ParseOperation(namespace string, request []byte) (*Operation, error) {
+	op := &struct {
+		Type      operation.Type `json:"type"`
+		DidSuffix string         `json:"didSuffix"`
+	}{}
+
+	err := json.Unmarshal(request, op)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
+	}
+
+	return &Operation{
+		Namespace:    namespace,
+		Type:         op.Type,
+		UniqueSuffix: op.DidSuffix,
+	}, nil
+}
+
+// Authorizer decides whether or not a principal is permitted to submit an operation.
+type Authorizer interface {
+	// Authorize returns an error wrapping ErrForbidden if the principal is not permitted to submit the operation.
+	// The principal is nil if no authenticator is configured.
+	Authorize(principal *Principal, op *Operation) error
+}
+
+// AuthorizerFunc is an adapter which allows a function to be used as an Authorizer.
+type AuthorizerFunc func(principal *Principal, op *Operation) error
+
+// Authorize invokes the function.
+func (f AuthorizerFunc) Authorize(principal *Principal, op *Operation) error {
+	return f(principal, op)
+}
+
+// Challenge returns the WWW-Authenticate challenge of the given authenticator (or an empty string
+// if the authenticator doesn't provide one).
+func Challenge(a Authenticator) string {
+	c, ok := a.(Challenger)
+	if !ok {
+		return ""
+	}
+
+	return c.Challenge()
+}
+
+// Chain returns an authenticator which tries the given authenticators in order. The principal of the first
+// authenticator that finds credentials in the request is returned. ErrNoCredentials is returned if none of
+// the authenticators finds credentials.
+func Chain(authenticators ...Authenticator) Authenticator {
+	return &chain{authenticators: authenticators}
+}
+
+type chain struct {
+	authenticators []Authenticator
+}
+
+func (c *chain) Authenticate(req *http.Request) (*Principal, error) {
+	for _, a := range c.authenticators {
+		principal, err := a.Authenticate(req)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+func (c *chain) Challenge() string {
+	var challenges []string
+
+	for _, a := range c.authenticators {
+		if challenge := Challenge(a); challenge != "" {
+			challenges = append(challenges, challenge)
+		}
+	}
+
+	return strings.Join(challenges, ", ")
+}
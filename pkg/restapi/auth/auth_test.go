@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+func TestChain(t *testing.T) {
+	alice := &Principal{ID: "alice", Tenant: "tenant1"}
+	bob := &Principal{ID: "bob", Tenant: "tenant2"}
+
+	a := Chain(
+		NewTokenAuthenticator(map[string]*Principal{"alice-token": alice}),
+		NewHMACAuthenticator(map[string]*HMACKey{"bob": {Secret: []byte("secret"), Principal: bob}}),
+	)
+
+	t.Run("first authenticator", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		req.Header.Set("Authorization", "Bearer alice-token")
+
+		p, err := a.Authenticate(req)
+		require.NoError(t, err)
+		require.Equal(t, alice, p)
+	})
+
+	t.Run("second authenticator", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		require.NoError(t, SignRequest(req, "bob", []byte("secret")))
+
+		p, err := a.Authenticate(req)
+		require.NoError(t, err)
+		require.Equal(t, bob, p)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		req.Header.Set("Authorization", "Bearer bob-token")
+
+		p, err := a.Authenticate(req)
+		require.True(t, errors.Is(err, ErrUnauthorized))
+		require.False(t, errors.Is(err, ErrNoCredentials))
+		require.Nil(t, p)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		p, err := a.Authenticate(httptest.NewRequest(http.MethodPost, "/operations", nil))
+		require.True(t, errors.Is(err, ErrNoCredentials))
+		require.True(t, errors.Is(err, ErrUnauthorized))
+		require.Nil(t, p)
+	})
+
+	t.Run("challenge", func(t *testing.T) {
+		require.Equal(t, "Bearer, HMAC-SHA256", Challenge(a))
+		require.Empty(t, Challenge(NewClientCertAuthenticator(nil)))
+	})
+}
+
+func TestAuthorizerFunc(t *testing.T) {
+	errExpected := errors.New("injected error")
+
+	var a Authorizer = AuthorizerFunc(func(principal *Principal, op *Operation) error {
+		if op.Type == operation.TypeDeactivate {
+			return errExpected
+		}
+
+		return nil
+	})
+
+	require.NoError(t, a.Authorize(nil, &Operation{Type: operation.TypeUpdate}))
+	require.Equal(t, errExpected, a.Authorize(nil, &Operation{Type: operation.TypeDeactivate}))
+}
+
+func TestParseOperation(t *testing.T) {
+	op, err := ParseOperation("did:sidetree", []byte(`{"type":"update","didSuffix":"abc"}`))
+	require.NoError(t, err)
+	require.Equal(t, &Operation{Namespace: "did:sidetree", Type: operation.TypeUpdate, UniqueSuffix: "abc"}, op)
+
+	op, err = ParseOperation("did:sidetree", []byte(`{`))
+	require.True(t, errors.Is(err, operation.ErrInvalidOperation))
+	require.Nil(t, op)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ClientCertAuthenticator authenticates requests with a TLS client certificate (mTLS). The certificate
+// must have been verified by the TLS server, i.e. the server's TLS configuration must set ClientCAs and
+// ClientAuth to tls.VerifyClientCertIfGiven or tls.RequireAndVerifyClientCert.
+type ClientCertAuthenticator struct {
+	subjects map[string]*Principal
+}
+
+// NewClientCertAuthenticator returns a new client certificate authenticator. The subjects map the common name
+// of the client certificate to the principal. If no subjects are provided then any verified certificate is
+// accepted and the principal ID is the common name of the certificate.
+func NewClientCertAuthenticator(subjects map[string]*Principal) *ClientCertAuthenticator {
+	return &ClientCertAuthenticator{subjects: subjects}
+}
+
+// Authenticate returns the principal for the client certificate of the request.
+func (a *ClientCertAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	if len(req.TLS.VerifiedChains) == 0 {
+		return nil, fmt.Errorf("%w: client certificate not verified", ErrUnauthorized)
+	}
+
+	cn := req.TLS.VerifiedChains[0][0].Subject.CommonName
+
+	if a.subjects == nil {
+		return &Principal{ID: cn}, nil
+	}
+
+	principal, ok := a.subjects[cn]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown client certificate subject [%s]", ErrUnauthorized, cn)
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCertAuthenticator(t *testing.T) {
+	alice := &Principal{ID: "alice", Tenant: "tenant1"}
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "alice.example.com"}}
+
+	verifiedRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		req.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		}
+
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		a := NewClientCertAuthenticator(map[string]*Principal{"alice.example.com": alice})
+
+		p, err := a.Authenticate(verifiedRequest())
+		require.NoError(t, err)
+		require.Equal(t, alice, p)
+	})
+
+	t.Run("any subject", func(t *testing.T) {
+		p, err := NewClientCertAuthenticator(nil).Authenticate(verifiedRequest())
+		require.NoError(t, err)
+		require.Equal(t, &Principal{ID: "alice.example.com"}, p)
+	})
+
+	t.Run("unknown subject", func(t *testing.T) {
+		a := NewClientCertAuthenticator(map[string]*Principal{"bob.example.com": alice})
+
+		p, err := a.Authenticate(verifiedRequest())
+		require.EqualError(t, err, "unauthorized: unknown client certificate subject [alice.example.com]")
+		require.Nil(t, p)
+	})
+
+	t.Run("not verified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+		p, err := NewClientCertAuthenticator(nil).Authenticate(req)
+		require.EqualError(t, err, "unauthorized: client certificate not verified")
+		require.Nil(t, p)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		a := NewClientCertAuthenticator(nil)
+
+		_, err := a.Authenticate(httptest.NewRequest(http.MethodPost, "/operations", nil))
+		require.True(t, errors.Is(err, ErrNoCredentials))
+
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		req.TLS = &tls.ConnectionState{}
+
+		_, err = a.Authenticate(req)
+		require.True(t, errors.Is(err, ErrNoCredentials))
+	})
+}
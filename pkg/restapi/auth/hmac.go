@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HMACScheme is the Authorization scheme for HMAC-signed requests:
+	//
+	//	Authorization: HMAC-SHA256 keyId=<key ID>,timestamp=<Unix time>,signature=<base64url signature>
+	//
+	// The signature is the HMAC-SHA256 of the string
+	//
+	//	<method>\n<request URI>\n<timestamp>\n<hex encoded SHA-256 of the body>
+	HMACScheme = "HMAC-SHA256"
+
+	defaultMaxClockSkew = 5 * time.Minute
+)
+
+// HMACKey is a shared secret and the principal that it authenticates.
+type HMACKey struct {
+	Secret    []byte
+	Principal *Principal
+}
+
+// HMACAuthenticator authenticates HMAC-signed requests (see HMACScheme). The timestamp of the request
+// must be within the maximum clock skew of the current time.
+type HMACAuthenticator struct {
+	keys         map[string]*HMACKey
+	maxClockSkew time.Duration
+	now          func() time.Time
+}
+
+// HMACOption is an HMAC authenticator option.
+type HMACOption func(opts *HMACAuthenticator)
+
+// WithMaxClockSkew sets the maximum difference between the request timestamp and the current time.
+func WithMaxClockSkew(skew time.Duration) HMACOption {
+	return func(opts *HMACAuthenticator) {
+		opts.maxClockSkew = skew
+	}
+}
+
+// NewHMACAuthenticator returns a new HMAC authenticator for the given keys (map of key ID to key).
+func NewHMACAuthenticator(keys map[string]*HMACKey, opts ...HMACOption) *HMACAuthenticator {
+	a := &HMACAuthenticator{
+		keys:         keys,
+		maxClockSkew: defaultMaxClockSkew,
+		now:          time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Authenticate verifies the HMAC signature of the request and returns the principal of the signing key.
+// The request body is read and replaced so that it may be read again by the handler.
+func (a *HMACAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	value, ok := credentials(req, HMACScheme)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	params := parseParams(value)
+
+	key, ok := a.keys[params["keyId"]]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown HMAC key ID [%s]", ErrUnauthorized, params["keyId"])
+	}
+
+	timestamp, err := strconv.ParseInt(params["timestamp"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid HMAC timestamp", ErrUnauthorized)
+	}
+
+	skew := a.now().Sub(time.Unix(timestamp, 0))
+	if skew > a.maxClockSkew || skew < -a.maxClockSkew {
+		return nil, fmt.Errorf("%w: HMAC timestamp is outside of the allowed clock skew", ErrUnauthorized)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid HMAC signature encoding", ErrUnauthorized)
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read request body: %s", ErrUnauthorized, err.Error())
+	}
+
+	if !hmac.Equal(signature, computeHMAC(key.Secret, req, params["timestamp"], body)) {
+		return nil, fmt.Errorf("%w: invalid HMAC signature", ErrUnauthorized)
+	}
+
+	return key.Principal, nil
+}
+
+// Challenge returns the WWW-Authenticate challenge for HMAC-signed requests.
+func (a *HMACAuthenticator) Challenge() string {
+	return HMACScheme
+}
+
+// SignRequest adds the HMAC Authorization header to the given request. The request body is read and replaced.
+func SignRequest(req *http.Request, keyID string, secret []byte) error {
+	body, err := readBody(req)
+	if err != nil {
+		return fmt.Errorf("read request body: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set(authorizationHeader, fmt.Sprintf("%s keyId=%s,timestamp=%s,signature=%s",
+		HMACScheme, keyID, timestamp,
+		base64.RawURLEncoding.EncodeToString(computeHMAC(secret, req, timestamp, body))))
+
+	return nil
+}
+
+func computeHMAC(secret []byte, req *http.Request, timestamp string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, secret)
+
+	// hash.Hash never returns an error
+	_, _ = mac.Write([]byte(strings.Join([]string{
+		req.Method, req.URL.RequestURI(), timestamp, hex.EncodeToString(bodyHash[:]),
+	}, "\n")))
+
+	return mac.Sum(nil)
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func parseParams(value string) map[string]string {
+	params := make(map[string]string)
+
+	for _, param := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		params[parts[0]] = strings.Trim(parts[1], `"`)
+	}
+
+	return params
+}
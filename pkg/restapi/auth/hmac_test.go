@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACAuthenticator(t *testing.T) {
+	alice := &Principal{ID: "alice", Tenant: "tenant1"}
+
+	a := NewHMACAuthenticator(map[string]*HMACKey{
+		"alice": {Secret: []byte("alice-secret"), Principal: alice},
+	}, WithMaxClockSkew(time.Minute))
+
+	require.Equal(t, HMACScheme, a.Challenge())
+
+	newRequest := func(t *testing.T, secret string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/operations?x=1", strings.NewReader(`{"type":"create"}`))
+		require.NoError(t, SignRequest(req, "alice", []byte(secret)))
+
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := newRequest(t, "alice-secret")
+
+		p, err := a.Authenticate(req)
+		require.NoError(t, err)
+		require.Equal(t, alice, p)
+
+		// the body can be read again by the handler
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, `{"type":"create"}`, string(body))
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/operations", nil)
+		require.NoError(t, SignRequest(req, "alice", []byte("alice-secret")))
+
+		p, err := a.Authenticate(req)
+		require.NoError(t, err)
+		require.Equal(t, alice, p)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		p, err := a.Authenticate(httptest.NewRequest(http.MethodPost, "/operations", nil))
+		require.True(t, errors.Is(err, ErrNoCredentials))
+		require.Nil(t, p)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		p, err := a.Authenticate(newRequest(t, "other-secret"))
+		require.EqualError(t, err, "unauthorized: invalid HMAC signature")
+		require.Nil(t, p)
+	})
+
+	t.Run("modified body", func(t *testing.T) {
+		req := newRequest(t, "alice-secret")
+		req.Body = ioutil.NopCloser(strings.NewReader(`{"type":"deactivate"}`))
+
+		_, err := a.Authenticate(req)
+		require.EqualError(t, err, "unauthorized: invalid HMAC signature")
+	})
+
+	t.Run("modified URI", func(t *testing.T) {
+		req := newRequest(t, "alice-secret")
+		req.URL.RawQuery = "x=2"
+
+		_, err := a.Authenticate(req)
+		require.EqualError(t, err, "unauthorized: invalid HMAC signature")
+	})
+
+	t.Run("unknown key ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		require.NoError(t, SignRequest(req, "bob", []byte("alice-secret")))
+
+		_, err := a.Authenticate(req)
+		require.EqualError(t, err, "unauthorized: unknown HMAC key ID [bob]")
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		tests := map[string]string{
+			"keyId=alice,timestamp=abc,signature=xyz":           "unauthorized: invalid HMAC timestamp",
+			`keyId="alice",timestamp=` + now() + `,signature=!`: "unauthorized: invalid HMAC signature encoding",
+			"keyId=alice,timestamp=" + now() + ",invalid":       "unauthorized: invalid HMAC signature",
+		}
+
+		for params, expected := range tests {
+			req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+			req.Header.Set("Authorization", HMACScheme+" "+params)
+
+			_, err := a.Authenticate(req)
+			require.EqualError(t, err, expected, params)
+		}
+	})
+
+	t.Run("clock skew", func(t *testing.T) {
+		req := newRequest(t, "alice-secret")
+
+		a.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+		defer func() { a.now = time.Now }()
+
+		_, err := a.Authenticate(req)
+		require.EqualError(t, err, "unauthorized: HMAC timestamp is outside of the allowed clock skew")
+
+		a.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+
+		_, err = a.Authenticate(req)
+		require.EqualError(t, err, "unauthorized: HMAC timestamp is outside of the allowed clock skew")
+	})
+
+	t.Run("read body error", func(t *testing.T) {
+		req := newRequest(t, "alice-secret")
+		req.Body = ioutil.NopCloser(&failingReader{})
+
+		_, err := a.Authenticate(req)
+		require.EqualError(t, err, "unauthorized: read request body: injected read error")
+
+		req = httptest.NewRequest(http.MethodPost, "/operations", &failingReader{})
+		require.EqualError(t, SignRequest(req, "alice", []byte("alice-secret")),
+			"read request body: injected read error")
+	})
+}
+
+func now() string {
+	return strconv.FormatInt(time.Now().Unix(), 10)
+}
+
+type failingReader struct{}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("injected read error")
+}
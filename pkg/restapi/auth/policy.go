@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+// TenantPolicy permits operations per tenant and operation type.
+type TenantPolicy struct {
+	permissions map[string]map[operation.Type]bool
+}
+
+// NewTenantPolicy returns a new tenant policy. The permissions map the tenant to the operation types that it
+// may submit. A tenant with no operation types may submit operations of any type. Principals of tenants that
+// are not in the map (and anonymous callers) are not permitted to submit operations.
+func NewTenantPolicy(permissions map[string][]operation.Type) *TenantPolicy {
+	p := &TenantPolicy{permissions: make(map[string]map[operation.Type]bool)}
+
+	for tenant, types := range permissions {
+		allowed := make(map[operation.Type]bool)
+
+		for _, t := range types {
+			allowed[t] = true
+		}
+
+		p.permissions[tenant] = allowed
+	}
+
+	return p
+}
+
+// Authorize returns an error wrapping ErrForbidden if the tenant of the principal may not submit the operation.
+func (p *TenantPolicy) Authorize(principal *Principal, op *Operation) error {
+	if principal == nil {
+		return fmt.Errorf("%w: anonymous caller", ErrForbidden)
+	}
+
+	allowed, ok := p.permissions[principal.Tenant]
+	if !ok {
+		return fmt.Errorf("%w: tenant [%s] may not submit operations", ErrForbidden, principal.Tenant)
+	}
+
+	if len(allowed) > 0 && !allowed[op.Type] {
+		return fmt.Errorf("%w: tenant [%s] may not submit %s operations", ErrForbidden, principal.Tenant, op.Type)
+	}
+
+	return nil
+}
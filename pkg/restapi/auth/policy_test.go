@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+)
+
+func TestTenantPolicy(t *testing.T) {
+	p := NewTenantPolicy(map[string][]operation.Type{
+		"tenant1": {operation.TypeCreate, operation.TypeUpdate},
+		"tenant2": nil,
+	})
+
+	tests := []struct {
+		principal *Principal
+		opType    operation.Type
+		allowed   bool
+	}{
+		{&Principal{ID: "alice", Tenant: "tenant1"}, operation.TypeCreate, true},
+		{&Principal{ID: "alice", Tenant: "tenant1"}, operation.TypeUpdate, true},
+		{&Principal{ID: "alice", Tenant: "tenant1"}, operation.TypeRecover, false},
+		{&Principal{ID: "alice", Tenant: "tenant1"}, operation.TypeDeactivate, false},
+		{&Principal{ID: "bob", Tenant: "tenant2"}, operation.TypeDeactivate, true},
+		{&Principal{ID: "carol", Tenant: "tenant3"}, operation.TypeCreate, false},
+		{&Principal{ID: "dave"}, operation.TypeCreate, false},
+		{nil, operation.TypeCreate, false},
+	}
+
+	for _, tc := range tests {
+		err := p.Authorize(tc.principal, &Operation{Namespace: "did:sidetree", Type: tc.opType})
+		if tc.allowed {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrForbidden))
+		}
+	}
+
+	err := p.Authorize(&Principal{ID: "alice", Tenant: "tenant1"}, &Operation{Type: operation.TypeRecover})
+	require.EqualError(t, err, "forbidden: tenant [tenant1] may not submit recover operations")
+}
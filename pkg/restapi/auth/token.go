@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerScheme        = "Bearer"
+)
+
+// TokenAuthenticator authenticates requests with a static bearer token (Authorization: Bearer <token>).
+type TokenAuthenticator struct {
+	tokens []*token
+}
+
+type token struct {
+	hash      [sha256.Size]byte
+	principal *Principal
+}
+
+// NewTokenAuthenticator returns a new bearer token authenticator for the given tokens
+// (map of token to the principal that it authenticates).
+func NewTokenAuthenticator(tokens map[string]*Principal) *TokenAuthenticator {
+	a := &TokenAuthenticator{}
+
+	for t, p := range tokens {
+		a.tokens = append(a.tokens, &token{hash: sha256.Sum256([]byte(t)), principal: p})
+	}
+
+	return a
+}
+
+// Authenticate returns the principal for the bearer token in the request.
+func (a *TokenAuthenticator) Authenticate(req *http.Request) (*Principal, error) {
+	value, ok := credentials(req, bearerScheme)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	// hashes are compared (in constant time) so that the comparison doesn't depend on the token length
+	hash := sha256.Sum256([]byte(value))
+
+	var principal *Principal
+
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(hash[:], t.hash[:]) == 1 {
+			principal = t.principal
+		}
+	}
+
+	if principal == nil {
+		return nil, fmt.Errorf("%w: invalid bearer token", ErrUnauthorized)
+	}
+
+	return principal, nil
+}
+
+// Challenge returns the WWW-Authenticate challenge for bearer tokens.
+func (a *TokenAuthenticator) Challenge() string {
+	return bearerScheme
+}
+
+// credentials returns the credentials from the Authorization header if the header uses the given scheme.
+func credentials(req *http.Request, scheme string) (string, bool) {
+	value := req.Header.Get(authorizationHeader)
+
+	if len(value) <= len(scheme) || !strings.EqualFold(value[:len(scheme)], scheme) || value[len(scheme)] != ' ' {
+		return "", false
+	}
+
+	return strings.TrimSpace(value[len(scheme)+1:]), true
+}
@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAuthenticator(t *testing.T) {
+	alice := &Principal{ID: "alice", Tenant: "tenant1"}
+	bob := &Principal{ID: "bob", Tenant: "tenant2"}
+
+	a := NewTokenAuthenticator(map[string]*Principal{
+		"alice-token": alice,
+		"bob-token":   bob,
+	})
+
+	require.Equal(t, "Bearer", a.Challenge())
+
+	t.Run("success", func(t *testing.T) {
+		for _, header := range []string{"Bearer alice-token", "bearer alice-token", "Bearer  alice-token "} {
+			req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+			req.Header.Set("Authorization", header)
+
+			p, err := a.Authenticate(req)
+			require.NoError(t, err, header)
+			require.Equal(t, alice, p)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+		req.Header.Set("Authorization", "Bearer bob-token")
+
+		p, err := a.Authenticate(req)
+		require.NoError(t, err)
+		require.Equal(t, bob, p)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		for _, token := range []string{"alice-token2", "alice", ""} {
+			req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			p, err := a.Authenticate(req)
+			require.EqualError(t, err, "unauthorized: invalid bearer token", token)
+			require.Nil(t, p)
+		}
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		for _, header := range []string{"", "Basic YWxpY2U6cGFzc3dvcmQ=", "Bearer", "Bearertoken"} {
+			req := httptest.NewRequest(http.MethodPost, "/operations", nil)
+			req.Header.Set("Authorization", header)
+
+			p, err := a.Authenticate(req)
+			require.True(t, errors.Is(err, ErrNoCredentials), header)
+			require.Nil(t, p)
+		}
+	})
+}
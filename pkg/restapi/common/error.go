@@ -6,17 +6,108 @@ SPDX-License-Identifier: Apache-2.0
 
 package common
 
-// HTTPError holds an error and an HTTP status code.
+import (
+	"errors"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+)
+
+// ErrorCode is a machine-readable code which identifies the type of error.
+type ErrorCode string
+
+const (
+	// ErrorCodeBadRequest indicates that the request could not be read.
+	ErrorCodeBadRequest ErrorCode = "bad_request"
+
+	// ErrorCodeInvalidDID indicates that the DID is malformed or doesn't belong to a supported namespace.
+	ErrorCodeInvalidDID ErrorCode = "invalid_did"
+
+	// ErrorCodeInvalidOperation indicates that the operation request failed parsing or validation.
+	ErrorCodeInvalidOperation ErrorCode = "invalid_operation"
+
+	// ErrorCodeUnauthorized indicates that the request could not be authenticated.
+	ErrorCodeUnauthorized ErrorCode = "unauthorized"
+
+	// ErrorCodeForbidden indicates that the caller is not permitted to perform the request.
+	ErrorCodeForbidden ErrorCode = "forbidden"
+
+	// ErrorCodeNotFound indicates that the document was not found.
+	ErrorCodeNotFound ErrorCode = "not_found"
+
+	// ErrorCodeDeactivated indicates that the document has been deactivated.
+	ErrorCodeDeactivated ErrorCode = "deactivated"
+
+	// ErrorCodeInvalidQuery indicates that the query parameters are invalid.
+	ErrorCodeInvalidQuery ErrorCode = "invalid_query"
+
+	// ErrorCodeProtocolUnavailable indicates that the protocol version could not be retrieved.
+	ErrorCodeProtocolUnavailable ErrorCode = "protocol_unavailable"
+
+	// ErrorCodeRequestTooLarge indicates that the request body exceeds the maximum size.
+	ErrorCodeRequestTooLarge ErrorCode = "request_too_large"
+
+	// ErrorCodeInternal indicates an unexpected server error.
+	ErrorCodeInternal ErrorCode = "internal_error"
+)
+
+var (
+	errDocumentNotFound    = errors.New("document not found")
+	errDocumentUnavailable = errors.New("document is no longer available")
+)
+
+// HTTPError holds an error, an HTTP status code and an error code.
 type HTTPError struct {
 	err    error
 	status int
+	code   ErrorCode
 }
 
-// NewHTTPError returns a new HTTPError.
+// NewHTTPError returns a new HTTPError. The error code is derived from the status code.
 func NewHTTPError(status int, err error) *HTTPError {
+	return NewHTTPErrorWithCode(status, codeFromStatus(status), err)
+}
+
+// NewHTTPErrorWithCode returns a new HTTPError with the given error code.
+func NewHTTPErrorWithCode(status int, code ErrorCode, err error) *HTTPError {
 	return &HTTPError{
 		err:    err,
 		status: status,
+		code:   code,
+	}
+}
+
+// MapError maps the given error to an HTTPError according to the sentinel error that it wraps.
+// Errors that don't wrap a known sentinel error are mapped to an internal server error.
+func MapError(err error) *HTTPError {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	switch {
+	case errors.Is(err, document.ErrInvalidDID):
+		return NewHTTPErrorWithCode(http.StatusBadRequest, ErrorCodeInvalidDID, err)
+	case errors.Is(err, operation.ErrInvalidOperation):
+		return NewHTTPErrorWithCode(http.StatusBadRequest, ErrorCodeInvalidOperation, err)
+	case errors.Is(err, store.ErrInvalidQuery):
+		return NewHTTPErrorWithCode(http.StatusBadRequest, ErrorCodeInvalidQuery, err)
+	case errors.Is(err, auth.ErrUnauthorized):
+		return NewHTTPErrorWithCode(http.StatusUnauthorized, ErrorCodeUnauthorized, err)
+	case errors.Is(err, auth.ErrForbidden):
+		return NewHTTPErrorWithCode(http.StatusForbidden, ErrorCodeForbidden, err)
+	case errors.Is(err, document.ErrNotFound):
+		return NewHTTPErrorWithCode(http.StatusNotFound, ErrorCodeNotFound, errDocumentNotFound)
+	case errors.Is(err, document.ErrDeactivated):
+		return NewHTTPErrorWithCode(http.StatusGone, ErrorCodeDeactivated, errDocumentUnavailable)
+	case errors.Is(err, protocol.ErrUnavailable):
+		return NewHTTPErrorWithCode(http.StatusServiceUnavailable, ErrorCodeProtocolUnavailable, err)
+	default:
+		return NewHTTPErrorWithCode(http.StatusInternalServerError, ErrorCodeInternal, err)
 	}
 }
 
@@ -25,7 +116,48 @@ func (e *HTTPError) Error() string {
 	return e.err.Error()
 }
 
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.err
+}
+
 // Status returns the status code.
 func (e *HTTPError) Status() int {
 	return e.status
 }
+
+// Code returns the error code.
+func (e *HTTPError) Code() ErrorCode {
+	return e.code
+}
+
+// PublicMessage returns the error message which may be returned to clients. The details of internal errors
+// (server errors) are not disclosed and should be logged instead.
+func (e *HTTPError) PublicMessage() string {
+	if e.status >= http.StatusInternalServerError {
+		return http.StatusText(e.status)
+	}
+
+	return e.err.Error()
+}
+
+func codeFromStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusGone:
+		return ErrorCodeDeactivated
+	case http.StatusServiceUnavailable:
+		return ErrorCodeProtocolUnavailable
+	case http.StatusRequestEntityTooLarge:
+		return ErrorCodeRequestTooLarge
+	default:
+		return ErrorCodeInternal
+	}
+}
@@ -8,10 +8,17 @@ package common
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
 )
 
 func TestNewHTTPError(t *testing.T) {
@@ -21,3 +28,45 @@ func TestNewHTTPError(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, err.Status())
 	require.Equal(t, errExpected.Error(), err.Error())
 }
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   ErrorCode
+	}{
+		{fmt.Errorf("%w: some detail", document.ErrInvalidDID), http.StatusBadRequest, ErrorCodeInvalidDID},
+		{fmt.Errorf("%w: some detail", operation.ErrInvalidOperation), http.StatusBadRequest, ErrorCodeInvalidOperation},
+		{fmt.Errorf("%w: some detail", store.ErrInvalidQuery), http.StatusBadRequest, ErrorCodeInvalidQuery},
+		{fmt.Errorf("%w: some detail", auth.ErrUnauthorized), http.StatusUnauthorized, ErrorCodeUnauthorized},
+		{fmt.Errorf("%w: some detail", auth.ErrForbidden), http.StatusForbidden, ErrorCodeForbidden},
+		{fmt.Errorf("%w: some detail", document.ErrNotFound), http.StatusNotFound, ErrorCodeNotFound},
+		{document.ErrDeactivated, http.StatusGone, ErrorCodeDeactivated},
+		{fmt.Errorf("%w: some detail", protocol.ErrUnavailable), http.StatusServiceUnavailable, ErrorCodeProtocolUnavailable},
+		{errors.New("some error"), http.StatusInternalServerError, ErrorCodeInternal},
+		{NewHTTPError(http.StatusBadRequest, errors.New("some error")), http.StatusBadRequest, ErrorCodeBadRequest},
+		{NewHTTPError(http.StatusRequestEntityTooLarge, errors.New("some error")), http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge},
+	}
+
+	for _, tc := range tests {
+		err := MapError(tc.err)
+		require.Equal(t, tc.status, err.Status(), tc.err.Error())
+		require.Equal(t, tc.code, err.Code(), tc.err.Error())
+	}
+
+	t.Run("not found details are not exposed", func(t *testing.T) {
+		err := MapError(fmt.Errorf("%w: uniqueSuffix not found in the store", document.ErrNotFound))
+		require.Equal(t, "document not found", err.Error())
+	})
+
+	t.Run("public message", func(t *testing.T) {
+		err := MapError(fmt.Errorf("%w: some detail", operation.ErrInvalidOperation))
+		require.Contains(t, err.PublicMessage(), "some detail")
+
+		err = MapError(errors.New("some internal detail"))
+		require.Equal(t, "Internal Server Error", err.PublicMessage())
+
+		err = MapError(fmt.Errorf("%w: some internal detail", protocol.ErrUnavailable))
+		require.Equal(t, "Service Unavailable", err.PublicMessage())
+	})
+}
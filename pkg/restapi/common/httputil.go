@@ -8,9 +8,15 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 )
 
+const (
+	problemContentType = "application/problem+json"
+	problemTypeDefault = "about:blank"
+)
+
 // WriteResponse writes a response to the response writer.
 func WriteResponse(rw http.ResponseWriter, status int, v interface{}) {
 	rw.Header().Set("Content-Type", "application/did+ld+json")
@@ -21,13 +27,45 @@ func WriteResponse(rw http.ResponseWriter, status int, v interface{}) {
 	}
 }
 
-// WriteError writes an error to the response writer.
+// ProblemDetails is the JSON error response body as defined in RFC 7807 (Problem Details for HTTP APIs),
+// extended with a machine-readable error code.
+type ProblemDetails struct {
+	Type   string    `json:"type"`
+	Title  string    `json:"title"`
+	Status int       `json:"status"`
+	Detail string    `json:"detail,omitempty"`
+	Code   ErrorCode `json:"code"`
+}
+
+// NewProblemDetails returns the problem details for the given status and error. If the given error
+// is an HTTPError then its error code is used, otherwise the error code is derived from the status code.
+// The detail is the public message of the error (see HTTPError.PublicMessage), i.e. the details of
+// server errors are not disclosed.
+func NewProblemDetails(status int, err error) *ProblemDetails {
+	httpErr := NewHTTPError(status, err)
+
+	var e *HTTPError
+	if errors.As(err, &e) {
+		httpErr = NewHTTPErrorWithCode(status, e.Code(), err)
+	}
+
+	return &ProblemDetails{
+		Type:   problemTypeDefault,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: httpErr.PublicMessage(),
+		Code:   httpErr.Code(),
+	}
+}
+
+// WriteError writes an error to the response writer as a problem details JSON body.
 func WriteError(rw http.ResponseWriter, status int, err error) {
 	logger.Warnf("returning error status: %d, message: %s", status, err.Error())
 
-	rw.Header().Set("Content-Type", "text/plain")
+	rw.Header().Set("Content-Type", problemContentType)
 	rw.WriteHeader(status)
-	_, e := rw.Write([]byte(err.Error()))
+
+	e := json.NewEncoder(rw).Encode(NewProblemDetails(status, err))
 	if e != nil {
 		logger.Errorf("Unable to write response: %s", e)
 	}
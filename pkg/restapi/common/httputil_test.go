@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -28,5 +29,43 @@ func TestWriteError(t *testing.T) {
 	errExpected := errors.New("some error")
 	WriteError(rw, http.StatusBadRequest, errExpected)
 	require.Equal(t, http.StatusBadRequest, rw.Code)
-	require.Equal(t, errExpected.Error(), rw.Body.String())
+	require.Equal(t, "application/problem+json", rw.Header().Get("content-type"))
+
+	var problem ProblemDetails
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+	require.Equal(t, http.StatusBadRequest, problem.Status)
+	require.Equal(t, http.StatusText(http.StatusBadRequest), problem.Title)
+	require.Equal(t, errExpected.Error(), problem.Detail)
+	require.Equal(t, ErrorCodeBadRequest, problem.Code)
+}
+
+func TestWriteError_HTTPError(t *testing.T) {
+	rw := httptest.NewRecorder()
+	errExpected := NewHTTPErrorWithCode(http.StatusBadRequest, ErrorCodeInvalidDID, errors.New("some error"))
+	WriteError(rw, errExpected.Status(), errExpected)
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+
+	var problem ProblemDetails
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+	require.Equal(t, ErrorCodeInvalidDID, problem.Code)
+	require.Equal(t, errExpected.Error(), problem.Detail)
+}
+
+func TestNewProblemDetails(t *testing.T) {
+	t.Run("client error", func(t *testing.T) {
+		problem := NewProblemDetails(http.StatusBadRequest, errors.New("some error"))
+		require.Equal(t, "some error", problem.Detail)
+		require.Equal(t, ErrorCodeBadRequest, problem.Code)
+	})
+
+	t.Run("server error details are not disclosed", func(t *testing.T) {
+		problem := NewProblemDetails(http.StatusInternalServerError, errors.New("store error"))
+		require.Equal(t, http.StatusText(http.StatusInternalServerError), problem.Detail)
+		require.Equal(t, ErrorCodeInternal, problem.Code)
+
+		problem = NewProblemDetails(http.StatusServiceUnavailable,
+			NewHTTPErrorWithCode(http.StatusServiceUnavailable, ErrorCodeProtocolUnavailable, errors.New("protocol error")))
+		require.Equal(t, http.StatusText(http.StatusServiceUnavailable), problem.Detail)
+		require.Equal(t, ErrorCodeProtocolUnavailable, problem.Code)
+	})
 }
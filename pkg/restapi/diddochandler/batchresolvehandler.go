@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+// BatchResolveHandler resolves multiple DID documents in a single request.
+type BatchResolveHandler struct {
+	*handler
+}
+
+// NewBatchResolveHandler returns a new DID document batch resolve handler. The maximum number of DIDs
+// per request defaults to dochandler.DefaultMaxBatchResolutionSize if maxSize is not positive.
+func NewBatchResolveHandler(basePath string, resolver dochandler.BatchResolver, maxSize int) *BatchResolveHandler {
+	return &BatchResolveHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/identifiers/resolve", basePath),
+			http.MethodPost,
+			dochandler.NewBatchResolveHandler(resolver, maxSize).Resolve,
+		),
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestBatchResolveHandler_Resolve(t *testing.T) {
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace)
+	handler := NewBatchResolveHandler(basePath, docHandler, 0)
+	require.Equal(t, basePath+"/identifiers/resolve", handler.Path())
+	require.Equal(t, http.MethodPost, handler.Method())
+	require.NotNil(t, handler.Handler())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/document/identifiers/resolve", bytes.NewReader([]byte(`{"ids":["someid"]}`)))
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), "must start with supported namespace")
+}
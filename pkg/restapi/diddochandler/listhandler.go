@@ -0,0 +1,30 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+// ListHandler lists the DIDs known to the node.
+type ListHandler struct {
+	*handler
+}
+
+// NewListHandler returns a new DID list handler.
+func NewListHandler(basePath, namespace string, index dochandler.OperationIndex) *ListHandler {
+	return &ListHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/identifiers", basePath),
+			http.MethodGet,
+			dochandler.NewListHandler(namespace, index).List,
+		),
+	}
+}
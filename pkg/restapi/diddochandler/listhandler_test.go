@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/opstore"
+)
+
+func TestListHandler_List(t *testing.T) {
+	opStore := opstore.New(opstore.NewMemBackend())
+	require.NoError(t, opStore.Put([]*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: "suffix", TransactionTime: 10},
+	}))
+
+	handler := NewListHandler(basePath, namespace, opStore)
+	require.Equal(t, basePath+"/identifiers", handler.Path())
+	require.Equal(t, http.MethodGet, handler.Method())
+	require.NotNil(t, handler.Handler())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/document/identifiers?createdAfter=9", nil)
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), namespace+":suffix")
+}
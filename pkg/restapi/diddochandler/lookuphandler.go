@@ -0,0 +1,30 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+// LookupHandler finds DIDs by JWK thumbprint, key ID or service endpoint.
+type LookupHandler struct {
+	*handler
+}
+
+// NewLookupHandler returns a new DID lookup handler.
+func NewLookupHandler(basePath string, finder dochandler.DIDFinder) *LookupHandler {
+	return &LookupHandler{
+		handler: newHandler(
+			fmt.Sprintf("%s/lookup", basePath),
+			http.MethodGet,
+			dochandler.NewLookupHandler(finder).Lookup,
+		),
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diddochandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+)
+
+func TestLookupHandler_Lookup(t *testing.T) {
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace)
+	handler := NewLookupHandler(basePath, docHandler)
+	require.Equal(t, basePath+"/lookup", handler.Path())
+	require.Equal(t, http.MethodGet, handler.Method())
+	require.NotNil(t, handler.Handler())
+
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/document/lookup?keyId=key1", nil)
+	handler.Handler()(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), `"ids":[]`)
+}
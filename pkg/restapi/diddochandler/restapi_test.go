@@ -22,6 +22,7 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
 )
 
 const (
@@ -38,6 +39,7 @@ func TestRESTAPI(t *testing.T) {
 		url,
 		NewUpdateHandler(basePath, didDocHandler, pc),
 		NewResolveHandler(basePath, didDocHandler),
+		NewBatchResolveHandler(basePath, didDocHandler, 0),
 	)
 	s.start()
 	defer s.stop()
@@ -76,6 +78,28 @@ func TestRESTAPI(t *testing.T) {
 
 		require.Equal(t, didID, result.Document["id"])
 	})
+	t.Run("Batch resolve DID docs", func(t *testing.T) {
+		createRequest, err := getCreateRequest()
+		require.NoError(t, err)
+
+		didID, err := getID(createRequest.SuffixData)
+		require.NoError(t, err)
+
+		request, err := json.Marshal(&dochandler.BatchResolutionRequest{IDs: []string{didID, didID}})
+		require.NoError(t, err)
+
+		resp, err := httpPut(t, clientURL+basePath+"/identifiers/resolve", request)
+		require.NoError(t, err)
+
+		var response dochandler.BatchResolutionResponse
+		require.NoError(t, json.Unmarshal(resp, &response))
+		require.Len(t, response.Resolutions, 2)
+
+		for _, resolution := range response.Resolutions {
+			require.Nil(t, resolution.Error)
+			require.Equal(t, didID, resolution.Result.Document["id"])
+		}
+	})
 }
 
 // httpPut sends a regular POST request to the sidetree-node
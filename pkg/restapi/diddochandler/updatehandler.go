@@ -19,13 +19,15 @@ type UpdateHandler struct {
 	*handler
 }
 
-// NewUpdateHandler returns a new DID document update handler.
-func NewUpdateHandler(basePath string, processor dochandler.Processor, pc protocol.Client) *UpdateHandler {
+// NewUpdateHandler returns a new DID document update handler. Authentication and authorization
+// may be enabled using the dochandler.WithAuthenticator and dochandler.WithAuthorizer options.
+func NewUpdateHandler(basePath string, processor dochandler.Processor, pc protocol.Client,
+	opts ...dochandler.UpdateOption) *UpdateHandler {
 	return &UpdateHandler{
 		handler: newHandler(
 			fmt.Sprintf("%s/operations", basePath),
 			http.MethodPost,
-			dochandler.NewUpdateHandler(processor, pc).Update,
+			dochandler.NewUpdateHandler(processor, pc, opts...).Update,
 		),
 	}
 }
@@ -89,7 +89,7 @@ func TestUpdateHandler_Update_Error(t *testing.T) {
 
 		body, err := ioutil.ReadAll(rw.Body)
 		require.NoError(t, err)
-		require.Contains(t, string(body), "bad request: operation type [other] not supported")
+		require.Contains(t, string(body), "invalid operation: operation type [other] not supported")
 	})
 }
 
@@ -176,9 +176,16 @@ var testJWK = &jws.JWK{
 
 func newMockProtocolClient() *mocks.MockProtocolClient {
 	pc := mocks.NewMockProtocolClient()
-	parser := operationparser.New(pc.Protocol)
+	parser, err := operationparser.New(pc.Protocol)
+	if err != nil {
+		panic(err)
+	}
+
 	dc := doccomposer.New()
-	oa := operationapplier.New(pc.Protocol, parser, dc)
+	oa, err := operationapplier.New(pc.Protocol, parser, dc)
+	if err != nil {
+		panic(err)
+	}
 
 	pv := pc.CurrentVersion
 	pv.OperationParserReturns(parser)
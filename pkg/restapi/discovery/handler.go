@@ -0,0 +1,171 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+var logger = log.New("sidetree-core-restapi-discovery")
+
+// Namespace contains the namespace, its aliases and the protocol client which provides
+// the protocol versions for the namespace.
+type Namespace struct {
+	Namespace      string
+	Aliases        []string
+	ProtocolClient protocol.Client
+}
+
+// Response contains the protocol parameters for each of the namespaces served by this node.
+type Response struct {
+	Namespaces []*NamespaceInfo `json:"namespaces"`
+}
+
+// NamespaceInfo contains the current and historical protocol parameters for a namespace.
+// Protocols are sorted by genesis time (ascending).
+type NamespaceInfo struct {
+	Namespace string          `json:"namespace"`
+	Aliases   []string        `json:"aliases"`
+	Current   *ProtocolInfo   `json:"current"`
+	Protocols []*ProtocolInfo `json:"protocols"`
+}
+
+// ProtocolInfo contains the protocol parameters along with the name of the protocol version which implements them.
+type ProtocolInfo struct {
+	Version string `json:"version"`
+
+	protocol.Protocol
+}
+
+// Handler returns the protocol parameters for the configured namespaces.
+type Handler struct {
+	path       string
+	namespaces []*Namespace
+}
+
+// NewHandler returns a new discovery handler.
+func NewHandler(path string, namespaces ...*Namespace) *Handler {
+	return &Handler{
+		path:       path,
+		namespaces: namespaces,
+	}
+}
+
+// Path returns the context path.
+func (h *Handler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the handler.
+func (h *Handler) Handler() common.HTTPRequestHandler {
+	return h.discover
+}
+
+func (h *Handler) discover(rw http.ResponseWriter, _ *http.Request) {
+	resp, err := Discover(h.namespaces...)
+	if err != nil {
+		httpErr := common.MapError(err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error retrieving protocols: %s", err.Error())
+		}
+
+		common.WriteError(rw, httpErr.Status(), httpErr)
+
+		return
+	}
+
+	common.WriteResponse(rw, http.StatusOK, resp)
+}
+
+// Discover returns the protocol parameters for the given namespaces. An error wrapping protocol.ErrUnavailable
+// is returned if the current protocol of any of the namespaces can't be retrieved.
+func Discover(namespaces ...*Namespace) (*Response, error) {
+	resp := &Response{
+		Namespaces: make([]*NamespaceInfo, 0, len(namespaces)),
+	}
+
+	for _, ns := range namespaces {
+		info, err := getNamespaceInfo(ns)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Namespaces = append(resp.Namespaces, info)
+	}
+
+	return resp, nil
+}
+
+func getNamespaceInfo(ns *Namespace) (*NamespaceInfo, error) {
+	current, err := ns.ProtocolClient.Current()
+	if err != nil {
+		return nil, fmt.Errorf("%w: get current protocol for namespace [%s]: %s",
+			protocol.ErrUnavailable, ns.Namespace, err.Error())
+	}
+
+	aliases := ns.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return &NamespaceInfo{
+		Namespace: ns.Namespace,
+		Aliases:   aliases,
+		Current:   newProtocolInfo(current),
+		Protocols: getProtocolHistory(ns, current),
+	}, nil
+}
+
+// getProtocolHistory walks back from the current protocol version to the genesis version. The protocol
+// client doesn't expose the full list of versions so the previous version is retrieved using the
+// transaction time just before the genesis time of the later version.
+func getProtocolHistory(ns *Namespace, current protocol.Version) []*ProtocolInfo {
+	protocols := []*ProtocolInfo{newProtocolInfo(current)}
+
+	pv := current
+	for pv.Protocol().GenesisTime > 0 {
+		prev, err := ns.ProtocolClient.Get(pv.Protocol().GenesisTime - 1)
+		if err != nil {
+			logger.Debugf("no protocol version for namespace [%s] before genesis time [%d]: %s",
+				ns.Namespace, pv.Protocol().GenesisTime, err.Error())
+
+			break
+		}
+
+		if prev.Protocol().GenesisTime >= pv.Protocol().GenesisTime {
+			break
+		}
+
+		protocols = append(protocols, newProtocolInfo(prev))
+		pv = prev
+	}
+
+	sort.Slice(protocols, func(i, j int) bool {
+		return protocols[i].GenesisTime < protocols[j].GenesisTime
+	})
+
+	return protocols
+}
+
+func newProtocolInfo(pv protocol.Version) *ProtocolInfo {
+	return &ProtocolInfo{
+		Version:  pv.Version(),
+		Protocol: pv.Protocol(),
+	}
+}
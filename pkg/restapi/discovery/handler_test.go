@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	namespace = "did:sidetree"
+	alias     = "did:sidetree:test"
+)
+
+func TestNewHandler(t *testing.T) {
+	h := NewHandler("/version")
+	require.Equal(t, "/version", h.Path())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.NotNil(t, h.Handler())
+}
+
+func TestHandler_Discover(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		pc := newMockProtocolClientWithUpgrade()
+
+		h := NewHandler("/version",
+			&Namespace{Namespace: namespace, Aliases: []string{alias}, ProtocolClient: pc},
+			&Namespace{Namespace: "did:other", ProtocolClient: mocks.NewMockProtocolClient()},
+		)
+
+		rw := httptest.NewRecorder()
+		h.Handler()(rw, httptest.NewRequest(http.MethodGet, "/version", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.Len(t, resp.Namespaces, 2)
+
+		ns := resp.Namespaces[0]
+		require.Equal(t, namespace, ns.Namespace)
+		require.Equal(t, []string{alias}, ns.Aliases)
+		require.Equal(t, uint64(100), ns.Current.GenesisTime)
+		require.Equal(t, "0.2", ns.Current.Version)
+		require.Equal(t, []string{"replace"}, ns.Current.Patches)
+
+		require.Len(t, ns.Protocols, 2)
+		require.Equal(t, uint64(0), ns.Protocols[0].GenesisTime)
+		require.Equal(t, mocks.CurrentVersion, ns.Protocols[0].Version)
+		require.Equal(t, pc.Versions[0].Protocol(), ns.Protocols[0].Protocol)
+		require.Equal(t, uint64(100), ns.Protocols[1].GenesisTime)
+
+		ns = resp.Namespaces[1]
+		require.Equal(t, "did:other", ns.Namespace)
+		require.NotNil(t, ns.Aliases)
+		require.Empty(t, ns.Aliases)
+		require.Len(t, ns.Protocols, 1)
+		require.Equal(t, ns.Current, ns.Protocols[0])
+	})
+
+	t.Run("success - no namespaces", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		NewHandler("/version").Handler()(rw, httptest.NewRequest(http.MethodGet, "/version", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.NotNil(t, resp.Namespaces)
+		require.Empty(t, resp.Namespaces)
+	})
+
+	t.Run("success - history is truncated when previous version is not available", func(t *testing.T) {
+		pc := newMockProtocolClientWithUpgrade()
+		pc.Versions = pc.Versions[1:]
+
+		rw := httptest.NewRecorder()
+		NewHandler("/version", &Namespace{Namespace: namespace, ProtocolClient: pc}).
+			Handler()(rw, httptest.NewRequest(http.MethodGet, "/version", nil))
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.Len(t, resp.Namespaces, 1)
+		require.Len(t, resp.Namespaces[0].Protocols, 1)
+		require.Equal(t, uint64(100), resp.Namespaces[0].Protocols[0].GenesisTime)
+	})
+
+	t.Run("error - protocol unavailable", func(t *testing.T) {
+		pc := mocks.NewMockProtocolClient()
+		pc.Err = errors.New("injected protocol error")
+
+		rw := httptest.NewRecorder()
+		NewHandler("/version", &Namespace{Namespace: namespace, ProtocolClient: pc}).
+			Handler()(rw, httptest.NewRequest(http.MethodGet, "/version", nil))
+		require.Equal(t, http.StatusServiceUnavailable, rw.Code)
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+		require.Equal(t, common.ErrorCodeProtocolUnavailable, problem.Code)
+		require.Equal(t, "Service Unavailable", problem.Detail)
+	})
+}
+
+func newMockProtocolClientWithUpgrade() *mocks.MockProtocolClient {
+	pc := mocks.NewMockProtocolClient()
+
+	latest := pc.Protocol
+	latest.GenesisTime = 100
+	latest.HashAlgorithm = 7
+	latest.Patches = []string{"replace"}
+
+	latestVersion := mocks.GetProtocolVersion(latest)
+	latestVersion.VersionReturns("0.2")
+
+	pc.Protocol = latest
+	pc.CurrentVersion = latestVersion
+	pc.Versions = append(pc.Versions, latestVersion)
+
+	return pc
+}
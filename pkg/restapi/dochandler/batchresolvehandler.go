@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+// DefaultMaxBatchResolutionSize is the default maximum number of DIDs that may be resolved in a single request.
+const DefaultMaxBatchResolutionSize = 100
+
+// BatchResolver resolves multiple documents.
+type BatchResolver interface {
+	ResolveDocuments(ids []string) []*document.DIDResolution
+}
+
+// BatchResolutionRequest contains the DIDs to be resolved.
+type BatchResolutionRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BatchResolutionResponse contains a resolution entry for each of the requested DIDs (in the order of the request).
+type BatchResolutionResponse struct {
+	Resolutions []*BatchResolutionEntry `json:"resolutions"`
+}
+
+// BatchResolutionEntry contains either the resolution result or the error for a single DID.
+type BatchResolutionEntry struct {
+	ID     string                     `json:"id"`
+	Result *document.ResolutionResult `json:"result,omitempty"`
+	Error  *common.ProblemDetails     `json:"error,omitempty"`
+}
+
+// BatchResolveHandler resolves multiple documents in a single request.
+type BatchResolveHandler struct {
+	resolver BatchResolver
+	maxSize  int
+}
+
+// NewBatchResolveHandler returns a new batch resolve handler. The maximum number of DIDs per request
+// defaults to DefaultMaxBatchResolutionSize if maxSize is not positive.
+func NewBatchResolveHandler(resolver BatchResolver, maxSize int) *BatchResolveHandler {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxBatchResolutionSize
+	}
+
+	return &BatchResolveHandler{
+		resolver: resolver,
+		maxSize:  maxSize,
+	}
+}
+
+// Resolve resolves the documents for the DIDs in the request.
+func (o *BatchResolveHandler) Resolve(rw http.ResponseWriter, req *http.Request) {
+	reqBytes, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, err)
+
+		return
+	}
+
+	request := &BatchResolutionRequest{}
+
+	err = json.Unmarshal(reqBytes, request)
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, fmt.Errorf("invalid batch resolution request: %s", err.Error()))
+
+		return
+	}
+
+	if len(request.IDs) == 0 {
+		common.WriteError(rw, http.StatusBadRequest, fmt.Errorf("missing ids in batch resolution request"))
+
+		return
+	}
+
+	if len(request.IDs) > o.maxSize {
+		common.WriteError(rw, http.StatusBadRequest,
+			fmt.Errorf("number of ids [%d] exceeds maximum batch resolution size [%d]", len(request.IDs), o.maxSize))
+
+		return
+	}
+
+	logger.Debugf("Resolving DID documents for %d IDs", len(request.IDs))
+
+	response := &BatchResolutionResponse{}
+
+	for _, resolution := range o.resolver.ResolveDocuments(request.IDs) {
+		response.Resolutions = append(response.Resolutions, newBatchResolutionEntry(resolution))
+	}
+
+	common.WriteResponse(rw, http.StatusOK, response)
+}
+
+func newBatchResolutionEntry(resolution *document.DIDResolution) *BatchResolutionEntry {
+	if resolution.Err != nil {
+		httpErr := common.MapError(resolution.Err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error resolving [%s]:  %s", resolution.ID, resolution.Err.Error())
+		}
+
+		return &BatchResolutionEntry{
+			ID:    resolution.ID,
+			Error: common.NewProblemDetails(httpErr.Status(), httpErr),
+		}
+	}
+
+	return &BatchResolutionEntry{
+		ID:     resolution.ID,
+		Result: resolution.Result,
+	}
+}
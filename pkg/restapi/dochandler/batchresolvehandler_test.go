@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+func TestBatchResolveHandler_Resolve(t *testing.T) {
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace)
+
+	create, err := getCreateRequest()
+	require.NoError(t, err)
+
+	createBytes, err := canonicalizer.MarshalCanonical(create)
+	require.NoError(t, err)
+
+	result, err := docHandler.ProcessOperation(createBytes, 0)
+	require.NoError(t, err)
+
+	handler := NewBatchResolveHandler(docHandler, 0)
+	require.Equal(t, DefaultMaxBatchResolutionSize, handler.maxSize)
+
+	t.Run("Success", func(t *testing.T) {
+		notFoundID := namespace + docutil.NamespaceDelimiter + "someid"
+
+		reqBytes, err := json.Marshal(&BatchResolutionRequest{
+			IDs: []string{result.Document.ID(), "someid", notFoundID},
+		})
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/identifiers/resolve", bytes.NewReader(reqBytes))
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, "application/did+ld+json", rw.Header().Get("content-type"))
+
+		var response BatchResolutionResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Resolutions, 3)
+
+		require.Equal(t, result.Document.ID(), response.Resolutions[0].ID)
+		require.NotNil(t, response.Resolutions[0].Result)
+		require.Nil(t, response.Resolutions[0].Error)
+		require.Equal(t, result.Document.ID(), response.Resolutions[0].Result.Document.ID())
+
+		require.Equal(t, "someid", response.Resolutions[1].ID)
+		require.Nil(t, response.Resolutions[1].Result)
+		require.Equal(t, http.StatusBadRequest, response.Resolutions[1].Error.Status)
+		require.Equal(t, common.ErrorCodeInvalidDID, response.Resolutions[1].Error.Code)
+
+		require.Equal(t, notFoundID, response.Resolutions[2].ID)
+		require.Equal(t, http.StatusNotFound, response.Resolutions[2].Error.Status)
+		require.Equal(t, common.ErrorCodeNotFound, response.Resolutions[2].Error.Code)
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		handler := NewBatchResolveHandler(mocks.NewMockDocumentHandler().WithNamespace(namespace).
+			WithError(errors.New("injected error")), 10)
+
+		reqBytes, err := json.Marshal(&BatchResolutionRequest{IDs: []string{result.Document.ID()}})
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/identifiers/resolve", bytes.NewReader(reqBytes))
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var response BatchResolutionResponse
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &response))
+		require.Len(t, response.Resolutions, 1)
+		require.Equal(t, common.ErrorCodeInternal, response.Resolutions[0].Error.Code)
+		require.Equal(t, "Internal Server Error", response.Resolutions[0].Error.Detail)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/identifiers/resolve", bytes.NewReader([]byte("{")))
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "invalid batch resolution request")
+	})
+
+	t.Run("Missing IDs", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/identifiers/resolve", bytes.NewReader([]byte(`{"ids":[]}`)))
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "missing ids")
+	})
+
+	t.Run("Too many IDs", func(t *testing.T) {
+		handler := NewBatchResolveHandler(docHandler, 1)
+
+		reqBytes, err := json.Marshal(&BatchResolutionRequest{IDs: []string{"id1", "id2"}})
+		require.NoError(t, err)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/identifiers/resolve", bytes.NewReader(reqBytes))
+		handler.Resolve(rw, req)
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "exceeds maximum batch resolution size")
+	})
+}
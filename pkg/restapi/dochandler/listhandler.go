@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	// DefaultListLimit is the default maximum number of identifiers returned in a single page.
+	DefaultListLimit = 100
+
+	// MaxListLimit is the maximum value of the 'limit' query parameter.
+	MaxListLimit = 1000
+
+	createdAfterParam = "createdAfter"
+	typeParam         = "type"
+	limitParam        = "limit"
+	cursorParam       = "cursor"
+)
+
+// OperationIndex queries anchored operations.
+type OperationIndex interface {
+	Query(query *store.Query) (*store.QueryResult, error)
+}
+
+// IdentifierList contains a page of identifiers.
+type IdentifierList struct {
+	Identifiers []*IdentifierEntry `json:"identifiers"`
+
+	// Cursor is passed in the 'cursor' query parameter in order to retrieve the next page.
+	// It is empty if there are no more identifiers.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// IdentifierEntry contains an identifier along with the operation which matched the query.
+type IdentifierEntry struct {
+	ID              string         `json:"id"`
+	OperationType   operation.Type `json:"operationType"`
+	TransactionTime uint64         `json:"transactionTime"`
+	AnchorString    string         `json:"anchorString,omitempty"`
+}
+
+// ListHandler lists the identifiers known to the node.
+type ListHandler struct {
+	namespace string
+	index     OperationIndex
+}
+
+// NewListHandler returns a new identifier list handler.
+func NewListHandler(namespace string, index OperationIndex) *ListHandler {
+	return &ListHandler{
+		namespace: namespace,
+		index:     index,
+	}
+}
+
+// List lists the identifiers which have an operation of the given type (defaults to create) anchored after
+// the given transaction time (query parameters 'type' and 'createdAfter'). An identifier appears once for
+// each matching operation, ordered by transaction time. Results are paged using the 'limit' and 'cursor'
+// query parameters.
+func (o *ListHandler) List(rw http.ResponseWriter, req *http.Request) {
+	query, err := getQuery(req.URL.Query())
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, common.MapError(err))
+
+		return
+	}
+
+	logger.Debugf("Listing identifiers for query: %+v", query)
+
+	result, err := o.index.Query(query)
+	if err != nil {
+		httpErr := common.MapError(err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error listing identifiers:  %s", err.Error())
+		}
+
+		common.WriteError(rw, httpErr.Status(), httpErr)
+
+		return
+	}
+
+	response := &IdentifierList{
+		Identifiers: []*IdentifierEntry{},
+		Cursor:      result.Cursor,
+	}
+
+	for _, op := range result.Operations {
+		response.Identifiers = append(response.Identifiers, &IdentifierEntry{
+			ID:              o.namespace + docutil.NamespaceDelimiter + op.UniqueSuffix,
+			OperationType:   op.Type,
+			TransactionTime: op.TransactionTime,
+			AnchorString:    op.AnchorString,
+		})
+	}
+
+	common.WriteResponse(rw, http.StatusOK, response)
+}
+
+func getQuery(params url.Values) (*store.Query, error) {
+	query := &store.Query{
+		OperationType: operation.TypeCreate,
+		Limit:         DefaultListLimit,
+		Cursor:        params.Get(cursorParam),
+	}
+
+	if opType := params.Get(typeParam); opType != "" {
+		switch t := operation.Type(opType); t {
+		case operation.TypeCreate, operation.TypeUpdate, operation.TypeRecover, operation.TypeDeactivate:
+			query.OperationType = t
+		default:
+			return nil, fmt.Errorf("%w: unsupported operation type [%s]", store.ErrInvalidQuery, opType)
+		}
+	}
+
+	if createdAfter := params.Get(createdAfterParam); createdAfter != "" {
+		t, err := strconv.ParseUint(createdAfter, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid value for '%s': %s", store.ErrInvalidQuery, createdAfterParam, createdAfter)
+		}
+
+		query.AnchoredAfter = t
+	}
+
+	if limit := params.Get(limitParam); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l <= 0 || l > MaxListLimit {
+			return nil, fmt.Errorf("%w: '%s' must be between 1 and %d", store.ErrInvalidQuery, limitParam, MaxListLimit)
+		}
+
+		query.Limit = l
+	}
+
+	return query, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/opstore"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+func TestListHandler_List(t *testing.T) {
+	opStore := opstore.New(opstore.NewMemBackend())
+	require.NoError(t, opStore.Put([]*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: "suffix1", TransactionTime: 10, AnchorString: "1.anchor1"},
+		{Type: operation.TypeCreate, UniqueSuffix: "suffix2", TransactionTime: 10, OperationIndex: 1, AnchorString: "1.anchor1"},
+		{Type: operation.TypeDeactivate, UniqueSuffix: "suffix1", TransactionTime: 11, AnchorString: "1.anchor2"},
+		{Type: operation.TypeCreate, UniqueSuffix: "suffix3", TransactionTime: 12, AnchorString: "1.anchor3"},
+	}))
+
+	handler := NewListHandler(namespace, opStore)
+
+	t.Run("Created after", func(t *testing.T) {
+		response := list(t, handler, url.Values{"createdAfter": {"10"}})
+		require.Len(t, response.Identifiers, 1)
+		require.Equal(t, &IdentifierEntry{
+			ID:              namespace + ":suffix3",
+			OperationType:   operation.TypeCreate,
+			TransactionTime: 12,
+			AnchorString:    "1.anchor3",
+		}, response.Identifiers[0])
+		require.Empty(t, response.Cursor)
+
+		response = list(t, handler, url.Values{"createdAfter": {"12"}})
+		require.NotNil(t, response.Identifiers)
+		require.Empty(t, response.Identifiers)
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		response := list(t, handler, url.Values{"type": {"deactivate"}})
+		require.Len(t, response.Identifiers, 1)
+		require.Equal(t, namespace+":suffix1", response.Identifiers[0].ID)
+		require.Equal(t, operation.TypeDeactivate, response.Identifiers[0].OperationType)
+	})
+
+	t.Run("Paging", func(t *testing.T) {
+		var ids []string
+
+		params := url.Values{"limit": {"2"}}
+
+		for {
+			response := list(t, handler, params)
+			for _, entry := range response.Identifiers {
+				ids = append(ids, entry.ID)
+			}
+
+			if response.Cursor == "" {
+				break
+			}
+
+			params.Set("cursor", response.Cursor)
+		}
+
+		require.Equal(t, []string{namespace + ":suffix1", namespace + ":suffix2", namespace + ":suffix3"}, ids)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		for _, params := range []url.Values{
+			{"type": {"invalid"}},
+			{"createdAfter": {"yesterday"}},
+			{"limit": {"0"}},
+			{"limit": {"1001"}},
+			{"cursor": {"invalid"}},
+		} {
+			rw := httptest.NewRecorder()
+			handler.List(rw, httptest.NewRequest(http.MethodGet, "/identifiers?"+params.Encode(), nil))
+			require.Equal(t, http.StatusBadRequest, rw.Code, params.Encode())
+
+			var problem common.ProblemDetails
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+			require.Equal(t, common.ErrorCodeInvalidQuery, problem.Code)
+		}
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		handler := NewListHandler(namespace, &mockOperationIndex{err: errors.New("injected error")})
+
+		rw := httptest.NewRecorder()
+		handler.List(rw, httptest.NewRequest(http.MethodGet, "/identifiers", nil))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.NotContains(t, rw.Body.String(), "injected error")
+	})
+}
+
+func list(t *testing.T, handler *ListHandler, params url.Values) *IdentifierList {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	handler.List(rw, httptest.NewRequest(http.MethodGet, "/identifiers?"+params.Encode(), nil))
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+	response := &IdentifierList{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), response))
+
+	return response
+}
+
+type mockOperationIndex struct {
+	err error
+}
+
+func (m *mockOperationIndex) Query(*store.Query) (*store.QueryResult, error) {
+	return nil, m.err
+}
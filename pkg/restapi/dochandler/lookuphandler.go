@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
+	"github.com/trustbloc/sidetree-core-go/pkg/docindex"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+// DIDFinder finds the DIDs of the documents which contain a given property value.
+type DIDFinder interface {
+	FindDIDs(property docindex.Property, value string) ([]string, error)
+}
+
+// LookupResponse contains the DIDs which were found.
+type LookupResponse struct {
+	IDs []string `json:"ids"`
+}
+
+// LookupHandler finds DIDs by JWK thumbprint, key ID or service endpoint.
+type LookupHandler struct {
+	finder DIDFinder
+}
+
+// NewLookupHandler returns a new DID lookup handler.
+func NewLookupHandler(finder DIDFinder) *LookupHandler {
+	return &LookupHandler{
+		finder: finder,
+	}
+}
+
+// Lookup finds the DIDs of the documents which contain the property value given in the query. Exactly one
+// of the query parameters 'jwkThumbprint', 'keyId' or 'serviceEndpoint' must be specified.
+func (o *LookupHandler) Lookup(rw http.ResponseWriter, req *http.Request) {
+	property, value, err := getLookupQuery(req)
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, common.MapError(err))
+
+		return
+	}
+
+	logger.Debugf("Looking up DIDs for %s [%s]", property, value)
+
+	ids, err := o.finder.FindDIDs(property, value)
+	if err != nil {
+		httpErr := common.MapError(err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error looking up DIDs:  %s", err.Error())
+		}
+
+		common.WriteError(rw, httpErr.Status(), httpErr)
+
+		return
+	}
+
+	if ids == nil {
+		ids = []string{}
+	}
+
+	common.WriteResponse(rw, http.StatusOK, &LookupResponse{IDs: ids})
+}
+
+func getLookupQuery(req *http.Request) (docindex.Property, string, error) {
+	params := req.URL.Query()
+
+	var (
+		property docindex.Property
+		value    string
+	)
+
+	for _, p := range docindex.Properties {
+		v := params.Get(string(p))
+		if v == "" {
+			continue
+		}
+
+		if property != "" {
+			return "", "", fmt.Errorf("%w: only one of %v may be specified", store.ErrInvalidQuery, docindex.Properties)
+		}
+
+		property, value = p, v
+	}
+
+	if property == "" {
+		return "", "", fmt.Errorf("%w: one of %v must be specified", store.ErrInvalidQuery, docindex.Properties)
+	}
+
+	return property, value, nil
+}
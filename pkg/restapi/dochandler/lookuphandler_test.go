@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dochandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/canonicalizer"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+func TestLookupHandler_Lookup(t *testing.T) {
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace)
+
+	create, err := getCreateRequest()
+	require.NoError(t, err)
+
+	createBytes, err := canonicalizer.MarshalCanonical(create)
+	require.NoError(t, err)
+
+	result, err := docHandler.ProcessOperation(createBytes, 0)
+	require.NoError(t, err)
+
+	handler := NewLookupHandler(docHandler)
+
+	t.Run("Success", func(t *testing.T) {
+		response := lookup(t, handler, url.Values{"keyId": {"key1"}})
+		require.Equal(t, []string{result.Document.ID()}, response.IDs)
+
+		response = lookup(t, handler, url.Values{"serviceEndpoint": {"https://unknown.example.com"}})
+		require.NotNil(t, response.IDs)
+		require.Empty(t, response.IDs)
+	})
+
+	t.Run("Invalid query", func(t *testing.T) {
+		for _, params := range []url.Values{
+			{},
+			{"keyId": {"key1"}, "jwkThumbprint": {"abc"}},
+		} {
+			rw := httptest.NewRecorder()
+			handler.Lookup(rw, httptest.NewRequest(http.MethodGet, "/lookup?"+params.Encode(), nil))
+			require.Equal(t, http.StatusBadRequest, rw.Code)
+
+			var problem common.ProblemDetails
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+			require.Equal(t, common.ErrorCodeInvalidQuery, problem.Code)
+		}
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		handler := NewLookupHandler(mocks.NewMockDocumentHandler().WithError(errors.New("injected error")))
+
+		rw := httptest.NewRecorder()
+		handler.Lookup(rw, httptest.NewRequest(http.MethodGet, "/lookup?keyId=key1", nil))
+		require.Equal(t, http.StatusInternalServerError, rw.Code)
+		require.NotContains(t, rw.Body.String(), "injected error")
+	})
+}
+
+func lookup(t *testing.T, handler *LookupHandler, params url.Values) *LookupResponse {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	handler.Lookup(rw, httptest.NewRequest(http.MethodGet, "/lookup?"+params.Encode(), nil))
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+	response := &LookupResponse{}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), response))
+
+	return response
+}
+
+var _ DIDFinder = (*mocks.MockDocumentHandler)(nil)
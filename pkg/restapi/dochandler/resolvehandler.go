@@ -8,10 +8,8 @@ package dochandler
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/gorilla/mux"
-	"github.com/pkg/errors"
 	"github.com/trustbloc/edge-core/pkg/log"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
@@ -54,19 +52,12 @@ func (o *ResolveHandler) Resolve(rw http.ResponseWriter, req *http.Request) {
 func (o *ResolveHandler) doResolve(id string) (*document.ResolutionResult, error) {
 	doc, err := o.resolver.ResolveDocument(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "bad request") {
-			return nil, common.NewHTTPError(http.StatusBadRequest, err)
+		httpErr := common.MapError(err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error:  %s", err.Error())
 		}
-		if strings.Contains(err.Error(), "not found") {
-			return nil, common.NewHTTPError(http.StatusNotFound, errors.New("document not found"))
-		}
-		if strings.Contains(err.Error(), "was deactivated") {
-			return nil, common.NewHTTPError(http.StatusGone, errors.New("document is no longer available"))
-		}
-
-		logger.Errorf("internal server error:  %s", err.Error())
 
-		return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+		return nil, httpErr
 	}
 
 	return doc, nil
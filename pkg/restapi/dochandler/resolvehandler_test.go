@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package dochandler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
@@ -81,6 +83,10 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/document", nil)
 		handler.Resolve(rw, req)
 		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+		require.Equal(t, common.ErrorCodeInvalidDID, problem.Code)
 	})
 	t.Run("Not found", func(t *testing.T) {
 		getID = func(req *http.Request) string {
@@ -93,6 +99,12 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/document", nil)
 		handler.Resolve(rw, req)
 		require.Equal(t, http.StatusNotFound, rw.Code)
+		require.Equal(t, "application/problem+json", rw.Header().Get("content-type"))
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+		require.Equal(t, common.ErrorCodeNotFound, problem.Code)
+		require.Equal(t, "document not found", problem.Detail)
 	})
 	t.Run("Error", func(t *testing.T) {
 		getID = func(req *http.Request) string {
@@ -106,7 +118,7 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/document", nil)
 		handler.Resolve(rw, req)
 		require.Equal(t, http.StatusInternalServerError, rw.Code)
-		require.Contains(t, rw.Body.String(), errExpected.Error())
+		require.NotContains(t, rw.Body.String(), errExpected.Error())
 	})
 	t.Run("Document is no longer available", func(t *testing.T) {
 		docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace)
@@ -141,6 +153,10 @@ func TestResolveHandler_Resolve(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/document", nil)
 		handler.Resolve(rw, req)
 		require.Equal(t, http.StatusGone, rw.Code)
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+		require.Equal(t, common.ErrorCodeDeactivated, problem.Code)
 	})
 }
 
@@ -7,12 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package dochandler
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
 	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 )
 
@@ -24,20 +25,57 @@ type Processor interface {
 
 // UpdateHandler handles the creation and update of documents.
 type UpdateHandler struct {
-	processor Processor
-	protocol  protocol.Client
+	processor     Processor
+	protocol      protocol.Client
+	authenticator auth.Authenticator
+	authorizer    auth.Authorizer
+}
+
+// UpdateOption is an update handler option.
+type UpdateOption func(opts *UpdateHandler)
+
+// WithAuthenticator requires requests to be authenticated by the given authenticator.
+func WithAuthenticator(a auth.Authenticator) UpdateOption {
+	return func(opts *UpdateHandler) {
+		opts.authenticator = a
+	}
+}
+
+// WithAuthorizer requires operations to be permitted by the given authorizer. The authorizer is passed
+// the principal returned by the authenticator (or nil if no authenticator is configured).
+func WithAuthorizer(a auth.Authorizer) UpdateOption {
+	return func(opts *UpdateHandler) {
+		opts.authorizer = a
+	}
 }
 
 // NewUpdateHandler returns a new document update handler.
-func NewUpdateHandler(processor Processor, pc protocol.Client) *UpdateHandler {
-	return &UpdateHandler{
+func NewUpdateHandler(processor Processor, pc protocol.Client, opts ...UpdateOption) *UpdateHandler {
+	h := &UpdateHandler{
 		processor: processor,
 		protocol:  pc,
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
 // Update creates or updates a document.
 func (h *UpdateHandler) Update(rw http.ResponseWriter, req *http.Request) {
+	principal, err := h.authenticate(req)
+	if err != nil {
+		if challenge := auth.Challenge(h.authenticator); challenge != "" {
+			rw.Header().Set("WWW-Authenticate", challenge)
+		}
+
+		writeError(rw, err)
+
+		return
+	}
+
 	request, err := ioutil.ReadAll(req.Body)
 	if err != nil {
 		common.WriteError(rw, http.StatusBadRequest, err)
@@ -45,6 +83,13 @@ func (h *UpdateHandler) Update(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	err = h.authorize(principal, request)
+	if err != nil {
+		writeError(rw, err)
+
+		return
+	}
+
 	response, err := h.doUpdate(request)
 	if err != nil {
 		common.WriteError(rw, err.(*common.HTTPError).Status(), err)
@@ -57,22 +102,64 @@ func (h *UpdateHandler) Update(rw http.ResponseWriter, req *http.Request) {
 func (h *UpdateHandler) doUpdate(operation []byte) (*document.ResolutionResult, error) {
 	currentProtocol, err := h.protocol.Current()
 	if err != nil {
-		return nil, err
+		logger.Errorf("unable to retrieve current protocol: %s", err.Error())
+
+		return nil, common.MapError(fmt.Errorf("%w: %s", protocol.ErrUnavailable, err.Error()))
 	}
 
 	// operation has been validated, now process it
 	result, err := h.processor.ProcessOperation(operation, currentProtocol.Protocol().GenesisTime)
 	if err != nil {
-		if strings.Contains(err.Error(), "bad request") {
+		httpErr := common.MapError(err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error:  %s", err.Error())
+		} else {
 			logger.Warnf("operation validation error: %s", err.Error())
-
-			return nil, common.NewHTTPError(http.StatusBadRequest, err)
 		}
 
-		logger.Errorf("internal server error:  %s", err.Error())
-
-		return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+		return nil, httpErr
 	}
 
 	return result, nil
 }
+
+func (h *UpdateHandler) authenticate(req *http.Request) (*auth.Principal, error) {
+	if h.authenticator == nil {
+		return nil, nil
+	}
+
+	principal, err := h.authenticator.Authenticate(req)
+	if err != nil {
+		logger.Warnf("authentication failed: %s", err.Error())
+
+		return nil, err
+	}
+
+	return principal, nil
+}
+
+func (h *UpdateHandler) authorize(principal *auth.Principal, request []byte) error {
+	if h.authorizer == nil {
+		return nil
+	}
+
+	op, err := auth.ParseOperation(h.processor.Namespace(), request)
+	if err != nil {
+		return err
+	}
+
+	err = h.authorizer.Authorize(principal, op)
+	if err != nil {
+		logger.Warnf("authorization failed: %s", err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func writeError(rw http.ResponseWriter, err error) {
+	httpErr := common.MapError(err)
+
+	common.WriteError(rw, httpErr.Status(), httpErr)
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
@@ -117,6 +119,10 @@ func TestUpdateHandler_Update(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/document", bytes.NewReader(getUnsupportedRequest()))
 		handler.Update(rw, req)
 		require.Equal(t, http.StatusBadRequest, rw.Code)
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+		require.Equal(t, common.ErrorCodeInvalidOperation, problem.Code)
 	})
 	t.Run("Bad Request", func(t *testing.T) {
 		rw := httptest.NewRecorder()
@@ -133,7 +139,127 @@ func TestUpdateHandler_Update(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/document", bytes.NewReader(create))
 		handler.Update(rw, req)
 		require.Equal(t, http.StatusInternalServerError, rw.Code)
-		require.Contains(t, rw.Body.String(), errExpected.Error())
+		require.NotContains(t, rw.Body.String(), errExpected.Error())
+	})
+	t.Run("Protocol error", func(t *testing.T) {
+		pcWithErr := newMockProtocolClient()
+		pcWithErr.Err = errors.New("injected protocol error")
+		handler := NewUpdateHandler(docHandler, pcWithErr)
+
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/document", bytes.NewReader(create))
+		handler.Update(rw, req)
+		require.Equal(t, http.StatusServiceUnavailable, rw.Code)
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+		require.Equal(t, common.ErrorCodeProtocolUnavailable, problem.Code)
+	})
+}
+
+func TestUpdateHandler_Auth(t *testing.T) {
+	pc := newMockProtocolClient()
+	docHandler := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	req, err := getCreateRequestInfo()
+	require.NoError(t, err)
+
+	create, err := client.NewCreateRequest(req)
+	require.NoError(t, err)
+
+	authenticator := auth.NewTokenAuthenticator(map[string]*auth.Principal{
+		"token1": {ID: "alice", Tenant: "tenant1"},
+		"token2": {ID: "bob", Tenant: "tenant2"},
+	})
+
+	handler := NewUpdateHandler(docHandler, pc,
+		WithAuthenticator(authenticator),
+		WithAuthorizer(auth.NewTenantPolicy(map[string][]operation.Type{
+			"tenant1": {operation.TypeCreate},
+		})),
+	)
+
+	doUpdate := func(handler *UpdateHandler, token string, body []byte) (*httptest.ResponseRecorder, *common.ProblemDetails) {
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/document", bytes.NewReader(body))
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		handler.Update(rw, req)
+
+		if rw.Code == http.StatusOK {
+			return rw, nil
+		}
+
+		var problem common.ProblemDetails
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &problem))
+
+		return rw, &problem
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rw, _ := doUpdate(handler, "token1", create)
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		rw, problem := doUpdate(handler, "", create)
+		require.Equal(t, http.StatusUnauthorized, rw.Code)
+		require.Equal(t, common.ErrorCodeUnauthorized, problem.Code)
+		require.Equal(t, "Bearer", rw.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		rw, problem := doUpdate(handler, "token3", create)
+		require.Equal(t, http.StatusUnauthorized, rw.Code)
+		require.Equal(t, common.ErrorCodeUnauthorized, problem.Code)
+	})
+
+	t.Run("tenant not permitted", func(t *testing.T) {
+		rw, problem := doUpdate(handler, "token2", create)
+		require.Equal(t, http.StatusForbidden, rw.Code)
+		require.Equal(t, common.ErrorCodeForbidden, problem.Code)
+	})
+
+	t.Run("operation type not permitted", func(t *testing.T) {
+		deactivate, err := client.NewDeactivateRequest(getDeactivateRequestInfo("did:sidetree:abc"))
+		require.NoError(t, err)
+
+		rw, problem := doUpdate(handler, "token1", deactivate)
+		require.Equal(t, http.StatusForbidden, rw.Code)
+		require.Equal(t, common.ErrorCodeForbidden, problem.Code)
+		require.Contains(t, problem.Detail, "may not submit deactivate operations")
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		rw, problem := doUpdate(handler, "token1", []byte(badRequest))
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Equal(t, common.ErrorCodeInvalidOperation, problem.Code)
+	})
+
+	t.Run("authorizer without authenticator", func(t *testing.T) {
+		var authorized *auth.Operation
+
+		handler := NewUpdateHandler(docHandler, pc,
+			WithAuthorizer(auth.AuthorizerFunc(func(principal *auth.Principal, op *auth.Operation) error {
+				require.Nil(t, principal)
+				authorized = op
+
+				return nil
+			})),
+		)
+
+		deactivate, err := client.NewDeactivateRequest(getDeactivateRequestInfo("did:sidetree:abc"))
+		require.NoError(t, err)
+
+		doUpdate(handler, "", deactivate)
+		require.Equal(t, &auth.Operation{
+			Namespace:    namespace,
+			Type:         operation.TypeDeactivate,
+			UniqueSuffix: "did:sidetree:abc",
+		}, authorized)
 	})
 }
 
@@ -293,9 +419,16 @@ const recoverDoc = `{
 
 func newMockProtocolClient() *mocks.MockProtocolClient {
 	pc := mocks.NewMockProtocolClient()
-	parser := operationparser.New(pc.Protocol)
+	parser, err := operationparser.New(pc.Protocol)
+	if err != nil {
+		panic(err)
+	}
+
 	dc := doccomposer.New()
-	oa := operationapplier.New(pc.Protocol, parser, dc)
+	oa, err := operationapplier.New(pc.Protocol, parser, dc)
+	if err != nil {
+		panic(err)
+	}
 
 	pv := pc.CurrentVersion
 	pv.OperationParserReturns(parser)
@@ -0,0 +1,257 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eventstream provides a server-sent events (SSE) endpoint which streams DID events to subscribers.
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/events"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+var logger = log.New("sidetree-core-restapi-eventstream")
+
+const (
+	didParam  = "did"
+	typeParam = "type"
+
+	defaultBufferSize = 100
+	defaultHeartbeat  = 30 * time.Second
+)
+
+// Handler streams DID events to subscribers using server-sent events. Each event is sent with the event ID,
+// the event type (created, updated, recovered or deactivated) and the JSON encoded event as data. Subscribers
+// may filter the events using the 'did' and 'type' query parameters (which may be repeated).
+//
+// The handler is also an events.Sink. Subscribers which don't keep up with the published events are disconnected.
+type Handler struct {
+	path       string
+	bufferSize int
+	heartbeat  time.Duration
+
+	mutex       sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	filter *events.Filter
+	ch     chan *events.Event
+}
+
+// Option is an event stream handler option.
+type Option func(opts *Handler)
+
+// WithBufferSize sets the maximum number of pending events per subscriber.
+func WithBufferSize(size int) Option {
+	return func(opts *Handler) {
+		opts.bufferSize = size
+	}
+}
+
+// WithHeartbeat sets the interval at which a comment is sent to idle subscribers in order to keep
+// the connection open.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(opts *Handler) {
+		opts.heartbeat = interval
+	}
+}
+
+// New returns a new event stream handler.
+func New(path string, opts ...Option) *Handler {
+	h := &Handler{
+		path:        path,
+		bufferSize:  defaultBufferSize,
+		heartbeat:   defaultHeartbeat,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Path returns the context path.
+func (h *Handler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the handler.
+func (h *Handler) Handler() common.HTTPRequestHandler {
+	return h.stream
+}
+
+// Publish sends the events to the subscribers whose filter matches the events.
+func (h *Handler) Publish(evts ...*events.Event) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for sub := range h.subscribers {
+		h.send(sub, sub.filter.Apply(evts))
+	}
+
+	return nil
+}
+
+// send sends the events to the subscriber. The subscriber is removed (and no more events are sent to it)
+// if its buffer is full. The caller must hold the lock.
+func (h *Handler) send(sub *subscriber, evts []*events.Event) {
+	for _, e := range evts {
+		select {
+		case sub.ch <- e:
+		default:
+			logger.Warnf("Event stream subscriber is too slow. Disconnecting.")
+
+			h.remove(sub)
+
+			return
+		}
+	}
+}
+
+// Close disconnects all subscribers.
+func (h *Handler) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+// Subscribers returns the number of connected subscribers.
+func (h *Handler) Subscribers() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return len(h.subscribers)
+}
+
+func (h *Handler) stream(rw http.ResponseWriter, req *http.Request) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		common.WriteError(rw, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+
+		return
+	}
+
+	filter, err := getFilter(req)
+	if err != nil {
+		common.WriteError(rw, http.StatusBadRequest, err)
+
+		return
+	}
+
+	sub := h.subscribe(filter)
+	defer h.unsubscribe(sub)
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	logger.Debugf("Event stream subscriber connected with filter %+v", filter)
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			logger.Debugf("Event stream subscriber disconnected")
+
+			return
+		case e, ok := <-sub.ch:
+			if !ok {
+				return
+			}
+
+			if err := writeEvent(rw, e); err != nil {
+				logger.Warnf("Failed to write event to subscriber: %s", err)
+
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(rw, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+func (h *Handler) subscribe(filter *events.Filter) *subscriber {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	sub := &subscriber{
+		filter: filter,
+		ch:     make(chan *events.Event, h.bufferSize),
+	}
+
+	h.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+func (h *Handler) unsubscribe(sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		h.remove(sub)
+	}
+}
+
+// remove removes the subscriber and closes its channel. The mutex must be held.
+func (h *Handler) remove(sub *subscriber) {
+	delete(h.subscribers, sub)
+	close(sub.ch)
+}
+
+func writeEvent(rw http.ResponseWriter, e *events.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(rw, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+
+	return err
+}
+
+func getFilter(req *http.Request) (*events.Filter, error) {
+	params := req.URL.Query()
+
+	filter := &events.Filter{DIDs: params[didParam]}
+
+	for _, t := range params[typeParam] {
+		switch eventType := events.Type(t); eventType {
+		case events.TypeCreated, events.TypeUpdated, events.TypeRecovered, events.TypeDeactivated:
+			filter.Types = append(filter.Types, eventType)
+		default:
+			return nil, fmt.Errorf("unsupported event type [%s]", t)
+		}
+	}
+
+	return filter, nil
+}
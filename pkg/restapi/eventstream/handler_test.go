@@ -0,0 +1,225 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/events"
+)
+
+const path = "/events"
+
+func TestHandler(t *testing.T) {
+	h := New(path)
+	require.Equal(t, path, h.Path())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.NotNil(t, h.Handler())
+}
+
+func TestHandler_Stream(t *testing.T) {
+	e1 := &events.Event{ID: "1.0.0", Type: events.TypeCreated, DID: "did:sidetree:1", UniqueSuffix: "1"}
+	e2 := &events.Event{ID: "1.0.1", Type: events.TypeUpdated, DID: "did:sidetree:2", UniqueSuffix: "2"}
+	e3 := &events.Event{ID: "1.0.2", Type: events.TypeDeactivated, DID: "did:sidetree:1", UniqueSuffix: "1"}
+
+	t.Run("success", func(t *testing.T) {
+		h := New(path)
+		srv := httptest.NewServer(http.HandlerFunc(h.Handler()))
+		defer srv.Close()
+
+		resp, reader := connect(t, h, srv.URL)
+		defer resp.Body.Close()
+
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+		require.NoError(t, h.Publish(e1, e2))
+
+		require.Equal(t, e1, readEvent(t, reader))
+		require.Equal(t, e2, readEvent(t, reader))
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		h := New(path)
+		srv := httptest.NewServer(http.HandlerFunc(h.Handler()))
+		defer srv.Close()
+
+		resp, reader := connect(t, h, srv.URL+"?did=did:sidetree:1&type=deactivated&type=updated")
+		defer resp.Body.Close()
+
+		require.NoError(t, h.Publish(e1, e2, e3))
+
+		require.Equal(t, e3, readEvent(t, reader))
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		h := New(path)
+
+		rw := httptest.NewRecorder()
+		h.Handler()(rw, httptest.NewRequest(http.MethodGet, path+"?type=invalid", nil))
+
+		require.Equal(t, http.StatusBadRequest, rw.Code)
+		require.Contains(t, rw.Body.String(), "unsupported event type [invalid]")
+		require.Equal(t, 0, h.Subscribers())
+	})
+
+	t.Run("streaming not supported", func(t *testing.T) {
+		h := New(path)
+
+		rw := &nonFlusher{ResponseWriter: httptest.NewRecorder()}
+		h.Handler()(rw, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, http.StatusInternalServerError, rw.ResponseWriter.(*httptest.ResponseRecorder).Code)
+	})
+
+	t.Run("heartbeat", func(t *testing.T) {
+		h := New(path, WithHeartbeat(10*time.Millisecond))
+		srv := httptest.NewServer(http.HandlerFunc(h.Handler()))
+		defer srv.Close()
+
+		resp, reader := connect(t, h, srv.URL)
+		defer resp.Body.Close()
+
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.Equal(t, ": keep-alive\n", line)
+	})
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		h := New(path, WithBufferSize(1))
+
+		sub := h.subscribe(nil)
+
+		require.NoError(t, h.Publish(e1, e2))
+		require.Equal(t, 0, h.Subscribers())
+
+		e, ok := <-sub.ch
+		require.True(t, ok)
+		require.Equal(t, e1, e)
+
+		_, ok = <-sub.ch
+		require.False(t, ok)
+
+		// unsubscribing a removed subscriber is a no-op
+		h.unsubscribe(sub)
+	})
+
+	t.Run("slow subscriber - more events than buffer", func(t *testing.T) {
+		h := New(path, WithBufferSize(1))
+
+		sub := h.subscribe(nil)
+		other := h.subscribe(nil)
+
+		require.NotPanics(t, func() {
+			require.NoError(t, h.Publish(e1, e2, e1, e2))
+		})
+		require.Equal(t, 0, h.Subscribers())
+
+		for _, ch := range []chan *events.Event{sub.ch, other.ch} {
+			e, ok := <-ch
+			require.True(t, ok)
+			require.Equal(t, e1, e)
+
+			_, ok = <-ch
+			require.False(t, ok)
+		}
+
+		// publishing after all subscribers were removed is a no-op
+		require.NoError(t, h.Publish(e1, e2, e1))
+	})
+
+	t.Run("close", func(t *testing.T) {
+		h := New(path)
+		srv := httptest.NewServer(http.HandlerFunc(h.Handler()))
+		defer srv.Close()
+
+		resp, reader := connect(t, h, srv.URL)
+		defer resp.Body.Close()
+
+		h.Close()
+		require.Equal(t, 0, h.Subscribers())
+
+		_, err := reader.ReadString('\n')
+		require.Error(t, err)
+	})
+
+	t.Run("client disconnect", func(t *testing.T) {
+		h := New(path)
+		srv := httptest.NewServer(http.HandlerFunc(h.Handler()))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		require.Equal(t, 1, h.Subscribers())
+
+		cancel()
+
+		for deadline := time.Now().Add(time.Second); h.Subscribers() > 0; time.Sleep(time.Millisecond) {
+			require.True(t, time.Now().Before(deadline), "subscriber not removed")
+		}
+	})
+}
+
+func connect(t *testing.T, h *Handler, url string) (*http.Response, *bufio.Reader) {
+	t.Helper()
+
+	resp, err := http.Get(url) //nolint:gosec,noctx
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 1, h.Subscribers())
+
+	return resp, bufio.NewReader(resp.Body)
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) *events.Event {
+	t.Helper()
+
+	fields := make(map[string]string)
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			break
+		}
+
+		i := strings.Index(line, ": ")
+		require.True(t, i > 0, "invalid line: %s", line)
+
+		fields[line[:i]] = line[i+2:]
+	}
+
+	e := &events.Event{}
+	require.NoError(t, json.Unmarshal([]byte(fields["data"]), e))
+	require.Equal(t, e.ID, fields["id"])
+	require.Equal(t, string(e.Type), fields["event"])
+
+	return e
+}
+
+type nonFlusher struct {
+	http.ResponseWriter
+}
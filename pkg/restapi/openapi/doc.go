@@ -0,0 +1,12 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package openapi contains the OpenAPI 3 specification (openapi.yml) of the DID document REST API.
+//
+// The specification is maintained by hand. The contract test in this package verifies that every route
+// of the REST handlers is documented, that the schemas match the request and response types and that
+// the responses of the handlers are valid according to the specification.
+package openapi
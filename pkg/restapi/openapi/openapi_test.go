@@ -0,0 +1,446 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/opstore"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/diddochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/discovery"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/server"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
+)
+
+const (
+	namespace = "did:sidetree"
+	basePath  = "/sidetree/0.0.1"
+
+	sha2_256 = 18
+
+	contentTypeJSON = "application/json"
+
+	specFile = "openapi.yml"
+)
+
+func init() {
+	// the DID document API responds with the JSON-LD content type
+	openapi3filter.RegisterBodyDecoder("application/did+ld+json", openapi3filter.RegisteredBodyDecoder(contentTypeJSON))
+}
+
+func TestSpec_Routes(t *testing.T) {
+	doc := loadSpec(t)
+
+	var routes []string
+
+	for _, h := range newHandlers(t, mocks.NewMockDocumentHandler().WithNamespace(namespace)) {
+		routes = append(routes, h.Method()+" "+strings.TrimPrefix(h.Path(), basePath))
+	}
+
+	sort.Strings(routes)
+
+	var documented []string
+
+	for path, item := range doc.Paths {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(documented)
+
+	require.Equal(t, routes, documented, "every route must be documented and every documented route must be served")
+}
+
+func TestSpec_Schemas(t *testing.T) {
+	doc := loadSpec(t)
+
+	types := map[string]interface{}{
+		"CreateRequest":           model.CreateRequest{},
+		"UpdateRequest":           model.UpdateRequest{},
+		"RecoverRequest":          model.RecoverRequest{},
+		"DeactivateRequest":       model.DeactivateRequest{},
+		"SuffixData":              model.SuffixDataModel{},
+		"Delta":                   model.DeltaModel{},
+		"UpdateSignedData":        model.UpdateSignedDataModel{},
+		"RecoverSignedData":       model.RecoverSignedDataModel{},
+		"DeactivateSignedData":    model.DeactivateSignedDataModel{},
+		"JWK":                     jws.JWK{},
+		"ResolutionResult":        document.ResolutionResult{},
+		"MethodMetadata":          document.MethodMetadata{},
+		"BatchResolutionRequest":  dochandler.BatchResolutionRequest{},
+		"BatchResolutionResponse": dochandler.BatchResolutionResponse{},
+		"BatchResolutionEntry":    dochandler.BatchResolutionEntry{},
+		"IdentifierList":          dochandler.IdentifierList{},
+		"IdentifierEntry":         dochandler.IdentifierEntry{},
+		"LookupResponse":          dochandler.LookupResponse{},
+		"DiscoveryResponse":       discovery.Response{},
+		"NamespaceInfo":           discovery.NamespaceInfo{},
+		"ProtocolInfo":            discovery.ProtocolInfo{},
+		"ProblemDetails":          common.ProblemDetails{},
+	}
+
+	for name, v := range types {
+		schema, ok := doc.Components.Schemas[name]
+		require.True(t, ok, "schema [%s] not found", name)
+
+		var properties []string
+		for p := range schema.Value.Properties {
+			properties = append(properties, p)
+		}
+
+		sort.Strings(properties)
+
+		require.Equal(t, jsonFields(reflect.TypeOf(v)), properties, "properties of schema [%s]", name)
+
+		for _, r := range schema.Value.Required {
+			require.Contains(t, properties, r, "required property of schema [%s]", name)
+		}
+	}
+}
+
+func TestSpec_Contract(t *testing.T) {
+	doc := loadSpec(t)
+
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(mocks.NewMockProtocolClient())
+
+	router := mux.NewRouter()
+	for _, h := range newHandlers(t, dh) {
+		router.HandleFunc(h.Path(), h.Handler()).Methods(h.Method())
+	}
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	doc.Servers = openapi3.Servers{{URL: ts.URL + basePath}}
+
+	specRouter, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	updateKey, updateCommitment := newKey(t)
+	recoveryKey, recoveryCommitment := newKey(t)
+
+	create, err := client.NewCreateRequest(&client.CreateRequestInfo{
+		Patches:            []patch.Patch{newAddPublicKeysPatch(t), newAddServicesPatch(t)},
+		RecoveryCommitment: recoveryCommitment,
+		UpdateCommitment:   updateCommitment,
+		MultihashCode:      sha2_256,
+	})
+	require.NoError(t, err)
+
+	suffix := uniqueSuffix(t, create)
+	did := namespace + docutil.NamespaceDelimiter + suffix
+
+	_, nextUpdateCommitment := newKey(t)
+
+	update, err := client.NewUpdateRequest(&client.UpdateRequestInfo{
+		DidSuffix:        suffix,
+		Patches:          []patch.Patch{newRemoveServicesPatch(t)},
+		UpdateKey:        updateKey.jwk,
+		UpdateCommitment: nextUpdateCommitment,
+		MultihashCode:    sha2_256,
+		Signer:           updateKey.signer,
+	})
+	require.NoError(t, err)
+
+	_, nextRecoveryCommitment := newKey(t)
+
+	recoverReq, err := client.NewRecoverRequest(&client.RecoverRequestInfo{
+		DidSuffix:          suffix,
+		OpaqueDocument:     `{"services":[{"id":"svc2","type":"LinkedDomains","serviceEndpoint":"https://example.org"}]}`,
+		RecoveryKey:        recoveryKey.jwk,
+		RecoveryCommitment: nextRecoveryCommitment,
+		UpdateCommitment:   nextUpdateCommitment,
+		MultihashCode:      sha2_256,
+		Signer:             recoveryKey.signer,
+	})
+	require.NoError(t, err)
+
+	deactivate, err := client.NewDeactivateRequest(&client.DeactivateRequestInfo{
+		DidSuffix:   suffix,
+		RecoveryKey: recoveryKey.jwk,
+		Signer:      recoveryKey.signer,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		route          string
+		path           string
+		body           []byte
+		status         int
+		invalidRequest bool
+	}{
+		{"create", http.MethodPost, "/operations", "/operations", create, http.StatusOK, false},
+		{"resolve", http.MethodGet, "/identifiers/{id}", "/identifiers/" + did, nil, http.StatusOK, false},
+		{"lookup", http.MethodGet, "/lookup", "/lookup?keyId=" + did + "%23key1", nil, http.StatusOK, false},
+		{"update", http.MethodPost, "/operations", "/operations", update, http.StatusOK, false},
+		{"recover", http.MethodPost, "/operations", "/operations", recoverReq, http.StatusOK, false},
+		{"batch resolve", http.MethodPost, "/identifiers/resolve", "/identifiers/resolve",
+			[]byte(`{"ids":["` + did + `","` + namespace + `:unknown","invalid"]}`), http.StatusOK, false},
+		{"list", http.MethodGet, "/identifiers", "/identifiers?type=create&limit=10", nil, http.StatusOK, false},
+		{"version", http.MethodGet, "/version", "/version", nil, http.StatusOK, false},
+		{"deactivate", http.MethodPost, "/operations", "/operations", deactivate, http.StatusOK, false},
+		{"resolve deactivated", http.MethodGet, "/identifiers/{id}", "/identifiers/" + did, nil, http.StatusGone, false},
+		{"resolve unknown", http.MethodGet, "/identifiers/{id}", "/identifiers/" + namespace + ":unknown", nil,
+			http.StatusNotFound, false},
+		{"resolve invalid DID", http.MethodGet, "/identifiers/{id}", "/identifiers/invalid", nil,
+			http.StatusBadRequest, false},
+		{"invalid operation", http.MethodPost, "/operations", "/operations", []byte(`{"type":"unsupported"}`),
+			http.StatusBadRequest, true},
+		{"invalid batch", http.MethodPost, "/identifiers/resolve", "/identifiers/resolve", []byte(`{"ids":[]}`),
+			http.StatusBadRequest, true},
+		{"invalid list query", http.MethodGet, "/identifiers", "/identifiers?limit=0", nil, http.StatusBadRequest, true},
+		{"invalid lookup query", http.MethodGet, "/lookup", "/lookup", nil, http.StatusBadRequest, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newRequest(t, tc.method, ts.URL+basePath+tc.path, tc.body)
+
+			route, pathParams, err := specRouter.FindRoute(req)
+			require.NoError(t, err, "%s %s not documented", tc.method, tc.route)
+			require.Equal(t, tc.route, route.Path)
+
+			input := &openapi3filter.RequestValidationInput{Request: req, PathParams: pathParams, Route: route}
+
+			err = openapi3filter.ValidateRequest(context.Background(), input)
+			if tc.invalidRequest {
+				require.Error(t, err, "invalid request is expected to be rejected by the specification")
+			} else {
+				require.NoError(t, err, "request: %s", tc.body)
+			}
+
+			resp, err := http.DefaultClient.Do(newRequest(t, tc.method, ts.URL+basePath+tc.path, tc.body))
+			require.NoError(t, err)
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			require.Equal(t, tc.status, resp.StatusCode, "response: %s", body)
+
+			err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 resp.StatusCode,
+				Header:                 resp.Header,
+				Body:                   ioutil.NopCloser(bytes.NewReader(body)),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+			})
+			require.NoError(t, err, "response: %s", body)
+		})
+	}
+}
+
+func TestSpec_Validate(t *testing.T) {
+	doc := loadSpec(t)
+
+	schema, ok := doc.Components.Schemas["ProblemDetails"]
+	require.True(t, ok)
+
+	valid := map[string]interface{}{"type": "about:blank", "title": "Not Found", "status": 404.0, "code": "not_found"}
+	require.NoError(t, schema.Value.VisitJSON(valid))
+
+	for _, value := range []interface{}{
+		"not an object",
+		map[string]interface{}{"type": "about:blank", "title": "Not Found", "status": 404.0},
+		merge(valid, "status", 404.5),
+		merge(valid, "code", "unknown"),
+		merge(valid, "other", "value"),
+	} {
+		require.Error(t, schema.Value.VisitJSON(value), "value: %v", value)
+	}
+
+	schema, ok = doc.Components.Schemas["Patch"]
+	require.True(t, ok)
+
+	require.Error(t, schema.Value.VisitJSON(map[string]interface{}{"action": "remove-services", "ids": []interface{}{1.0}}))
+}
+
+// loadSpec loads the specification and validates that it is a valid OpenAPI 3 document.
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	doc, err := openapi3.NewLoader().LoadFromFile(specFile)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	return doc
+}
+
+func newRequest(t *testing.T, method, url string, body []byte) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body)) //nolint:noctx
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	return req
+}
+
+type key struct {
+	jwk    *jws.JWK
+	signer client.Signer
+}
+
+func newKey(t *testing.T) (*key, string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := pubkey.GetPublicKeyJWK(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	c, err := commitment.Calculate(jwk, sha2_256, crypto.SHA256)
+	require.NoError(t, err)
+
+	return &key{jwk: jwk, signer: ecsigner.New(privateKey, "ES256", "")}, c
+}
+
+func newHandlers(t *testing.T, dh *mocks.MockDocumentHandler) []common.HTTPHandler {
+	t.Helper()
+
+	pc := mocks.NewMockProtocolClient()
+
+	opStore := opstore.New(opstore.NewMemBackend())
+	require.NoError(t, opStore.Put([]*operation.AnchoredOperation{
+		{Type: operation.TypeCreate, UniqueSuffix: "suffix1", TransactionTime: 10, AnchorString: "1.anchor1"},
+	}))
+
+	return append(server.DIDDocHandlers(basePath, dh, pc),
+		diddochandler.NewListHandler(basePath, namespace, opStore),
+		discovery.NewHandler(basePath+"/version", &discovery.Namespace{
+			Namespace:      namespace,
+			Aliases:        []string{"did:alias"},
+			ProtocolClient: pc,
+		}),
+	)
+}
+
+func newAddPublicKeysPatch(t *testing.T) patch.Patch {
+	t.Helper()
+
+	p, err := patch.NewAddPublicKeysPatch(`[{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"purposes": ["verificationMethod", "authentication"],
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+		}
+	}]`)
+	require.NoError(t, err)
+
+	return p
+}
+
+func newAddServicesPatch(t *testing.T) patch.Patch {
+	t.Helper()
+
+	p, err := patch.NewAddServiceEndpointsPatch(`[{
+		"id": "svc1",
+		"type": "LinkedDomains",
+		"serviceEndpoint": "https://example.com"
+	}]`)
+	require.NoError(t, err)
+
+	return p
+}
+
+func newRemoveServicesPatch(t *testing.T) patch.Patch {
+	t.Helper()
+
+	p, err := patch.NewRemoveServiceEndpointsPatch(`["svc1"]`)
+	require.NoError(t, err)
+
+	return p
+}
+
+func uniqueSuffix(t *testing.T, create []byte) string {
+	t.Helper()
+
+	var createReq model.CreateRequest
+	require.NoError(t, json.Unmarshal(create, &createReq))
+
+	suffix, err := docutil.CalculateModelMultihash(createReq.SuffixData, sha2_256)
+	require.NoError(t, err)
+
+	return suffix
+}
+
+// jsonFields returns the (sorted) JSON field names of the given struct type.
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.Anonymous {
+			fields = append(fields, jsonFields(f.Type)...)
+
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fields = append(fields, name)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+func merge(m map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range m {
+		merged[k] = v
+	}
+
+	merged[key] = value
+
+	return merged
+}
@@ -0,0 +1,154 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const contentType = "application/json"
+
+// CreateHandler creates DIDs.
+type CreateHandler struct {
+	*handler
+}
+
+// NewCreateHandler returns a new handler which serves {basePath}/create.
+func NewCreateHandler(basePath string, registrar *Registrar) *CreateHandler {
+	return &CreateHandler{
+		handler: newHandler(fmt.Sprintf("%s/create", basePath), registrar, func() interface{} { return &CreateRequest{} },
+			func(r *Registrar, req interface{}) (*Response, error) {
+				return r.Create(req.(*CreateRequest))
+			},
+		),
+	}
+}
+
+// UpdateHandler updates DIDs.
+type UpdateHandler struct {
+	*handler
+}
+
+// NewUpdateHandler returns a new handler which serves {basePath}/update.
+func NewUpdateHandler(basePath string, registrar *Registrar) *UpdateHandler {
+	return &UpdateHandler{
+		handler: newHandler(fmt.Sprintf("%s/update", basePath), registrar, func() interface{} { return &UpdateRequest{} },
+			func(r *Registrar, req interface{}) (*Response, error) {
+				return r.Update(req.(*UpdateRequest))
+			},
+		),
+	}
+}
+
+// DeactivateHandler deactivates DIDs.
+type DeactivateHandler struct {
+	*handler
+}
+
+// NewDeactivateHandler returns a new handler which serves {basePath}/deactivate.
+func NewDeactivateHandler(basePath string, registrar *Registrar) *DeactivateHandler {
+	return &DeactivateHandler{
+		handler: newHandler(fmt.Sprintf("%s/deactivate", basePath), registrar, func() interface{} { return &DeactivateRequest{} },
+			func(r *Registrar, req interface{}) (*Response, error) {
+				return r.Deactivate(req.(*DeactivateRequest))
+			},
+		),
+	}
+}
+
+type handler struct {
+	path       string
+	registrar  *Registrar
+	newRequest func() interface{}
+	process    func(r *Registrar, req interface{}) (*Response, error)
+}
+
+func newHandler(path string, registrar *Registrar, newRequest func() interface{},
+	process func(r *Registrar, req interface{}) (*Response, error)) *handler {
+	return &handler{
+		path:       path,
+		registrar:  registrar,
+		newRequest: newRequest,
+		process:    process,
+	}
+}
+
+// Path returns the context path.
+func (h *handler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *handler) Method() string {
+	return http.MethodPost
+}
+
+// Handler returns the handler.
+func (h *handler) Handler() common.HTTPRequestHandler {
+	return h.handle
+}
+
+func (h *handler) handle(rw http.ResponseWriter, req *http.Request) {
+	principal, err := h.registrar.authenticate(req)
+	if err != nil {
+		if challenge := auth.Challenge(h.registrar.authenticator); challenge != "" {
+			rw.Header().Set("WWW-Authenticate", challenge)
+		}
+
+		writeError(rw, err)
+
+		return
+	}
+
+	request := h.newRequest()
+
+	err = json.NewDecoder(req.Body).Decode(request)
+	if err != nil {
+		writeError(rw, common.NewHTTPError(http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)))
+
+		return
+	}
+
+	resp, err := h.process(h.registrar.withPrincipal(principal), request)
+	if err != nil {
+		writeError(rw, err)
+
+		return
+	}
+
+	writeResponse(rw, http.StatusOK, resp)
+}
+
+// writeError writes the failed state with the public message of the mapped error (see common.MapError),
+// i.e. the details of server errors are only logged.
+func writeError(rw http.ResponseWriter, err error) {
+	httpErr := common.MapError(err)
+
+	logger.Warnf("returning error status: %d, message: %s", httpErr.Status(), err.Error())
+
+	writeResponse(rw, httpErr.Status(), &Response{
+		DIDState: &DIDState{
+			State:  StateFailed,
+			Reason: httpErr.PublicMessage(),
+		},
+	})
+}
+
+func writeResponse(rw http.ResponseWriter, status int, resp *Response) {
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(status)
+
+	err := json.NewEncoder(rw).Encode(resp)
+	if err != nil {
+		logger.Errorf("Unable to write response: %s", err)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const basePath = "/registrar"
+
+func TestHandlers(t *testing.T) {
+	processor := newTestProcessor(t)
+	r := New(processor, processor.pc)
+
+	createHandler := NewCreateHandler(basePath, r)
+	updateHandler := NewUpdateHandler(basePath, r)
+	deactivateHandler := NewDeactivateHandler(basePath, r)
+
+	for path, h := range map[string]common.HTTPHandler{
+		basePath + "/create":     createHandler,
+		basePath + "/update":     updateHandler,
+		basePath + "/deactivate": deactivateHandler,
+	} {
+		require.Equal(t, path, h.Path())
+		require.Equal(t, http.MethodPost, h.Method())
+		require.NotNil(t, h.Handler())
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rw := post(t, createHandler, &CreateRequest{DIDDocument: parseDoc(t, validDoc)})
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, contentType, rw.Header().Get("Content-Type"))
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.True(t, strings.HasPrefix(resp.DIDState.DID, namespace))
+
+		rw = post(t, deactivateHandler, &DeactivateRequest{
+			DID:    resp.DIDState.DID,
+			Secret: &Secret{RecoveryKey: resp.DIDState.Secret.RecoveryKey},
+		})
+		require.Equal(t, http.StatusOK, rw.Code)
+	})
+
+	t.Run("error - invalid request", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		createHandler.Handler()(rw, httptest.NewRequest(http.MethodPost, basePath+"/create", strings.NewReader("{")))
+		requireFailed(t, rw, http.StatusBadRequest, "invalid request")
+	})
+
+	t.Run("error - registration failed", func(t *testing.T) {
+		rw := post(t, updateHandler, &UpdateRequest{DID: "did:other:123"})
+		requireFailed(t, rw, http.StatusBadRequest, "must start with namespace")
+
+		rw = post(t, updateHandler, &UpdateRequest{JobID: "123"})
+		requireFailed(t, rw, http.StatusNotFound, "not found or expired")
+	})
+
+	t.Run("error - internal error details are not disclosed", func(t *testing.T) {
+		p := &failingProcessor{testProcessor: processor, err: errors.New("injected store error")}
+
+		rw := post(t, NewCreateHandler(basePath, New(p, processor.pc)), &CreateRequest{DIDDocument: parseDoc(t, validDoc)})
+		requireFailed(t, rw, http.StatusInternalServerError, "Internal Server Error")
+		require.NotContains(t, rw.Body.String(), "injected")
+	})
+}
+
+type failingProcessor struct {
+	*testProcessor
+	err error
+}
+
+func (p *failingProcessor) ProcessOperation([]byte, uint64) (*document.ResolutionResult, error) {
+	return nil, p.err
+}
+
+func TestHandlers_Auth(t *testing.T) {
+	processor := newTestProcessor(t)
+
+	authenticator := auth.NewTokenAuthenticator(map[string]*auth.Principal{
+		"token1": {ID: "alice", Tenant: "tenant1"},
+		"token2": {ID: "bob", Tenant: "tenant2"},
+	})
+
+	r := New(processor, processor.pc,
+		WithAuthenticator(authenticator),
+		WithAuthorizer(auth.NewTenantPolicy(map[string][]operation.Type{
+			"tenant1": {operation.TypeCreate, operation.TypeUpdate},
+			"tenant2": {operation.TypeCreate},
+		})),
+	)
+
+	createHandler := NewCreateHandler(basePath, r)
+	updateHandler := NewUpdateHandler(basePath, r)
+	deactivateHandler := NewDeactivateHandler(basePath, r)
+
+	opts := &Options{ClientSecretMode: true}
+	updateKey := newECKey(t)
+	recoveryKey := newEDKey(t)
+
+	createRequest := &CreateRequest{
+		Options:     opts,
+		Secret:      &Secret{NextUpdateKey: updateKey.public, NextRecoveryKey: recoveryKey.public},
+		DIDDocument: parseDoc(t, validDoc),
+	}
+
+	t.Run("no credentials", func(t *testing.T) {
+		rw := post(t, createHandler, createRequest)
+		requireFailed(t, rw, http.StatusUnauthorized, "unauthorized")
+		require.Equal(t, "Bearer", rw.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		requireFailed(t, postWithToken(t, createHandler, "token3", createRequest), http.StatusUnauthorized, "unauthorized")
+	})
+
+	rw := postWithToken(t, createHandler, "token1", createRequest)
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	var resp Response
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+
+	did := resp.DIDState.DID
+
+	t.Run("operation type not permitted", func(t *testing.T) {
+		rw := postWithToken(t, deactivateHandler, "token1", &DeactivateRequest{
+			DID:     did,
+			Options: opts,
+			Secret:  &Secret{RecoveryKey: recoveryKey.public},
+		})
+		requireFailed(t, rw, http.StatusForbidden, "may not submit deactivate operations")
+	})
+
+	t.Run("job continued by another tenant", func(t *testing.T) {
+		rw := postWithToken(t, updateHandler, "token2", &UpdateRequest{
+			DID:                  did,
+			Options:              opts,
+			Secret:               &Secret{UpdateKey: updateKey.public, NextUpdateKey: newECKey(t).public},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc)},
+		})
+		requireFailed(t, rw, http.StatusForbidden, "may not submit update operations")
+
+		rw = postWithToken(t, updateHandler, "token1", &UpdateRequest{
+			DID:                  did,
+			Options:              opts,
+			Secret:               &Secret{UpdateKey: updateKey.public, NextUpdateKey: newECKey(t).public},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+		require.Equal(t, StateAction, resp.DIDState.State)
+
+		continueRequest := &UpdateRequest{
+			JobID: resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{
+				updateKeyID: updateKey.sign(t, resp.DIDState.SigningRequest[updateKeyID]),
+			}},
+		}
+
+		rw = postWithToken(t, updateHandler, "token2", continueRequest)
+		requireFailed(t, rw, http.StatusForbidden, "may not submit update operations")
+		require.Len(t, processor.document(t, did).Services(), 1)
+
+		// the job is kept for the permitted caller
+		rw = postWithToken(t, updateHandler, "token1", continueRequest)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Len(t, processor.document(t, did).Services(), 2)
+	})
+
+	t.Run("authorizer without authenticator", func(t *testing.T) {
+		var authorized []*auth.Operation
+
+		r := New(processor, processor.pc,
+			WithAuthorizer(auth.AuthorizerFunc(func(principal *auth.Principal, op *auth.Operation) error {
+				require.Nil(t, principal)
+				authorized = append(authorized, op)
+
+				return nil
+			})),
+		)
+
+		rw := post(t, NewDeactivateHandler(basePath, r), &DeactivateRequest{
+			DID:     did,
+			Options: opts,
+			Secret:  &Secret{RecoveryKey: recoveryKey.public},
+		})
+		require.Equal(t, http.StatusOK, rw.Code)
+
+		_, err := r.Create(&CreateRequest{DIDDocument: parseDoc(t, validDoc)})
+		require.NoError(t, err)
+
+		require.Equal(t, []*auth.Operation{
+			{Namespace: namespace, Type: operation.TypeDeactivate, UniqueSuffix: did[len(namespace)+1:]},
+			{Namespace: namespace, Type: operation.TypeCreate},
+		}, authorized)
+	})
+}
+
+func post(t *testing.T, h common.HTTPHandler, req interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return postWithToken(t, h, "", req)
+}
+
+func postWithToken(t *testing.T, h common.HTTPHandler, token string, req interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	b, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq := httptest.NewRequest(http.MethodPost, h.Path(), bytes.NewReader(b))
+
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rw := httptest.NewRecorder()
+	h.Handler()(rw, httpReq)
+
+	return rw
+}
+
+func requireFailed(t *testing.T, rw *httptest.ResponseRecorder, status int, reason string) {
+	t.Helper()
+
+	require.Equal(t, status, rw.Code)
+
+	var resp Response
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &resp))
+	require.Equal(t, StateFailed, resp.DIDState.State)
+	require.Contains(t, resp.DIDState.Reason, reason)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const jobIDSize = 16
+
+var (
+	errJobNotFound   = errors.New("not found or expired")
+	errJobInProgress = errors.New("is already in progress")
+)
+
+// job holds a pending operation which is waiting for the client's signature.
+type job struct {
+	op           *pendingOperation
+	signingInput []byte
+	expiry       time.Time
+	inProgress   bool
+}
+
+// jobStore is an in-memory store of pending jobs. Jobs expire after the configured TTL.
+type jobStore struct {
+	mutex sync.Mutex
+	jobs  map[string]*job
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+func newJobStore(ttl time.Duration) *jobStore {
+	return &jobStore{
+		jobs: make(map[string]*job),
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// put adds the job and returns the job ID.
+func (s *jobStore) put(op *pendingOperation, signingInput []byte) (string, error) {
+	id, err := newJobID()
+	if err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.purgeExpired()
+
+	s.jobs[id] = &job{
+		op:           op,
+		signingInput: signingInput,
+		expiry:       s.now().Add(s.ttl),
+	}
+
+	return id, nil
+}
+
+// acquire returns the job with the given ID and marks it as in progress so that it isn't continued concurrently.
+// The job stays in the store until it is removed, so it may be continued again (e.g. with a corrected signature)
+// after it has been released.
+func (s *jobStore) acquire(id string) (*job, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.purgeExpired()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, errJobNotFound
+	}
+
+	if j.inProgress {
+		return nil, errJobInProgress
+	}
+
+	j.inProgress = true
+
+	return j, nil
+}
+
+// release makes the job with the given ID available to be continued again.
+func (s *jobStore) release(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if j, ok := s.jobs[id]; ok {
+		j.inProgress = false
+	}
+}
+
+// remove deletes the job with the given ID.
+func (s *jobStore) remove(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.jobs, id)
+}
+
+func (s *jobStore) purgeExpired() {
+	now := s.now()
+
+	for id, j := range s.jobs {
+		if now.After(j.expiry) {
+			logger.Debugf("Job [%s] for DID [%s] has expired", id, j.op.did)
+
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, jobIDSize)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("generate job ID: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
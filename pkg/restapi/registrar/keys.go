@@ -0,0 +1,204 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+)
+
+const (
+	updateKeyID   = "updateKey"
+	recoveryKeyID = "recoveryKey"
+
+	privateKeyParam = "d"
+)
+
+// signingAlgorithms maps the JWK curve to the JWS signing algorithm.
+var signingAlgorithms = map[string]string{
+	"P-256":     "ES256",
+	"P-384":     "ES384",
+	"P-521":     "ES512",
+	"secp256k1": "ES256K",
+	"Ed25519":   "EdDSA",
+}
+
+// generateKey generates a new P-256 key.
+func generateKey() (*internaljws.JWK, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+
+	jwk := &internaljws.JWK{Kty: "EC", Crv: "P-256"}
+	jwk.Key = privateKey
+
+	return jwk, nil
+}
+
+// parsePrivateKey parses a JWK which contains a private key.
+func parsePrivateKey(name string, raw json.RawMessage) (*internaljws.JWK, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("missing %s in secret", name)
+	}
+
+	jwk := &internaljws.JWK{}
+
+	err := json.Unmarshal(raw, jwk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", name, err.Error())
+	}
+
+	if jwk.IsPublic() {
+		return nil, fmt.Errorf("%s must contain the private key", name)
+	}
+
+	return jwk, nil
+}
+
+// parsePublicKey parses a JWK which contains a public key. Private keys are rejected since they must not
+// be shared with the node in client-managed secret mode.
+func parsePublicKey(name string, raw json.RawMessage) (*jws.JWK, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("missing %s in secret", name)
+	}
+
+	params := make(map[string]interface{})
+
+	err := json.Unmarshal(raw, &params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", name, err.Error())
+	}
+
+	if _, ok := params[privateKeyParam]; ok {
+		return nil, fmt.Errorf("%s must not contain the private key in client-managed secret mode", name)
+	}
+
+	jwk := &jws.JWK{}
+
+	err = json.Unmarshal(raw, jwk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", name, err.Error())
+	}
+
+	err = jwk.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", name, err.Error())
+	}
+
+	return jwk, nil
+}
+
+// publicKey returns the public JWK of the given private key.
+func publicKey(jwk *internaljws.JWK) (*jws.JWK, error) {
+	var pubKey interface{}
+
+	switch key := jwk.Key.(type) {
+	case *ecdsa.PrivateKey:
+		pubKey = &key.PublicKey
+	case ed25519.PrivateKey:
+		pubKey = key.Public()
+	default:
+		return nil, fmt.Errorf("unsupported key type [%T]", jwk.Key)
+	}
+
+	return pubkey.GetPublicKeyJWK(pubKey)
+}
+
+// signingAlgorithm returns the JWS algorithm for the given key.
+func signingAlgorithm(jwk *jws.JWK) (string, error) {
+	alg, ok := signingAlgorithms[jwk.Crv]
+	if !ok {
+		return "", fmt.Errorf("unsupported curve [%s]", jwk.Crv)
+	}
+
+	return alg, nil
+}
+
+// signingKeyID returns the kid of the JWS which is signed with the given key. The kid is the JWK thumbprint of
+// the key if the protocol uses JWK thumbprints, otherwise the kid is empty.
+func signingKeyID(p protocol.Protocol, name string, jwk *jws.JWK) (string, error) {
+	if !p.JWKThumbprints {
+		return "", nil
+	}
+
+	kid, err := jwk.Thumbprint()
+	if err != nil {
+		return "", badRequest("invalid %s: %s", name, err.Error())
+	}
+
+	return kid, nil
+}
+
+func newSigner(jwk *internaljws.JWK, alg, kid string) (client.Signer, error) {
+	switch key := jwk.Key.(type) {
+	case *ecdsa.PrivateKey:
+		return ecsigner.New(key, alg, kid), nil
+	case ed25519.PrivateKey:
+		return edsigner.New(key, alg, kid), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type [%T]", jwk.Key)
+	}
+}
+
+func newHeaders(alg, kid string) jws.Headers {
+	return jws.Headers{
+		jws.HeaderAlgorithm: alg,
+		jws.HeaderKeyID:     kid,
+	}
+}
+
+// capturingSigner captures the JWS signing input instead of signing it. It is used in client-managed secret mode
+// to retrieve the payload which has to be signed by the client.
+type capturingSigner struct {
+	headers      jws.Headers
+	signingInput []byte
+}
+
+func (s *capturingSigner) Headers() jws.Headers {
+	return s.headers
+}
+
+func (s *capturingSigner) Sign(data []byte) ([]byte, error) {
+	s.signingInput = data
+
+	return []byte{}, nil
+}
+
+// presignedSigner returns the signature which was provided by the client. The request is rebuilt
+// from the same data so the signing input must be the one which was given to the client.
+type presignedSigner struct {
+	headers      jws.Headers
+	signingInput []byte
+	signature    []byte
+}
+
+func (s *presignedSigner) Headers() jws.Headers {
+	return s.headers
+}
+
+func (s *presignedSigner) Sign(data []byte) ([]byte, error) {
+	if !bytes.Equal(data, s.signingInput) {
+		return nil, errors.New("signing input doesn't match the signing request payload")
+	}
+
+	return s.signature, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"encoding/json"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+// Registration states.
+const (
+	// StateFinished indicates that the operation was accepted by the node.
+	StateFinished = "finished"
+
+	// StateFailed indicates that the operation failed (see DIDState.Reason).
+	StateFailed = "failed"
+
+	// StateAction indicates that the client has to perform an action (see DIDState.Action) and then
+	// continue the job by sending the result along with the job ID.
+	StateAction = "action"
+)
+
+// ActionSignPayload is the action which asks the client to sign the payloads in DIDState.SigningRequest.
+const ActionSignPayload = "signPayload"
+
+// DID document operations for the update request.
+const (
+	// OperationSetDIDDocument replaces the DID document (performed as a Sidetree recover operation).
+	OperationSetDIDDocument = "setDidDocument"
+
+	// OperationAddToDIDDocument adds the public keys and services in the given DID document.
+	OperationAddToDIDDocument = "addToDidDocument"
+
+	// OperationRemoveFromDIDDocument removes the public keys and services (by ID) in the given DID document.
+	OperationRemoveFromDIDDocument = "removeFromDidDocument"
+)
+
+// CreateRequest is the request to create a DID.
+type CreateRequest struct {
+	JobID       string            `json:"jobId,omitempty"`
+	Options     *Options          `json:"options,omitempty"`
+	Secret      *Secret           `json:"secret,omitempty"`
+	DIDDocument document.Document `json:"didDocument,omitempty"`
+}
+
+// UpdateRequest is the request to update a DID. Each DID document operation is paired with the DID
+// document at the same index. The operation defaults to setDidDocument.
+type UpdateRequest struct {
+	JobID                string              `json:"jobId,omitempty"`
+	DID                  string              `json:"did,omitempty"`
+	Options              *Options            `json:"options,omitempty"`
+	Secret               *Secret             `json:"secret,omitempty"`
+	DIDDocumentOperation []string            `json:"didDocumentOperation,omitempty"`
+	DIDDocument          []document.Document `json:"didDocument,omitempty"`
+}
+
+// DeactivateRequest is the request to deactivate a DID.
+type DeactivateRequest struct {
+	JobID   string   `json:"jobId,omitempty"`
+	DID     string   `json:"did,omitempty"`
+	Options *Options `json:"options,omitempty"`
+	Secret  *Secret  `json:"secret,omitempty"`
+}
+
+// Options contains the registration options.
+type Options struct {
+	// ClientSecretMode indicates that the client manages the keys. The client provides public keys
+	// in the secret and signs the payloads returned in the signing request.
+	ClientSecretMode bool `json:"clientSecretMode,omitempty"`
+}
+
+// Secret contains the keys (JWK) used by the operation.
+//
+// In internal secret mode the current update/recovery keys must include the private key ('d'), and the
+// keys that were generated for the next operations are returned (with the private key) in the response.
+// In client-managed secret mode only public keys are accepted: the current keys are used to verify the
+// client's signatures and the next keys are used to calculate the commitments.
+type Secret struct {
+	UpdateKey       json.RawMessage             `json:"updateKey,omitempty"`
+	RecoveryKey     json.RawMessage             `json:"recoveryKey,omitempty"`
+	NextUpdateKey   json.RawMessage             `json:"nextUpdateKey,omitempty"`
+	NextRecoveryKey json.RawMessage             `json:"nextRecoveryKey,omitempty"`
+	SigningResponse map[string]*SigningResponse `json:"signingResponse,omitempty"`
+}
+
+// Response is the registration response.
+type Response struct {
+	JobID                   string                   `json:"jobId,omitempty"`
+	DIDState                *DIDState                `json:"didState"`
+	DIDRegistrationMetadata map[string]interface{}   `json:"didRegistrationMetadata,omitempty"`
+	DIDDocumentMetadata     *document.MethodMetadata `json:"didDocumentMetadata,omitempty"`
+}
+
+// DIDState contains the state of the registration.
+type DIDState struct {
+	State          string                     `json:"state"`
+	DID            string                     `json:"did,omitempty"`
+	Secret         *Secret                    `json:"secret,omitempty"`
+	DIDDocument    document.Document          `json:"didDocument,omitempty"`
+	Action         string                     `json:"action,omitempty"`
+	SigningRequest map[string]*SigningRequest `json:"signingRequest,omitempty"`
+	Reason         string                     `json:"reason,omitempty"`
+}
+
+// SigningRequest contains the payload which has to be signed by the client. The payload is the
+// base64url encoded JWS signing input (protected header and payload).
+type SigningRequest struct {
+	KID     string `json:"kid"`
+	Alg     string `json:"alg"`
+	Payload string `json:"payload"`
+}
+
+// SigningResponse contains the base64url encoded signature of the signing request payload.
+type SigningResponse struct {
+	Signature string `json:"signature"`
+}
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"encoding/json"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// getPatches returns the patches for the given addToDidDocument and removeFromDidDocument operations. Each
+// operation applies to the public keys and services of the DID document at the same index.
+func getPatches(operations []string, docs []document.Document) ([]patch.Patch, error) {
+	var patches []patch.Patch
+
+	for i, op := range operations {
+		var (
+			p   []patch.Patch
+			err error
+		)
+
+		switch op {
+		case OperationAddToDIDDocument:
+			p, err = getAddPatches(docs[i])
+		case OperationRemoveFromDIDDocument:
+			p, err = getRemovePatches(docs[i])
+		case OperationSetDIDDocument:
+			return nil, badRequest("%s cannot be combined with other DID document operations", OperationSetDIDDocument)
+		default:
+			return nil, badRequest("DID document operation [%s] is not supported", op)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, p...)
+	}
+
+	if len(patches) == 0 {
+		return nil, badRequest("the DID documents don't contain any public keys or services")
+	}
+
+	return patches, nil
+}
+
+func getAddPatches(doc document.Document) ([]patch.Patch, error) {
+	var patches []patch.Patch
+
+	if publicKeys, ok := doc[document.PublicKeyProperty]; ok {
+		p, err := newPatch(patch.NewAddPublicKeysPatch, publicKeys)
+		if err != nil {
+			return nil, badRequest("add public keys: %s", err.Error())
+		}
+
+		patches = append(patches, p)
+	}
+
+	if services, ok := doc[document.ServiceProperty]; ok {
+		p, err := newPatch(patch.NewAddServiceEndpointsPatch, services)
+		if err != nil {
+			return nil, badRequest("add services: %s", err.Error())
+		}
+
+		patches = append(patches, p)
+	}
+
+	return patches, nil
+}
+
+func getRemovePatches(doc document.Document) ([]patch.Patch, error) {
+	var patches []patch.Patch
+
+	if publicKeys := doc.PublicKeys(); len(publicKeys) > 0 {
+		ids := make([]string, len(publicKeys))
+		for i, pk := range publicKeys {
+			ids[i] = pk.ID()
+		}
+
+		p, err := newPatch(patch.NewRemovePublicKeysPatch, ids)
+		if err != nil {
+			return nil, badRequest("remove public keys: %s", err.Error())
+		}
+
+		patches = append(patches, p)
+	}
+
+	if services := doc.Services(); len(services) > 0 {
+		ids := make([]string, len(services))
+		for i, s := range services {
+			ids[i] = s.ID()
+		}
+
+		p, err := newPatch(patch.NewRemoveServiceEndpointsPatch, ids)
+		if err != nil {
+			return nil, badRequest("remove services: %s", err.Error())
+		}
+
+		patches = append(patches, p)
+	}
+
+	return patches, nil
+}
+
+func newPatch(create func(string) (patch.Patch, error), value interface{}) (patch.Patch, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return create(string(b))
+}
@@ -0,0 +1,646 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/auth"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+)
+
+var logger = log.New("sidetree-core-restapi-registrar")
+
+const defaultJobTTL = 10 * time.Minute
+
+// Registrar builds Sidetree operations from DID documents and keys, and submits them to the processor.
+// It follows the DIF Universal Registrar API (create, update and deactivate).
+//
+// In internal secret mode (the default) the registrar signs the operations using the private keys
+// provided in the secret and generates the keys for the next operations. In client-managed secret mode
+// the registrar never sees a private key: the operation is held in a job and the client is asked
+// to sign the payload. The client continues the job by sending the job ID along with the signature.
+//
+// If an authorizer is configured, every operation (including the continuation of a job) has to be permitted
+// by the authorizer before it is submitted. The handlers authenticate requests using the configured authenticator.
+type Registrar struct {
+	processor     dochandler.Processor
+	protocol      protocol.Client
+	jobs          *jobStore
+	jobTTL        time.Duration
+	authenticator auth.Authenticator
+	authorizer    auth.Authorizer
+
+	// principal is the authenticated caller of the current request (see withPrincipal)
+	principal *auth.Principal
+}
+
+// Option is a registrar option.
+type Option func(opts *Registrar)
+
+// WithJobTTL sets the time after which a job that is waiting for the client's signature expires.
+func WithJobTTL(ttl time.Duration) Option {
+	return func(opts *Registrar) {
+		opts.jobTTL = ttl
+	}
+}
+
+// WithAuthenticator requires the requests of the registrar handlers to be authenticated by the given authenticator.
+func WithAuthenticator(a auth.Authenticator) Option {
+	return func(opts *Registrar) {
+		opts.authenticator = a
+	}
+}
+
+// WithAuthorizer requires operations to be permitted by the given authorizer. The authorizer is passed
+// the principal returned by the authenticator (or nil if no authenticator is configured).
+func WithAuthorizer(a auth.Authorizer) Option {
+	return func(opts *Registrar) {
+		opts.authorizer = a
+	}
+}
+
+// New returns a new registrar.
+func New(processor dochandler.Processor, pc protocol.Client, opts ...Option) *Registrar {
+	r := &Registrar{
+		processor: processor,
+		protocol:  pc,
+		jobTTL:    defaultJobTTL,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.jobs = newJobStore(r.jobTTL)
+
+	return r
+}
+
+// pendingOperation contains the data required to build (and rebuild) the signed operation request.
+type pendingOperation struct {
+	opType      operation.Type
+	suffix      string
+	did         string
+	genesisTime uint64
+	kid         string // name of the signing key in the secret (updateKey or recoveryKey)
+	keyID       string // kid of the JWS protected header (see signingKeyID)
+	alg         string
+	build       func(signer client.Signer) ([]byte, error)
+	secret      *Secret
+}
+
+// Create creates a DID from the DID document in the request.
+func (r *Registrar) Create(req *CreateRequest) (*Response, error) {
+	if req.JobID != "" {
+		return nil, badRequest("create doesn't require a signature so there is no job to continue")
+	}
+
+	if len(req.DIDDocument) == 0 {
+		return nil, badRequest("missing DID document")
+	}
+
+	if err := r.authorize(operation.TypeCreate, ""); err != nil {
+		return nil, err
+	}
+
+	p, err := r.currentProtocol()
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecretMode := isClientSecretMode(req.Options)
+
+	updateCommitment, updateKey, err := nextCommitment(p, clientSecretMode, updateKeyID, req.Secret.nextUpdateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCommitment, recoveryKey, err := nextCommitment(p, clientSecretMode, recoveryKeyID, req.Secret.nextRecoveryKey())
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(req.DIDDocument)
+	if err != nil {
+		return nil, badRequest("marshal DID document: %s", err.Error())
+	}
+
+	request, err := client.NewCreateRequest(&client.CreateRequestInfo{
+		OpaqueDocument:     string(doc),
+		UpdateCommitment:   updateCommitment,
+		RecoveryCommitment: recoveryCommitment,
+		MultihashCode:      p.MultihashAlgorithm,
+	})
+	if err != nil {
+		return nil, badRequest("create request: %s", err.Error())
+	}
+
+	return r.submit(request, p.GenesisTime, "", newSecret(updateKey, recoveryKey))
+}
+
+// Update updates the DID document. A setDidDocument operation replaces the document and requires the recovery
+// key (a Sidetree recover operation), whereas addToDidDocument and removeFromDidDocument operations require
+// the update key (a Sidetree update operation).
+func (r *Registrar) Update(req *UpdateRequest) (*Response, error) {
+	if req.JobID != "" {
+		return r.continueJob(req.JobID, req.Secret)
+	}
+
+	suffix, err := r.getSuffix(req.DID)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := req.DIDDocumentOperation
+	if len(operations) == 0 {
+		operations = []string{OperationSetDIDDocument}
+	}
+
+	if len(operations) != len(req.DIDDocument) {
+		return nil, badRequest("the number of DID document operations [%d] must match the number of DID documents [%d]",
+			len(operations), len(req.DIDDocument))
+	}
+
+	p, err := r.currentProtocol()
+	if err != nil {
+		return nil, err
+	}
+
+	var op *pendingOperation
+
+	if operations[0] == OperationSetDIDDocument {
+		if len(operations) > 1 {
+			return nil, badRequest("%s cannot be combined with other DID document operations", OperationSetDIDDocument)
+		}
+
+		op, err = newRecoverOperation(p, req, suffix)
+	} else {
+		op, err = newUpdateOperation(p, req, suffix, operations)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.signAndSubmit(op, req.Options, req.Secret.updateOrRecoveryKey(op.kid))
+}
+
+// Deactivate deactivates the DID. The recovery key is required.
+func (r *Registrar) Deactivate(req *DeactivateRequest) (*Response, error) {
+	if req.JobID != "" {
+		return r.continueJob(req.JobID, req.Secret)
+	}
+
+	suffix, err := r.getSuffix(req.DID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := r.currentProtocol()
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryKey, err := signingPublicKey(isClientSecretMode(req.Options), recoveryKeyID, req.Secret.recoveryKey())
+	if err != nil {
+		return nil, err
+	}
+
+	keyID, err := signingKeyID(p, recoveryKeyID, recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &pendingOperation{
+		opType:      operation.TypeDeactivate,
+		suffix:      suffix,
+		did:         req.DID,
+		genesisTime: p.GenesisTime,
+		kid:         recoveryKeyID,
+		keyID:       keyID,
+		build: func(signer client.Signer) ([]byte, error) {
+			return client.NewDeactivateRequest(&client.DeactivateRequestInfo{
+				DidSuffix:   suffix,
+				RecoveryKey: recoveryKey,
+				Signer:      signer,
+			})
+		},
+	}
+
+	op.alg, err = signingAlgorithm(recoveryKey)
+	if err != nil {
+		return nil, badRequest("invalid %s: %s", recoveryKeyID, err.Error())
+	}
+
+	return r.signAndSubmit(op, req.Options, req.Secret.recoveryKey())
+}
+
+func newUpdateOperation(p protocol.Protocol, req *UpdateRequest, suffix string, operations []string) (*pendingOperation, error) {
+	patches, err := getPatches(operations, req.DIDDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	clientSecretMode := isClientSecretMode(req.Options)
+
+	updateKey, err := signingPublicKey(clientSecretMode, updateKeyID, req.Secret.updateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	updateCommitment, nextUpdateKey, err := nextCommitment(p, clientSecretMode, updateKeyID, req.Secret.nextUpdateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	alg, err := signingAlgorithm(updateKey)
+	if err != nil {
+		return nil, badRequest("invalid %s: %s", updateKeyID, err.Error())
+	}
+
+	keyID, err := signingKeyID(p, updateKeyID, updateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingOperation{
+		opType:      operation.TypeUpdate,
+		suffix:      suffix,
+		did:         req.DID,
+		genesisTime: p.GenesisTime,
+		kid:         updateKeyID,
+		keyID:       keyID,
+		alg:         alg,
+		secret:      newSecret(nextUpdateKey, nil),
+		build: func(signer client.Signer) ([]byte, error) {
+			return client.NewUpdateRequest(&client.UpdateRequestInfo{
+				DidSuffix:        suffix,
+				Patches:          patches,
+				UpdateCommitment: updateCommitment,
+				UpdateKey:        updateKey,
+				MultihashCode:    p.MultihashAlgorithm,
+				Signer:           signer,
+			})
+		},
+	}, nil
+}
+
+func newRecoverOperation(p protocol.Protocol, req *UpdateRequest, suffix string) (*pendingOperation, error) {
+	clientSecretMode := isClientSecretMode(req.Options)
+
+	recoveryKey, err := signingPublicKey(clientSecretMode, recoveryKeyID, req.Secret.recoveryKey())
+	if err != nil {
+		return nil, err
+	}
+
+	updateCommitment, nextUpdateKey, err := nextCommitment(p, clientSecretMode, updateKeyID, req.Secret.nextUpdateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCommitment, nextRecoveryKey, err := nextCommitment(p, clientSecretMode, recoveryKeyID, req.Secret.nextRecoveryKey())
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(req.DIDDocument[0])
+	if err != nil {
+		return nil, badRequest("marshal DID document: %s", err.Error())
+	}
+
+	alg, err := signingAlgorithm(recoveryKey)
+	if err != nil {
+		return nil, badRequest("invalid %s: %s", recoveryKeyID, err.Error())
+	}
+
+	keyID, err := signingKeyID(p, recoveryKeyID, recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingOperation{
+		opType:      operation.TypeRecover,
+		suffix:      suffix,
+		did:         req.DID,
+		genesisTime: p.GenesisTime,
+		kid:         recoveryKeyID,
+		keyID:       keyID,
+		alg:         alg,
+		secret:      newSecret(nextUpdateKey, nextRecoveryKey),
+		build: func(signer client.Signer) ([]byte, error) {
+			return client.NewRecoverRequest(&client.RecoverRequestInfo{
+				DidSuffix:          suffix,
+				RecoveryKey:        recoveryKey,
+				OpaqueDocument:     string(doc),
+				RecoveryCommitment: recoveryCommitment,
+				UpdateCommitment:   updateCommitment,
+				MultihashCode:      p.MultihashAlgorithm,
+				Signer:             signer,
+			})
+		},
+	}, nil
+}
+
+// signAndSubmit signs the operation with the private key (internal secret mode) and submits it, or (in
+// client-managed secret mode) creates a job and returns the payload which has to be signed by the client.
+func (r *Registrar) signAndSubmit(op *pendingOperation, opts *Options, key json.RawMessage) (*Response, error) {
+	if err := r.authorize(op.opType, op.suffix); err != nil {
+		return nil, err
+	}
+
+	if isClientSecretMode(opts) {
+		return r.requestSignature(op)
+	}
+
+	privateKey, err := parsePrivateKey(op.kid, key)
+	if err != nil {
+		return nil, badRequest("%s", err.Error())
+	}
+
+	signer, err := newSigner(privateKey, op.alg, op.keyID)
+	if err != nil {
+		return nil, badRequest("invalid %s: %s", op.kid, err.Error())
+	}
+
+	request, err := op.build(signer)
+	if err != nil {
+		return nil, badRequest("build request: %s", err.Error())
+	}
+
+	return r.submit(request, op.genesisTime, op.did, op.secret)
+}
+
+func (r *Registrar) requestSignature(op *pendingOperation) (*Response, error) {
+	signer := &capturingSigner{headers: newHeaders(op.alg, op.keyID)}
+
+	_, err := op.build(signer)
+	if err != nil {
+		return nil, badRequest("build request: %s", err.Error())
+	}
+
+	jobID, err := r.jobs.put(op, signer.signingInput)
+	if err != nil {
+		return nil, common.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	logger.Debugf("Created job [%s] for DID [%s]; waiting for signature", jobID, op.did)
+
+	return &Response{
+		JobID: jobID,
+		DIDState: &DIDState{
+			State:  StateAction,
+			DID:    op.did,
+			Action: ActionSignPayload,
+			SigningRequest: map[string]*SigningRequest{
+				op.kid: {
+					KID:     op.kid,
+					Alg:     op.alg,
+					Payload: base64.RawURLEncoding.EncodeToString(signer.signingInput),
+				},
+			},
+		},
+	}, nil
+}
+
+// continueJob submits the operation of the job using the signature in the secret. The job is only removed once
+// the operation has been submitted or has failed permanently, so that the client may retry a job which failed
+// because of an invalid signature (or a transient error) before the job expires.
+func (r *Registrar) continueJob(jobID string, secret *Secret) (*Response, error) {
+	j, err := r.jobs.acquire(jobID)
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, errJobInProgress) {
+			status = http.StatusConflict
+		}
+
+		return nil, common.NewHTTPError(status, fmt.Errorf("job [%s] %w", jobID, err))
+	}
+
+	// the caller which continues the job may not be the one which created it
+	if err := r.authorize(j.op.opType, j.op.suffix); err != nil {
+		r.jobs.release(jobID)
+
+		return nil, err
+	}
+
+	resp, err := r.completeJob(j, secret)
+	if err != nil && isRetryable(err) {
+		r.jobs.release(jobID)
+
+		return nil, err
+	}
+
+	r.jobs.remove(jobID)
+
+	return resp, err
+}
+
+func (r *Registrar) completeJob(j *job, secret *Secret) (*Response, error) {
+	signature, err := secret.signature(j.op.kid)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := j.op.build(&presignedSigner{
+		headers:      newHeaders(j.op.alg, j.op.keyID),
+		signingInput: j.signingInput,
+		signature:    signature,
+	})
+	if err != nil {
+		return nil, badRequest("build request: %s", err.Error())
+	}
+
+	return r.submit(request, j.op.genesisTime, j.op.did, j.op.secret)
+}
+
+// isRetryable returns true if the job may succeed when it is continued again, i.e. if the request was invalid
+// (e.g. the signature didn't verify) or the server failed to process it.
+func isRetryable(err error) bool {
+	status := common.MapError(err).Status()
+
+	return status == http.StatusBadRequest || status >= http.StatusInternalServerError
+}
+
+func (r *Registrar) submit(request []byte, genesisTime uint64, did string, secret *Secret) (*Response, error) {
+	result, err := r.processor.ProcessOperation(request, genesisTime)
+	if err != nil {
+		httpErr := common.MapError(err)
+		if httpErr.Status() == http.StatusInternalServerError {
+			logger.Errorf("internal server error processing operation for DID [%s]: %s", did, err.Error())
+		}
+
+		return nil, httpErr
+	}
+
+	resp := &Response{
+		DIDState: &DIDState{
+			State:  StateFinished,
+			DID:    did,
+			Secret: secret,
+		},
+	}
+
+	// the processor only returns the document for create operations
+	if result != nil {
+		resp.DIDState.DID = result.Document.ID()
+		resp.DIDState.DIDDocument = result.Document
+		resp.DIDDocumentMetadata = &result.MethodMetadata
+	}
+
+	return resp, nil
+}
+
+// withPrincipal returns a copy of the registrar which authorizes operations for the given principal.
+func (r *Registrar) withPrincipal(principal *auth.Principal) *Registrar {
+	rc := *r
+	rc.principal = principal
+
+	return &rc
+}
+
+func (r *Registrar) authenticate(req *http.Request) (*auth.Principal, error) {
+	if r.authenticator == nil {
+		return nil, nil
+	}
+
+	principal, err := r.authenticator.Authenticate(req)
+	if err != nil {
+		logger.Warnf("authentication failed: %s", err.Error())
+
+		return nil, common.MapError(err)
+	}
+
+	return principal, nil
+}
+
+func (r *Registrar) authorize(opType operation.Type, suffix string) error {
+	if r.authorizer == nil {
+		return nil
+	}
+
+	err := r.authorizer.Authorize(r.principal, &auth.Operation{
+		Namespace:    r.processor.Namespace(),
+		Type:         opType,
+		UniqueSuffix: suffix,
+	})
+	if err != nil {
+		logger.Warnf("authorization failed: %s", err.Error())
+
+		return common.MapError(err)
+	}
+
+	return nil
+}
+
+func (r *Registrar) currentProtocol() (protocol.Protocol, error) {
+	pv, err := r.protocol.Current()
+	if err != nil {
+		return protocol.Protocol{}, common.MapError(fmt.Errorf("%w: %s", protocol.ErrUnavailable, err.Error()))
+	}
+
+	return pv.Protocol(), nil
+}
+
+func (r *Registrar) getSuffix(did string) (string, error) {
+	if did == "" {
+		return "", badRequest("missing DID")
+	}
+
+	prefix := r.processor.Namespace() + docutil.NamespaceDelimiter
+
+	if !strings.HasPrefix(did, prefix) || len(did) == len(prefix) {
+		return "", badRequest("DID [%s] must start with namespace [%s]", did, r.processor.Namespace())
+	}
+
+	return did[len(prefix):], nil
+}
+
+// signingPublicKey returns the public key which is used to sign the operation. In internal secret mode
+// the public key is derived from the private key.
+func signingPublicKey(clientSecretMode bool, name string, raw json.RawMessage) (*jws.JWK, error) {
+	if clientSecretMode {
+		jwk, err := parsePublicKey(name, raw)
+		if err != nil {
+			return nil, badRequest("%s", err.Error())
+		}
+
+		return jwk, nil
+	}
+
+	privateKey, err := parsePrivateKey(name, raw)
+	if err != nil {
+		return nil, badRequest("%s", err.Error())
+	}
+
+	jwk, err := publicKey(privateKey)
+	if err != nil {
+		return nil, badRequest("invalid %s: %s", name, err.Error())
+	}
+
+	return jwk, nil
+}
+
+// nextCommitment returns the commitment for the next operation. In internal secret mode a new key is
+// generated (and returned so that it can be given to the client); in client-managed secret mode the
+// commitment is calculated from the public key provided by the client.
+func nextCommitment(p protocol.Protocol, clientSecretMode bool, name string, raw json.RawMessage) (string, *internaljws.JWK, error) {
+	var (
+		jwk        *jws.JWK
+		privateKey *internaljws.JWK
+		err        error
+	)
+
+	if clientSecretMode {
+		jwk, err = parsePublicKey("next "+name, raw)
+		if err != nil {
+			return "", nil, badRequest("%s", err.Error())
+		}
+	} else {
+		privateKey, err = generateKey()
+		if err != nil {
+			return "", nil, common.NewHTTPError(http.StatusInternalServerError, err)
+		}
+
+		jwk, err = publicKey(privateKey)
+		if err != nil {
+			return "", nil, common.NewHTTPError(http.StatusInternalServerError, err)
+		}
+	}
+
+	c, err := commitment.CalculateForProtocol(jwk, p)
+	if err != nil {
+		return "", nil, badRequest("calculate %s commitment: %s", name, err.Error())
+	}
+
+	return c, privateKey, nil
+}
+
+func isClientSecretMode(opts *Options) bool {
+	return opts != nil && opts.ClientSecretMode
+}
+
+func badRequest(format string, args ...interface{}) *common.HTTPError {
+	return common.NewHTTPError(http.StatusBadRequest, fmt.Errorf(format, args...))
+}
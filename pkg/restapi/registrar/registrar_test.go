@@ -0,0 +1,776 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+const namespace = "did:sidetree"
+
+const validDoc = `{
+	"publicKey": [{
+		"id": "key1",
+		"type": "JsonWebKey2020",
+		"purposes": ["authentication"],
+		"publicKeyJwk": {
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "PUymIqdtF_qxaAqPABSw-C-owT1KYYQbsMKFM-L9fJA",
+			"y": "nM84jDHCMOTGTh_ZdHq4dBBdo4Z5PkEOW9jA8z8IsGc"
+		}
+	}],
+	"service": [{
+		"id": "service1",
+		"type": "LinkedDomains",
+		"serviceEndpoint": "https://example.com"
+	}]
+}`
+
+const serviceDoc = `{
+	"service": [{
+		"id": "service2",
+		"type": "LinkedDomains",
+		"serviceEndpoint": "https://example.org"
+	}]
+}`
+
+func TestRegistrar_InternalSecretMode(t *testing.T) {
+	processor := newTestProcessor(t)
+	r := New(processor, processor.pc)
+
+	resp, err := r.Create(&CreateRequest{DIDDocument: parseDoc(t, validDoc)})
+	require.NoError(t, err)
+	require.Equal(t, StateFinished, resp.DIDState.State)
+	require.Empty(t, resp.JobID)
+	require.NotEmpty(t, resp.DIDState.DID)
+	require.NotNil(t, resp.DIDState.DIDDocument)
+	require.NotNil(t, resp.DIDDocumentMetadata)
+	require.NotNil(t, resp.DIDState.Secret)
+	requirePrivateKey(t, resp.DIDState.Secret.UpdateKey)
+	requirePrivateKey(t, resp.DIDState.Secret.RecoveryKey)
+
+	did := resp.DIDState.DID
+	secret := resp.DIDState.Secret
+
+	t.Run("update - add and remove", func(t *testing.T) {
+		resp, err := r.Update(&UpdateRequest{
+			DID:                  did,
+			Secret:               &Secret{UpdateKey: secret.UpdateKey},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument, OperationRemoveFromDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc), {"publicKey": []interface{}{map[string]interface{}{"id": "key1"}}}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Equal(t, did, resp.DIDState.DID)
+		requirePrivateKey(t, resp.DIDState.Secret.UpdateKey)
+		require.Empty(t, resp.DIDState.Secret.RecoveryKey)
+
+		doc := processor.document(t, did)
+		require.Len(t, doc.Services(), 2)
+		require.Empty(t, doc.PublicKeys())
+
+		secret.UpdateKey = resp.DIDState.Secret.UpdateKey
+	})
+
+	t.Run("update - previous update key is rejected", func(t *testing.T) {
+		_, err := r.Update(&UpdateRequest{
+			DID:                  did,
+			Secret:               &Secret{UpdateKey: secret.RecoveryKey},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, validDoc)},
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, common.MapError(err).Status())
+	})
+
+	t.Run("update - set DID document", func(t *testing.T) {
+		resp, err := r.Update(&UpdateRequest{
+			DID:         did,
+			Secret:      &Secret{RecoveryKey: secret.RecoveryKey},
+			DIDDocument: []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		requirePrivateKey(t, resp.DIDState.Secret.UpdateKey)
+		requirePrivateKey(t, resp.DIDState.Secret.RecoveryKey)
+
+		doc := processor.document(t, did)
+		require.Len(t, doc.Services(), 1)
+		require.Equal(t, "service2", doc.Services()[0].ID())
+
+		secret = resp.DIDState.Secret
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		resp, err := r.Deactivate(&DeactivateRequest{
+			DID:    did,
+			Secret: &Secret{RecoveryKey: secret.RecoveryKey},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Equal(t, did, resp.DIDState.DID)
+		require.Nil(t, resp.DIDState.Secret)
+
+		require.Nil(t, processor.document(t, did))
+	})
+}
+
+func TestRegistrar_ClientSecretMode(t *testing.T) {
+	processor := newTestProcessor(t)
+	r := New(processor, processor.pc)
+
+	opts := &Options{ClientSecretMode: true}
+
+	updateKey := newECKey(t)
+	recoveryKey := newEDKey(t)
+
+	resp, err := r.Create(&CreateRequest{
+		Options:     opts,
+		Secret:      &Secret{NextUpdateKey: updateKey.public, NextRecoveryKey: recoveryKey.public},
+		DIDDocument: parseDoc(t, validDoc),
+	})
+	require.NoError(t, err)
+	require.Equal(t, StateFinished, resp.DIDState.State)
+	require.Nil(t, resp.DIDState.Secret)
+
+	did := resp.DIDState.DID
+
+	t.Run("update", func(t *testing.T) {
+		nextUpdateKey := newECKey(t)
+
+		resp, err := r.Update(&UpdateRequest{
+			DID:                  did,
+			Options:              opts,
+			Secret:               &Secret{UpdateKey: updateKey.public, NextUpdateKey: nextUpdateKey.public},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateAction, resp.DIDState.State)
+		require.Equal(t, ActionSignPayload, resp.DIDState.Action)
+		require.NotEmpty(t, resp.JobID)
+
+		signingRequest := resp.DIDState.SigningRequest[updateKeyID]
+		require.NotNil(t, signingRequest)
+		require.Equal(t, "ES256", signingRequest.Alg)
+		require.Equal(t, updateKeyID, signingRequest.KID)
+
+		// nothing is submitted until the client signs the payload
+		require.Len(t, processor.document(t, did).Services(), 1)
+
+		resp, err = r.Update(&UpdateRequest{
+			JobID:  resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{updateKeyID: updateKey.sign(t, signingRequest)}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Equal(t, did, resp.DIDState.DID)
+		require.Nil(t, resp.DIDState.Secret)
+
+		require.Len(t, processor.document(t, did).Services(), 2)
+
+		updateKey = nextUpdateKey
+	})
+
+	t.Run("update - invalid signature", func(t *testing.T) {
+		resp, err := r.Update(&UpdateRequest{
+			DID:                  did,
+			Options:              opts,
+			Secret:               &Secret{UpdateKey: updateKey.public, NextUpdateKey: newECKey(t).public},
+			DIDDocumentOperation: []string{OperationRemoveFromDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.NoError(t, err)
+
+		signature := newECKey(t).sign(t, resp.DIDState.SigningRequest[updateKeyID])
+
+		_, err = r.Update(&UpdateRequest{
+			JobID:  resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{updateKeyID: signature}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to check signature")
+		require.Len(t, processor.document(t, did).Services(), 2)
+
+		// the job is kept so that it can be retried with a corrected signature
+		signature = updateKey.sign(t, resp.DIDState.SigningRequest[updateKeyID])
+
+		resp2, err := r.Update(&UpdateRequest{
+			JobID:  resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{updateKeyID: signature}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp2.DIDState.State)
+		require.Len(t, processor.document(t, did).Services(), 1)
+
+		// the job is done
+		_, err = r.Update(&UpdateRequest{
+			JobID:  resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{updateKeyID: signature}},
+		})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, common.MapError(err).Status())
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		resp, err := r.Deactivate(&DeactivateRequest{
+			DID:     did,
+			Options: opts,
+			Secret:  &Secret{RecoveryKey: recoveryKey.public},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateAction, resp.DIDState.State)
+
+		signingRequest := resp.DIDState.SigningRequest[recoveryKeyID]
+		require.NotNil(t, signingRequest)
+		require.Equal(t, "EdDSA", signingRequest.Alg)
+
+		resp, err = r.Deactivate(&DeactivateRequest{
+			JobID:  resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{recoveryKeyID: recoveryKey.sign(t, signingRequest)}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+
+		require.Nil(t, processor.document(t, did))
+	})
+}
+
+func TestRegistrar_JWKThumbprints(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	pc.Protocol.JWKThumbprints = true
+	pc.CurrentVersion = mocks.GetProtocolVersion(pc.Protocol)
+
+	processor := newTestProcessorWithProtocol(t, pc)
+	r := New(processor, processor.pc)
+
+	t.Run("internal secret mode", func(t *testing.T) {
+		resp, err := r.Create(&CreateRequest{DIDDocument: parseDoc(t, validDoc)})
+		require.NoError(t, err)
+
+		did := resp.DIDState.DID
+		secret := resp.DIDState.Secret
+
+		resp, err = r.Update(&UpdateRequest{
+			DID:                  did,
+			Secret:               &Secret{UpdateKey: secret.UpdateKey},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Len(t, processor.document(t, did).Services(), 2)
+
+		resp, err = r.Update(&UpdateRequest{
+			DID:         did,
+			Secret:      &Secret{RecoveryKey: secret.RecoveryKey},
+			DIDDocument: []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Len(t, processor.document(t, did).Services(), 1)
+
+		resp, err = r.Deactivate(&DeactivateRequest{
+			DID:    did,
+			Secret: &Secret{RecoveryKey: resp.DIDState.Secret.RecoveryKey},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Nil(t, processor.document(t, did))
+	})
+
+	t.Run("client secret mode", func(t *testing.T) {
+		opts := &Options{ClientSecretMode: true}
+
+		updateKey := newECKey(t)
+
+		resp, err := r.Create(&CreateRequest{
+			Options:     opts,
+			Secret:      &Secret{NextUpdateKey: updateKey.public, NextRecoveryKey: newEDKey(t).public},
+			DIDDocument: parseDoc(t, validDoc),
+		})
+		require.NoError(t, err)
+
+		did := resp.DIDState.DID
+
+		resp, err = r.Update(&UpdateRequest{
+			DID:                  did,
+			Options:              opts,
+			Secret:               &Secret{UpdateKey: updateKey.public, NextUpdateKey: newECKey(t).public},
+			DIDDocumentOperation: []string{OperationAddToDIDDocument},
+			DIDDocument:          []document.Document{parseDoc(t, serviceDoc)},
+		})
+		require.NoError(t, err)
+
+		signingRequest := resp.DIDState.SigningRequest[updateKeyID]
+		require.NotNil(t, signingRequest)
+
+		// the kid of the payload which is signed by the client is the JWK thumbprint of the update key
+		jwk := &jws.JWK{}
+		require.NoError(t, json.Unmarshal(updateKey.public, jwk))
+
+		thumbprint, err := jwk.Thumbprint()
+		require.NoError(t, err)
+
+		signingInput, err := base64.RawURLEncoding.DecodeString(signingRequest.Payload)
+		require.NoError(t, err)
+
+		protectedHeaders, err := base64.RawURLEncoding.DecodeString(strings.Split(string(signingInput), ".")[0])
+		require.NoError(t, err)
+
+		headers := jws.Headers{}
+		require.NoError(t, json.Unmarshal(protectedHeaders, &headers))
+
+		kid, ok := headers.KeyID()
+		require.True(t, ok)
+		require.Equal(t, thumbprint, kid)
+
+		resp, err = r.Update(&UpdateRequest{
+			JobID:  resp.JobID,
+			Secret: &Secret{SigningResponse: map[string]*SigningResponse{updateKeyID: updateKey.sign(t, signingRequest)}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, StateFinished, resp.DIDState.State)
+		require.Len(t, processor.document(t, did).Services(), 2)
+	})
+}
+
+func TestRegistrar_Errors(t *testing.T) {
+	processor := newTestProcessor(t)
+	r := New(processor, processor.pc)
+
+	key := newECKey(t)
+
+	t.Run("create", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			req    *CreateRequest
+			errMsg string
+		}{
+			{"missing DID document", &CreateRequest{}, "missing DID document"},
+			{"job ID", &CreateRequest{JobID: "123"}, "no job to continue"},
+			{
+				"missing next key",
+				&CreateRequest{Options: &Options{ClientSecretMode: true}, DIDDocument: parseDoc(t, validDoc)},
+				"missing next updateKey in secret",
+			},
+			{
+				"private key in client secret mode",
+				&CreateRequest{
+					Options:     &Options{ClientSecretMode: true},
+					Secret:      &Secret{NextUpdateKey: key.private, NextRecoveryKey: key.public},
+					DIDDocument: parseDoc(t, validDoc),
+				},
+				"must not contain the private key",
+			},
+			{"invalid document", &CreateRequest{DIDDocument: document.Document{"publicKey": "invalid"}}, "invalid add public keys value"},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := r.Create(tc.req)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+				require.Equal(t, http.StatusBadRequest, common.MapError(err).Status())
+			})
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		did := namespace + docutil.NamespaceDelimiter + "suffix"
+		doc := []document.Document{parseDoc(t, serviceDoc)}
+
+		tests := []struct {
+			name   string
+			req    *UpdateRequest
+			errMsg string
+		}{
+			{"missing DID", &UpdateRequest{}, "missing DID"},
+			{"invalid namespace", &UpdateRequest{DID: "did:other:suffix"}, "must start with namespace"},
+			{"missing suffix", &UpdateRequest{DID: namespace + ":"}, "must start with namespace"},
+			{"document count", &UpdateRequest{DID: did}, "must match the number of DID documents"},
+			{
+				"set combined with other operations",
+				&UpdateRequest{
+					DID:                  did,
+					DIDDocumentOperation: []string{OperationSetDIDDocument, OperationAddToDIDDocument},
+					DIDDocument:          append(doc, doc...),
+				},
+				"cannot be combined",
+			},
+			{
+				"set after other operations",
+				&UpdateRequest{
+					DID:                  did,
+					DIDDocumentOperation: []string{OperationAddToDIDDocument, OperationSetDIDDocument},
+					DIDDocument:          append(doc, doc...),
+				},
+				"cannot be combined",
+			},
+			{
+				"unsupported operation",
+				&UpdateRequest{DID: did, DIDDocumentOperation: []string{"replace"}, DIDDocument: doc},
+				"DID document operation [replace] is not supported",
+			},
+			{
+				"no patches",
+				&UpdateRequest{DID: did, DIDDocumentOperation: []string{OperationAddToDIDDocument}, DIDDocument: []document.Document{{}}},
+				"don't contain any public keys or services",
+			},
+			{
+				"missing update key",
+				&UpdateRequest{DID: did, DIDDocumentOperation: []string{OperationAddToDIDDocument}, DIDDocument: doc},
+				"missing updateKey in secret",
+			},
+			{
+				"public update key in internal secret mode",
+				&UpdateRequest{
+					DID:                  did,
+					Secret:               &Secret{UpdateKey: key.public},
+					DIDDocumentOperation: []string{OperationAddToDIDDocument},
+					DIDDocument:          doc,
+				},
+				"updateKey must contain the private key",
+			},
+			{
+				"missing recovery key",
+				&UpdateRequest{DID: did, DIDDocument: doc},
+				"missing recoveryKey in secret",
+			},
+			{
+				"missing signature",
+				&UpdateRequest{JobID: "123"},
+				"job [123] not found or expired",
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := r.Update(tc.req)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		_, err := r.Deactivate(&DeactivateRequest{DID: namespace + ":suffix", Secret: &Secret{RecoveryKey: json.RawMessage(`{`)}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid recoveryKey")
+
+		_, err = r.Deactivate(&DeactivateRequest{DID: namespace + ":suffix", Secret: &Secret{RecoveryKey: key.private}})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, common.MapError(err).Status())
+	})
+
+	t.Run("missing signing response", func(t *testing.T) {
+		resp, err := r.Deactivate(&DeactivateRequest{
+			DID:     namespace + ":suffix",
+			Options: &Options{ClientSecretMode: true},
+			Secret:  &Secret{RecoveryKey: key.public},
+		})
+		require.NoError(t, err)
+
+		_, err = r.Deactivate(&DeactivateRequest{JobID: resp.JobID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing signing response [recoveryKey]")
+
+		// the DID doesn't exist so the job fails permanently and is removed
+		secret := &Secret{SigningResponse: map[string]*SigningResponse{
+			recoveryKeyID: key.sign(t, resp.DIDState.SigningRequest[recoveryKeyID]),
+		}}
+
+		_, err = r.Deactivate(&DeactivateRequest{JobID: resp.JobID, Secret: secret})
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, common.MapError(err).Status())
+		require.NotContains(t, err.Error(), "not found or expired")
+
+		_, err = r.Deactivate(&DeactivateRequest{JobID: resp.JobID, Secret: secret})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found or expired")
+	})
+
+	t.Run("job in progress", func(t *testing.T) {
+		resp, err := r.Deactivate(&DeactivateRequest{
+			DID:     namespace + ":suffix",
+			Options: &Options{ClientSecretMode: true},
+			Secret:  &Secret{RecoveryKey: key.public},
+		})
+		require.NoError(t, err)
+
+		_, err = r.jobs.acquire(resp.JobID)
+		require.NoError(t, err)
+
+		_, err = r.Deactivate(&DeactivateRequest{JobID: resp.JobID})
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, common.MapError(err).Status())
+
+		r.jobs.release(resp.JobID)
+
+		_, err = r.Deactivate(&DeactivateRequest{JobID: resp.JobID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "missing signing response [recoveryKey]")
+	})
+
+	t.Run("protocol error", func(t *testing.T) {
+		pc := mocks.NewMockProtocolClient()
+		pc.Err = errors.New("injected protocol error")
+
+		_, err := New(processor, pc).Create(&CreateRequest{DIDDocument: parseDoc(t, validDoc)})
+		require.Error(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, common.MapError(err).Status())
+	})
+}
+
+func TestRegistrar_JobExpiry(t *testing.T) {
+	processor := newTestProcessor(t)
+	r := New(processor, processor.pc, WithJobTTL(time.Minute))
+
+	now := time.Now()
+	r.jobs.now = func() time.Time { return now }
+
+	key := newECKey(t)
+
+	resp, err := r.Deactivate(&DeactivateRequest{
+		DID:     namespace + ":suffix",
+		Options: &Options{ClientSecretMode: true},
+		Secret:  &Secret{RecoveryKey: key.public},
+	})
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+
+	_, err = r.Deactivate(&DeactivateRequest{
+		JobID:  resp.JobID,
+		Secret: &Secret{SigningResponse: map[string]*SigningResponse{recoveryKeyID: key.sign(t, resp.DIDState.SigningRequest[recoveryKeyID])}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found or expired")
+}
+
+type testKey struct {
+	private json.RawMessage
+	public  json.RawMessage
+	signer  client.Signer
+}
+
+func (k *testKey) sign(t *testing.T, req *SigningRequest) *SigningResponse {
+	t.Helper()
+
+	payload, err := base64.RawURLEncoding.DecodeString(req.Payload)
+	require.NoError(t, err)
+
+	signature, err := k.signer.Sign(payload)
+	require.NoError(t, err)
+
+	return &SigningResponse{Signature: base64.RawURLEncoding.EncodeToString(signature)}
+}
+
+func newECKey(t *testing.T) *testKey {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return newTestKey(t, privateKey, &privateKey.PublicKey, ecsigner.New(privateKey, "ES256", "key"))
+}
+
+func newEDKey(t *testing.T) *testKey {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return newTestKey(t, privateKey, publicKey, edsigner.New(privateKey, "EdDSA", "key"))
+}
+
+func newTestKey(t *testing.T, privateKey, publicKey interface{}, signer client.Signer) *testKey {
+	t.Helper()
+
+	jwk, err := pubkey.GetPublicKeyJWK(publicKey)
+	require.NoError(t, err)
+
+	public, err := json.Marshal(jwk)
+	require.NoError(t, err)
+
+	privateJWK := &internaljws.JWK{}
+	privateJWK.Key = privateKey
+
+	private, err := json.Marshal(privateJWK)
+	require.NoError(t, err)
+
+	return &testKey{private: private, public: public, signer: signer}
+}
+
+func requirePrivateKey(t *testing.T, raw json.RawMessage) {
+	t.Helper()
+
+	_, err := parsePrivateKey("key", raw)
+	require.NoError(t, err)
+}
+
+func parseDoc(t *testing.T, doc string) document.Document {
+	t.Helper()
+
+	d, err := document.FromBytes([]byte(doc))
+	require.NoError(t, err)
+
+	return d
+}
+
+// testProcessor applies the operations using the operation parser and applier (which verify the signatures
+// and commitments) and keeps the resolution model of each DID.
+type testProcessor struct {
+	pc      *mocks.MockProtocolClient
+	parser  *operationparser.Parser
+	applier *operationapplier.Applier
+	models  map[string]*protocol.ResolutionModel
+}
+
+func newTestProcessor(t *testing.T) *testProcessor {
+	t.Helper()
+
+	return newTestProcessorWithProtocol(t, mocks.NewMockProtocolClient())
+}
+
+func newTestProcessorWithProtocol(t *testing.T, pc *mocks.MockProtocolClient) *testProcessor {
+	t.Helper()
+
+	parser, err := operationparser.New(pc.Protocol)
+	require.NoError(t, err)
+
+	applier, err := operationapplier.New(pc.Protocol, parser, doccomposer.New())
+	require.NoError(t, err)
+
+	return &testProcessor{
+		pc:      pc,
+		parser:  parser,
+		applier: applier,
+		models:  make(map[string]*protocol.ResolutionModel),
+	}
+}
+
+func (p *testProcessor) Namespace() string {
+	return namespace
+}
+
+func (p *testProcessor) ProcessOperation(request []byte, _ uint64) (*document.ResolutionResult, error) {
+	op, err := p.parser.Parse(namespace, request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
+	}
+
+	rm, ok := p.models[op.UniqueSuffix]
+	if !ok {
+		if op.Type != operation.TypeCreate {
+			return nil, document.ErrNotFound
+		}
+
+		rm = &protocol.ResolutionModel{}
+	} else if err := p.checkCommitment(op.Type, request, rm); err != nil {
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
+	}
+
+	rm, err = p.applier.Apply(&operation.AnchoredOperation{
+		Type:            op.Type,
+		UniqueSuffix:    op.UniqueSuffix,
+		OperationBuffer: op.OperationBuffer,
+	}, rm)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", operation.ErrInvalidOperation, err.Error())
+	}
+
+	p.models[op.UniqueSuffix] = rm
+
+	if op.Type != operation.TypeCreate {
+		return nil, nil
+	}
+
+	doc := rm.Doc
+	doc[document.IDProperty] = op.ID
+
+	return &document.ResolutionResult{
+		Document: doc,
+		MethodMetadata: document.MethodMetadata{
+			UpdateCommitment:   rm.UpdateCommitment,
+			RecoveryCommitment: rm.RecoveryCommitment,
+		},
+	}, nil
+}
+
+// checkCommitment checks that the revealed key matches the commitment of the previous operation. (This check
+// is performed by the operation processor when the operations are resolved.)
+func (p *testProcessor) checkCommitment(opType operation.Type, request []byte, rm *protocol.ResolutionModel) error {
+	if opType == operation.TypeCreate {
+		return errors.New("document already exists")
+	}
+
+	if rm.Doc == nil {
+		return document.ErrDeactivated
+	}
+
+	reveal, err := p.parser.GetRevealValue(request)
+	if err != nil {
+		return err
+	}
+
+	c, err := commitment.CalculateForProtocol(reveal, p.pc.Protocol)
+	if err != nil {
+		return err
+	}
+
+	expected := rm.RecoveryCommitment
+	if opType == operation.TypeUpdate {
+		expected = rm.UpdateCommitment
+	}
+
+	if c != expected {
+		return errors.New("commitment doesn't match")
+	}
+
+	return nil
+}
+
+func (p *testProcessor) document(t *testing.T, did string) document.Document {
+	t.Helper()
+
+	rm, ok := p.models[did[len(namespace)+1:]]
+	require.True(t, ok)
+
+	return rm.Doc
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registrar
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	internaljws "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+)
+
+// newSecret returns the secret containing the keys which were generated for the next operations.
+// Nil is returned if no keys were generated (client-managed secret mode).
+func newSecret(updateKey, recoveryKey *internaljws.JWK) *Secret {
+	if updateKey == nil && recoveryKey == nil {
+		return nil
+	}
+
+	return &Secret{
+		UpdateKey:   marshalKey(updateKey),
+		RecoveryKey: marshalKey(recoveryKey),
+	}
+}
+
+func marshalKey(jwk *internaljws.JWK) json.RawMessage {
+	if jwk == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(jwk)
+	if err != nil {
+		// should never happen since the key was generated by the registrar
+		logger.Errorf("Unable to marshal key: %s", err)
+
+		return nil
+	}
+
+	return b
+}
+
+func (s *Secret) updateKey() json.RawMessage {
+	if s == nil {
+		return nil
+	}
+
+	return s.UpdateKey
+}
+
+func (s *Secret) recoveryKey() json.RawMessage {
+	if s == nil {
+		return nil
+	}
+
+	return s.RecoveryKey
+}
+
+func (s *Secret) updateOrRecoveryKey(kid string) json.RawMessage {
+	if kid == recoveryKeyID {
+		return s.recoveryKey()
+	}
+
+	return s.updateKey()
+}
+
+func (s *Secret) nextUpdateKey() json.RawMessage {
+	if s == nil {
+		return nil
+	}
+
+	return s.NextUpdateKey
+}
+
+func (s *Secret) nextRecoveryKey() json.RawMessage {
+	if s == nil {
+		return nil
+	}
+
+	return s.NextRecoveryKey
+}
+
+// signature returns the decoded signature for the given signing request.
+func (s *Secret) signature(requestID string) ([]byte, error) {
+	if s == nil || s.SigningResponse == nil || s.SigningResponse[requestID] == nil {
+		return nil, badRequest("missing signing response [%s] in secret", requestID)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(s.SigningResponse[requestID].Signature)
+	if err != nil {
+		return nil, badRequest("invalid signature in signing response [%s]: %s", requestID, err.Error())
+	}
+
+	if len(signature) == 0 {
+		return nil, badRequest("empty signature in signing response [%s]", requestID)
+	}
+
+	return signature, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+)
+
+const (
+	// RequestIDHeader is the header which contains the request ID. A request ID provided by the client
+	// is used if it is valid, otherwise a new request ID is generated.
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+	requestIDSize      = 16
+
+	corsAllowedMethods = "GET, POST, PUT, DELETE, OPTIONS"
+	corsAllowedHeaders = "Accept, Authorization, Content-Type, " + RequestIDHeader
+	corsMaxAge         = "600"
+	anyOrigin          = "*"
+)
+
+type requestIDKey struct{}
+
+// RequestID returns the request ID from the given (request) context.
+func RequestID(ctx context.Context) string {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return id
+}
+
+// requestID adds the request ID to the request context and to the response headers.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		rw.Header().Set(RequestIDHeader, id)
+
+		next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), requestIDKey{}, id)))
+	})
+}
+
+// recovery recovers from a panic in the handler and returns an internal server error.
+func recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		defer func() {
+			if r := recover(); r != nil {
+				if r == http.ErrAbortHandler { //nolint:errorlint
+					panic(r)
+				}
+
+				logger.Errorf("Recovered from panic in handler for %s %s (request ID [%s]): %v",
+					req.Method, req.URL.Path, rw.Header().Get(RequestIDHeader), r)
+
+				common.WriteError(rw, http.StatusInternalServerError,
+					common.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("internal server error")))
+			}
+		}()
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// cors adds the CORS headers for the allowed origins and responds to preflight requests.
+func cors(allowedOrigins []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		origin := req.Header.Get("Origin")
+		if origin == "" || !isAllowedOrigin(allowedOrigins, origin) {
+			next.ServeHTTP(rw, req)
+
+			return
+		}
+
+		rw.Header().Set("Access-Control-Allow-Origin", origin)
+		rw.Header().Add("Vary", "Origin")
+		rw.Header().Set("Access-Control-Expose-Headers", RequestIDHeader)
+
+		if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+			rw.Header().Set("Access-Control-Allow-Methods", corsAllowedMethods)
+			rw.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+			rw.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			rw.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// bodySizeLimit rejects requests with a body larger than the limit with status 413 (Request Entity Too Large).
+func bodySizeLimit(limit func() (int64, error), next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Body == nil || req.Body == http.NoBody {
+			next.ServeHTTP(rw, req)
+
+			return
+		}
+
+		maxSize, err := limit()
+		if err != nil {
+			httpErr := common.MapError(err)
+			common.WriteError(rw, httpErr.Status(), httpErr)
+
+			return
+		}
+
+		if req.ContentLength > maxSize {
+			writeTooLarge(rw, maxSize)
+
+			return
+		}
+
+		// the content length may be unknown (or wrong) so the body is read up to the limit
+		body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxSize+1))
+		if err != nil {
+			common.WriteError(rw, http.StatusBadRequest, fmt.Errorf("read request body: %w", err))
+
+			return
+		}
+
+		if int64(len(body)) > maxSize {
+			writeTooLarge(rw, maxSize)
+
+			return
+		}
+
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+func writeTooLarge(rw http.ResponseWriter, maxSize int64) {
+	common.WriteError(rw, http.StatusRequestEntityTooLarge,
+		fmt.Errorf("request body exceeds the maximum size of %d bytes", maxSize))
+}
+
+func isAllowedOrigin(allowedOrigins []string, origin string) bool {
+	for _, o := range allowedOrigins {
+		if o == anyOrigin || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, requestIDSize)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		// should never happen
+		logger.Warnf("Unable to generate request ID: %s", err)
+
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
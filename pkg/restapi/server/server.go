@@ -0,0 +1,293 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/diddochandler"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+var logger = log.New("sidetree-core-restapi-server")
+
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultMaxBodySize       = 1 << 20 // 1 MiB
+)
+
+// DocumentHandler is the document handler which serves the DID document API.
+type DocumentHandler interface {
+	dochandler.Processor
+	dochandler.Resolver
+	dochandler.BatchResolver
+	dochandler.DIDFinder
+}
+
+// DIDDocHandlers returns the DID document API handlers (operations, resolution, batch resolution and lookup)
+// for the given document handler. The update options (e.g. authentication and authorization) apply to
+// the operations endpoint only; the resolution endpoints are public.
+func DIDDocHandlers(basePath string, dh DocumentHandler, pc protocol.Client,
+	opts ...dochandler.UpdateOption) []common.HTTPHandler {
+	return []common.HTTPHandler{
+		diddochandler.NewUpdateHandler(basePath, dh, pc, opts...),
+		diddochandler.NewResolveHandler(basePath, dh),
+		diddochandler.NewBatchResolveHandler(basePath, dh, 0),
+		diddochandler.NewLookupHandler(basePath, dh),
+	}
+}
+
+// Server serves the REST handlers. The handlers are wrapped with the following middleware (outermost first):
+// panic recovery, request ID, CORS (if allowed origins are configured) and request body size limit.
+//
+// The request body size of the operations route (diddochandler.UpdateHandler) is limited to the maximum
+// operation size; the request body size of all other routes is limited to the maximum body size.
+type Server struct {
+	addr              string
+	handlers          []common.HTTPHandler
+	protocol          protocol.Client
+	maxBodySize       int64
+	maxOperationSize  int64
+	allowedOrigins    []string
+	tlsConfig         *tls.Config
+	certFile          string
+	keyFile           string
+	readHeaderTimeout time.Duration
+	readTimeout       time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+
+	mutex      sync.Mutex
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// Option is a server option.
+type Option func(opts *Server)
+
+// WithProtocolClient limits the size of operation request bodies to the maximum operation size of the current
+// protocol version (unless an explicit limit is set using WithMaxOperationSize).
+func WithProtocolClient(pc protocol.Client) Option {
+	return func(opts *Server) {
+		opts.protocol = pc
+	}
+}
+
+// WithMaxOperationSize limits the size of operation request bodies to the given number of bytes.
+func WithMaxOperationSize(size int64) Option {
+	return func(opts *Server) {
+		opts.maxOperationSize = size
+	}
+}
+
+// WithMaxBodySize limits the size of request bodies of all routes other than the operations route to the given
+// number of bytes (default 1 MiB). Zero means no limit. It is also the limit of operation request bodies if
+// neither a protocol client nor a maximum operation size is configured.
+func WithMaxBodySize(size int64) Option {
+	return func(opts *Server) {
+		opts.maxBodySize = size
+	}
+}
+
+// WithCORS enables CORS for the given origins ("*" allows any origin).
+func WithCORS(allowedOrigins ...string) Option {
+	return func(opts *Server) {
+		opts.allowedOrigins = allowedOrigins
+	}
+}
+
+// WithTLS serves HTTPS using the given certificate and key files.
+func WithTLS(certFile, keyFile string) Option {
+	return func(opts *Server) {
+		opts.certFile = certFile
+		opts.keyFile = keyFile
+	}
+}
+
+// WithTLSConfig serves HTTPS using the given TLS configuration. The certificates may be provided
+// in the configuration or using WithTLS.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(opts *Server) {
+		opts.tlsConfig = cfg
+	}
+}
+
+// WithTimeouts sets the HTTP server read, write and idle timeouts. Zero means no timeout.
+func WithTimeouts(read, write, idle time.Duration) Option {
+	return func(opts *Server) {
+		opts.readTimeout = read
+		opts.writeTimeout = write
+		opts.idleTimeout = idle
+	}
+}
+
+// New returns a new server which serves the given handlers on the given address.
+func New(addr string, handlers []common.HTTPHandler, opts ...Option) *Server {
+	s := &Server{
+		addr:              addr,
+		handlers:          handlers,
+		maxBodySize:       defaultMaxBodySize,
+		readHeaderTimeout: defaultReadHeaderTimeout,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Handler returns the HTTP handler (router and middleware) for the configured handlers.
+func (s *Server) Handler() http.Handler {
+	router := mux.NewRouter()
+
+	for _, h := range s.handlers {
+		logger.Debugf("Registering handler: %s %s", h.Method(), h.Path())
+
+		router.Handle(h.Path(), s.limitBodySize(h)).Methods(h.Method())
+	}
+
+	var handler http.Handler = router
+
+	if len(s.allowedOrigins) > 0 {
+		handler = cors(s.allowedOrigins, handler)
+	}
+
+	return recovery(requestID(handler))
+}
+
+// Start starts listening on the configured address and serves requests in the background.
+// An error is returned if the server is already started or if the listener can't be created.
+func (s *Server) Start() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.httpServer != nil {
+		return errors.New("server already started")
+	}
+
+	httpServer := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		TLSConfig:         s.tlsConfig,
+		ReadHeaderTimeout: s.readHeaderTimeout,
+		ReadTimeout:       s.readTimeout,
+		WriteTimeout:      s.writeTimeout,
+		IdleTimeout:       s.idleTimeout,
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen on [%s]: %w", s.addr, err)
+	}
+
+	s.httpServer = httpServer
+	s.listener = listener
+
+	go s.serve(httpServer, listener)
+
+	logger.Infof("Started REST server on [%s] (TLS: %t)", listener.Addr(), s.isTLS())
+
+	return nil
+}
+
+// Addr returns the address that the server is listening on (or the configured address if the server
+// hasn't been started).
+func (s *Server) Addr() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+
+	return s.addr
+}
+
+// Stop gracefully shuts down the server: the listener is closed and in-flight requests are allowed
+// to complete until the given context is done.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mutex.Lock()
+	httpServer := s.httpServer
+	s.httpServer = nil
+	s.listener = nil
+	s.mutex.Unlock()
+
+	if httpServer == nil {
+		return errors.New("server not started")
+	}
+
+	logger.Infof("Stopping REST server on [%s]", s.addr)
+
+	err := httpServer.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("shutdown REST server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) serve(httpServer *http.Server, listener net.Listener) {
+	var err error
+
+	if s.isTLS() {
+		err = httpServer.ServeTLS(listener, s.certFile, s.keyFile)
+	} else {
+		err = httpServer.Serve(listener)
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Errorf("REST server on [%s] stopped: %s", s.addr, err)
+	}
+}
+
+func (s *Server) isTLS() bool {
+	return s.certFile != "" || s.tlsConfig != nil
+}
+
+// limitBodySize wraps the handler with the request body size limit of its route.
+func (s *Server) limitBodySize(h common.HTTPHandler) http.Handler {
+	handler := http.HandlerFunc(h.Handler())
+
+	if _, ok := h.(*diddochandler.UpdateHandler); ok && (s.maxOperationSize > 0 || s.protocol != nil) {
+		return bodySizeLimit(s.operationSizeLimit, handler)
+	}
+
+	if s.maxBodySize <= 0 {
+		return handler
+	}
+
+	return bodySizeLimit(func() (int64, error) { return s.maxBodySize, nil }, handler)
+}
+
+// operationSizeLimit returns the maximum operation request body size. The explicit limit takes precedence over
+// the maximum operation size of the current protocol.
+func (s *Server) operationSizeLimit() (int64, error) {
+	if s.maxOperationSize > 0 {
+		return s.maxOperationSize, nil
+	}
+
+	pv, err := s.protocol.Current()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", protocol.ErrUnavailable, err.Error())
+	}
+
+	return int64(pv.Protocol().MaxOperationSize), nil
+}
@@ -0,0 +1,339 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+const (
+	namespace = "did:sidetree"
+	basePath  = "/sidetree/0.0.1"
+	did       = namespace + ":EiDOQXC2GnoVyHwIRbjhLx_cNc6vmZaS04SZjZdlLLAPRg"
+)
+
+func TestServer_Handler(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	s := New(":0", DIDDocHandlers(basePath, dh, pc), WithProtocolClient(pc), WithCORS("https://example.com"))
+
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	t.Run("routes", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, ts.URL+basePath+"/identifiers/"+did, nil, nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.Equal(t, common.ErrorCodeNotFound, readProblem(t, resp).Code)
+
+		resp = doRequest(t, http.MethodGet, ts.URL+basePath+"/lookup?keyId=key1", nil, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		resp = doRequest(t, http.MethodGet, ts.URL+basePath+"/operations", nil, nil)
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("request ID", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, ts.URL+basePath+"/identifiers/"+did, nil, nil)
+		require.NoError(t, resp.Body.Close())
+		require.Len(t, resp.Header.Get(RequestIDHeader), 2*requestIDSize)
+
+		resp = doRequest(t, http.MethodGet, ts.URL+basePath+"/identifiers/"+did, nil,
+			map[string]string{RequestIDHeader: "my-request-id"})
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, "my-request-id", resp.Header.Get(RequestIDHeader))
+
+		resp = doRequest(t, http.MethodGet, ts.URL+basePath+"/identifiers/"+did, nil,
+			map[string]string{RequestIDHeader: strings.Repeat("x", maxRequestIDLength+1)})
+		require.NoError(t, resp.Body.Close())
+		require.Len(t, resp.Header.Get(RequestIDHeader), 2*requestIDSize)
+	})
+
+	t.Run("body size limit", func(t *testing.T) {
+		resp := doRequest(t, http.MethodPost, ts.URL+basePath+"/operations",
+			bytes.NewReader(make([]byte, mocks.MaxOperationByteSize+1)), nil)
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		require.Equal(t, common.ErrorCodeRequestTooLarge, readProblem(t, resp).Code)
+
+		// unknown content length
+		resp = doRequest(t, http.MethodPost, ts.URL+basePath+"/operations",
+			io.MultiReader(strings.NewReader("{"), bytes.NewReader(make([]byte, mocks.MaxOperationByteSize))), nil)
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		// the body is passed to the handler if it is within the limit
+		resp = doRequest(t, http.MethodPost, ts.URL+basePath+"/operations", strings.NewReader("{}"), nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, common.ErrorCodeInvalidOperation, readProblem(t, resp).Code)
+	})
+
+	t.Run("CORS", func(t *testing.T) {
+		resp := doRequest(t, http.MethodOptions, ts.URL+basePath+"/operations", nil, map[string]string{
+			"Origin":                        "https://example.com",
+			"Access-Control-Request-Method": http.MethodPost,
+		})
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, "https://example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), http.MethodPost)
+		require.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "Content-Type")
+
+		resp = doRequest(t, http.MethodGet, ts.URL+basePath+"/identifiers/"+did, nil,
+			map[string]string{"Origin": "https://example.com"})
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, "https://example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, RequestIDHeader, resp.Header.Get("Access-Control-Expose-Headers"))
+
+		resp = doRequest(t, http.MethodGet, ts.URL+basePath+"/identifiers/"+did, nil,
+			map[string]string{"Origin": "https://other.com"})
+		require.NoError(t, resp.Body.Close())
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestServer_BodySizeLimit(t *testing.T) {
+	pc := mocks.NewMockProtocolClient()
+	dh := mocks.NewMockDocumentHandler().WithNamespace(namespace).WithProtocolClient(pc)
+
+	t.Run("explicit operation size limit", func(t *testing.T) {
+		ts := httptest.NewServer(New(":0", DIDDocHandlers(basePath, dh, pc),
+			WithProtocolClient(pc), WithMaxOperationSize(10)).Handler())
+		defer ts.Close()
+
+		resp := doRequest(t, http.MethodPost, ts.URL+basePath+"/operations", strings.NewReader("01234567890"), nil)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("batch resolution is not limited to the maximum operation size", func(t *testing.T) {
+		ts := httptest.NewServer(New(":0", DIDDocHandlers(basePath, dh, pc), WithProtocolClient(pc)).Handler())
+		defer ts.Close()
+
+		request := &dochandler.BatchResolutionRequest{IDs: make([]string, mocks.MaxOperationByteSize/len(did)+1)}
+		for i := range request.IDs {
+			request.IDs[i] = did
+		}
+
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		require.Greater(t, len(body), mocks.MaxOperationByteSize)
+
+		resp := doRequest(t, http.MethodPost, ts.URL+basePath+"/identifiers/resolve", bytes.NewReader(body), nil)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("explicit body size limit", func(t *testing.T) {
+		ts := httptest.NewServer(New(":0", DIDDocHandlers(basePath, dh, pc),
+			WithProtocolClient(pc), WithMaxBodySize(10)).Handler())
+		defer ts.Close()
+
+		resp := doRequest(t, http.MethodPost, ts.URL+basePath+"/identifiers/resolve", strings.NewReader("01234567890"), nil)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+		// the operations route is limited to the maximum operation size
+		resp = doRequest(t, http.MethodPost, ts.URL+basePath+"/operations", strings.NewReader("{}"), nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, common.ErrorCodeInvalidOperation, readProblem(t, resp).Code)
+	})
+
+	t.Run("no protocol client", func(t *testing.T) {
+		ts := httptest.NewServer(New(":0", DIDDocHandlers(basePath, dh, pc), WithMaxBodySize(10)).Handler())
+		defer ts.Close()
+
+		resp := doRequest(t, http.MethodPost, ts.URL+basePath+"/operations", strings.NewReader("01234567890"), nil)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("protocol unavailable", func(t *testing.T) {
+		errPC := mocks.NewMockProtocolClient()
+		errPC.Err = errors.New("injected protocol error")
+
+		ts := httptest.NewServer(New(":0", DIDDocHandlers(basePath, dh, pc), WithProtocolClient(errPC)).Handler())
+		defer ts.Close()
+
+		resp := doRequest(t, http.MethodPost, ts.URL+basePath+"/operations", strings.NewReader("{}"), nil)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, common.ErrorCodeProtocolUnavailable, readProblem(t, resp).Code)
+	})
+}
+
+func TestServer_Recovery(t *testing.T) {
+	var requestID string
+
+	h := &testHandler{path: "/panic", method: http.MethodGet, handler: func(rw http.ResponseWriter, req *http.Request) {
+		requestID = RequestID(req.Context())
+
+		panic("injected panic")
+	}}
+
+	ts := httptest.NewServer(New(":0", []common.HTTPHandler{h}).Handler())
+	defer ts.Close()
+
+	resp := doRequest(t, http.MethodGet, ts.URL+"/panic", nil, nil)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.NotEmpty(t, requestID)
+	require.Equal(t, requestID, resp.Header.Get(RequestIDHeader))
+	require.Equal(t, common.ErrorCodeInternal, readProblem(t, resp).Code)
+
+	require.Empty(t, RequestID(context.Background()))
+}
+
+func TestServer_StartStop(t *testing.T) {
+	h := &testHandler{path: "/hello", method: http.MethodGet, handler: func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}}
+
+	t.Run("success", func(t *testing.T) {
+		s := New("127.0.0.1:0", []common.HTTPHandler{h})
+		require.Equal(t, "127.0.0.1:0", s.Addr())
+
+		require.NoError(t, s.Start())
+		require.NotEqual(t, "127.0.0.1:0", s.Addr())
+
+		err := s.Start()
+		require.EqualError(t, err, "server already started")
+
+		resp := doRequest(t, http.MethodGet, "http://"+s.Addr()+"/hello", nil, nil)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.NoError(t, s.Stop(context.Background()))
+
+		err = s.Stop(context.Background())
+		require.EqualError(t, err, "server not started")
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		cert := newCertificate(t)
+
+		s := New("127.0.0.1:0", []common.HTTPHandler{h}, WithTLSConfig(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}), WithTimeouts(time.Second, time.Second, time.Second))
+
+		require.NoError(t, s.Start())
+
+		defer func() {
+			require.NoError(t, s.Stop(context.Background()))
+		}()
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		}}
+
+		resp, err := client.Get("https://" + s.Addr() + "/hello")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("TLS files", func(t *testing.T) {
+		s := New("127.0.0.1:0", []common.HTTPHandler{h}, WithTLS("cert.pem", "key.pem"))
+		require.True(t, s.isTLS())
+	})
+
+	t.Run("listen error", func(t *testing.T) {
+		err := New("invalid:address:0", nil).Start()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "listen on [invalid:address:0]")
+	})
+}
+
+type testHandler struct {
+	path    string
+	method  string
+	handler common.HTTPRequestHandler
+}
+
+func (h *testHandler) Path() string {
+	return h.path
+}
+
+func (h *testHandler) Method() string {
+	return h.method
+}
+
+func (h *testHandler) Handler() common.HTTPRequestHandler {
+	return h.handler
+}
+
+func doRequest(t *testing.T, method, url string, body io.Reader, headers map[string]string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, body) //nolint:noctx
+	require.NoError(t, err)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+func readProblem(t *testing.T, resp *http.Response) *common.ProblemDetails {
+	t.Helper()
+
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	var problem common.ProblemDetails
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+
+	return &problem
+}
+
+func newCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: privateKey}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package uniresolver
+
+import (
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContentTypeDIDLDJSON is the content type of the JSON-LD representation of a DID document.
+	ContentTypeDIDLDJSON = "application/did+ld+json"
+
+	// ContentTypeDIDJSON is the content type of the JSON representation of a DID document (without @context).
+	ContentTypeDIDJSON = "application/did+json"
+
+	// ContentTypeResolutionResult is the content type of a DID resolution result.
+	ContentTypeResolutionResult = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+	mediaTypeLDJSON          = "application/ld+json"
+	resolutionResultProfile  = "https://w3id.org/did-resolution"
+	defaultContentType       = ContentTypeDIDLDJSON
+	qualityParam             = "q"
+	profileParam             = "profile"
+	defaultQuality           = 1.0
+	wildcardMediaType        = "*/*"
+	applicationWildcardMedia = "application/*"
+)
+
+type mediaRange struct {
+	mediaType string
+	profile   string
+	quality   float64
+}
+
+// negotiateContentType returns the supported content type which best matches the given Accept header value.
+// The default content type (application/did+ld+json) is returned if the header is empty or accepts any
+// content type. False is returned if none of the accepted content types are supported.
+func negotiateContentType(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return defaultContentType, true
+	}
+
+	for _, r := range parseAccept(accept) {
+		if contentType, ok := r.contentType(); ok {
+			return contentType, true
+		}
+	}
+
+	return "", false
+}
+
+// parseAccept parses the media ranges in the given Accept header value and sorts them by quality (descending).
+// Media ranges which can't be parsed or which have a quality of 0 (i.e. not acceptable) are ignored.
+func parseAccept(accept string) []*mediaRange {
+	var ranges []*mediaRange
+
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			logger.Debugf("Ignoring invalid media range [%s]: %s", value, err)
+
+			continue
+		}
+
+		quality := defaultQuality
+
+		if q, ok := params[qualityParam]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				logger.Debugf("Ignoring media range [%s] with invalid quality: %s", value, err)
+
+				continue
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, &mediaRange{
+			mediaType: mediaType,
+			profile:   params[profileParam],
+			quality:   quality,
+		})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	return ranges
+}
+
+func (r *mediaRange) contentType() (string, bool) {
+	switch r.mediaType {
+	case ContentTypeDIDLDJSON, ContentTypeDIDJSON:
+		return r.mediaType, true
+	case mediaTypeLDJSON:
+		if r.profile == resolutionResultProfile {
+			return ContentTypeResolutionResult, true
+		}
+
+		return "", false
+	case wildcardMediaType, applicationWildcardMedia:
+		return defaultContentType, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package uniresolver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/trustbloc/edge-core/pkg/log"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/common"
+	"github.com/trustbloc/sidetree-core-go/pkg/restapi/dochandler"
+)
+
+var logger = log.New("sidetree-core-restapi-uniresolver")
+
+const (
+	resolutionContext = "https://w3id.org/did-resolution/v1"
+
+	acceptOption      = "accept"
+	versionIDOption   = "versionId"
+	versionTimeOption = "versionTime"
+)
+
+// Error codes returned in the DID resolution metadata.
+const (
+	ErrorInvalidDID                 = "invalidDid"
+	ErrorNotFound                   = "notFound"
+	ErrorRepresentationNotSupported = "representationNotSupported"
+	ErrorInvalidOptions             = "invalidOptions"
+	ErrorInternal                   = "internalError"
+	ErrorServiceUnavailable         = "serviceUnavailable"
+)
+
+// ResolutionResult is the DID resolution result as defined by the DID resolution specification.
+type ResolutionResult struct {
+	Context            string              `json:"@context"`
+	Document           document.Document   `json:"didDocument"`
+	ResolutionMetadata *ResolutionMetadata `json:"didResolutionMetadata"`
+	DocumentMetadata   *DocumentMetadata   `json:"didDocumentMetadata"`
+}
+
+// ResolutionMetadata contains metadata about the resolution process.
+type ResolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// DocumentMetadata contains metadata about the resolved DID document.
+type DocumentMetadata struct {
+	CanonicalID string                   `json:"canonicalId,omitempty"`
+	Deactivated bool                     `json:"deactivated,omitempty"`
+	Method      *document.MethodMetadata `json:"method,omitempty"`
+}
+
+// ResolveHandler resolves DID documents according to the DIF Universal Resolver driver contract. The representation
+// of the response is negotiated using the Accept header (or the 'accept' resolution option):
+//   - application/did+ld+json: the DID document (default)
+//   - application/did+json: the DID document without @context
+//   - application/ld+json;profile="https://w3id.org/did-resolution": the DID resolution result
+//
+// Errors are always returned as a DID resolution result.
+type ResolveHandler struct {
+	path     string
+	resolver dochandler.Resolver
+}
+
+// NewResolveHandler returns a new Universal Resolver driver handler which serves {basePath}/identifiers/{id}.
+func NewResolveHandler(basePath string, resolver dochandler.Resolver) *ResolveHandler {
+	return &ResolveHandler{
+		path:     fmt.Sprintf("%s/identifiers/{id}", basePath),
+		resolver: resolver,
+	}
+}
+
+// Path returns the context path.
+func (h *ResolveHandler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method.
+func (h *ResolveHandler) Method() string {
+	return http.MethodGet
+}
+
+// Handler returns the handler.
+func (h *ResolveHandler) Handler() common.HTTPRequestHandler {
+	return h.resolve
+}
+
+func (h *ResolveHandler) resolve(rw http.ResponseWriter, req *http.Request) {
+	params := req.URL.Query()
+
+	for _, option := range []string{versionIDOption, versionTimeOption} {
+		if params.Get(option) != "" {
+			writeError(rw, http.StatusBadRequest, ErrorInvalidOptions,
+				fmt.Sprintf("resolution option [%s] is not supported", option))
+
+			return
+		}
+	}
+
+	accept := req.Header.Get("Accept")
+	if a := params.Get(acceptOption); a != "" {
+		accept = a
+	}
+
+	contentType, ok := negotiateContentType(accept)
+	if !ok {
+		writeError(rw, http.StatusNotAcceptable, ErrorRepresentationNotSupported,
+			fmt.Sprintf("representation [%s] is not supported", accept))
+
+		return
+	}
+
+	id := getID(req)
+
+	logger.Debugf("Resolving DID [%s] with content type [%s]", id, contentType)
+
+	result, err := h.resolver.ResolveDocument(id)
+	if err != nil {
+		writeResolutionError(rw, id, err)
+
+		return
+	}
+
+	switch contentType {
+	case ContentTypeResolutionResult:
+		writeResponse(rw, http.StatusOK, contentType, newResolutionResult(result))
+	case ContentTypeDIDJSON:
+		writeResponse(rw, http.StatusOK, contentType, withoutContext(result.Document))
+	default:
+		writeResponse(rw, http.StatusOK, contentType, result.Document)
+	}
+}
+
+func newResolutionResult(result *document.ResolutionResult) *ResolutionResult {
+	methodMetadata := result.MethodMetadata
+
+	return &ResolutionResult{
+		Context:  resolutionContext,
+		Document: result.Document,
+		ResolutionMetadata: &ResolutionMetadata{
+			ContentType: ContentTypeDIDLDJSON,
+		},
+		DocumentMetadata: &DocumentMetadata{
+			CanonicalID: methodMetadata.CanonicalID,
+			Method:      &methodMetadata,
+		},
+	}
+}
+
+// withoutContext returns a copy of the given document without the @context property.
+func withoutContext(doc document.Document) document.Document {
+	jsonDoc := make(document.Document, len(doc))
+
+	for k, v := range doc {
+		if k != document.ContextProperty {
+			jsonDoc[k] = v
+		}
+	}
+
+	return jsonDoc
+}
+
+func writeResolutionError(rw http.ResponseWriter, id string, err error) {
+	if errors.Is(err, document.ErrDeactivated) {
+		writeResponse(rw, http.StatusGone, ContentTypeResolutionResult, &ResolutionResult{
+			Context:            resolutionContext,
+			ResolutionMetadata: &ResolutionMetadata{},
+			DocumentMetadata:   &DocumentMetadata{Deactivated: true},
+		})
+
+		return
+	}
+
+	httpErr := common.MapError(err)
+
+	if httpErr.Status() >= http.StatusInternalServerError {
+		logger.Errorf("server error resolving DID [%s]: %s", id, err.Error())
+	}
+
+	writeError(rw, httpErr.Status(), errorCode(httpErr.Status()), httpErr.PublicMessage())
+}
+
+// errorCode returns the DID resolution error code for the status of a mapped resolution error.
+func errorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorInvalidDID
+	case http.StatusNotFound:
+		return ErrorNotFound
+	case http.StatusServiceUnavailable:
+		return ErrorServiceUnavailable
+	default:
+		return ErrorInternal
+	}
+}
+
+func writeError(rw http.ResponseWriter, status int, code, message string) {
+	logger.Debugf("returning error status: %d, error: %s, message: %s", status, code, message)
+
+	writeResponse(rw, status, ContentTypeResolutionResult, &ResolutionResult{
+		Context: resolutionContext,
+		ResolutionMetadata: &ResolutionMetadata{
+			Error:   code,
+			Message: message,
+		},
+		DocumentMetadata: &DocumentMetadata{},
+	})
+}
+
+func writeResponse(rw http.ResponseWriter, status int, contentType string, v interface{}) {
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(status)
+
+	err := json.NewEncoder(rw).Encode(v)
+	if err != nil {
+		logger.Errorf("Unable to write response: %s", err)
+	}
+}
+
+var getID = func(req *http.Request) string {
+	return mux.Vars(req)["id"]
+}
@@ -0,0 +1,205 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package uniresolver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/document"
+)
+
+const (
+	basePath = "/1.0"
+	did      = "did:sidetree:EiDOQXC2GnoVyHwIRbjhLx_cNc6vmZaS04SZjZdlLLAPRg"
+)
+
+func TestNewResolveHandler(t *testing.T) {
+	h := NewResolveHandler(basePath, &mockResolver{})
+	require.Equal(t, basePath+"/identifiers/{id}", h.Path())
+	require.Equal(t, http.MethodGet, h.Method())
+	require.NotNil(t, h.Handler())
+}
+
+func TestResolveHandler_Resolve(t *testing.T) {
+	resolver := &mockResolver{
+		result: &document.ResolutionResult{
+			Document: document.Document{
+				document.ContextProperty: []interface{}{"https://www.w3.org/ns/did/v1"},
+				document.IDProperty:      did,
+			},
+			MethodMetadata: document.MethodMetadata{
+				UpdateCommitment:   "update",
+				RecoveryCommitment: "recovery",
+				Published:          true,
+				CanonicalID:        did,
+			},
+		},
+	}
+
+	t.Run("success - default representation", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/*", ContentTypeDIDLDJSON, "text/html, */*;q=0.8"} {
+			rw := resolve(t, resolver, did, accept, nil)
+			require.Equal(t, http.StatusOK, rw.Code, accept)
+			require.Equal(t, ContentTypeDIDLDJSON, rw.Header().Get("Content-Type"), accept)
+
+			var doc document.Document
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &doc))
+			require.Equal(t, did, doc.ID())
+			require.Contains(t, doc, document.ContextProperty)
+		}
+	})
+
+	t.Run("success - JSON representation", func(t *testing.T) {
+		rw := resolve(t, resolver, did, ContentTypeDIDJSON, nil)
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, ContentTypeDIDJSON, rw.Header().Get("Content-Type"))
+
+		var doc document.Document
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &doc))
+		require.Equal(t, did, doc.ID())
+		require.NotContains(t, doc, document.ContextProperty)
+
+		// the resolved document must not be modified
+		require.Contains(t, resolver.result.Document, document.ContextProperty)
+	})
+
+	t.Run("success - resolution result", func(t *testing.T) {
+		for _, accept := range []string{
+			ContentTypeResolutionResult,
+			`application/ld+json; profile="https://w3id.org/did-resolution"`,
+			`application/did+json;q=0.5, application/ld+json;profile="https://w3id.org/did-resolution"`,
+		} {
+			rw := resolve(t, resolver, did, accept, nil)
+			require.Equal(t, http.StatusOK, rw.Code, accept)
+			require.Equal(t, ContentTypeResolutionResult, rw.Header().Get("Content-Type"), accept)
+
+			var result ResolutionResult
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+			require.Equal(t, resolutionContext, result.Context)
+			require.Equal(t, did, result.Document.ID())
+			require.Equal(t, ContentTypeDIDLDJSON, result.ResolutionMetadata.ContentType)
+			require.Empty(t, result.ResolutionMetadata.Error)
+			require.Equal(t, did, result.DocumentMetadata.CanonicalID)
+			require.False(t, result.DocumentMetadata.Deactivated)
+			require.Equal(t, resolver.result.MethodMetadata, *result.DocumentMetadata.Method)
+		}
+	})
+
+	t.Run("success - accept resolution option", func(t *testing.T) {
+		rw := resolve(t, resolver, did, ContentTypeDIDLDJSON, url.Values{acceptOption: {ContentTypeDIDJSON}})
+		require.Equal(t, http.StatusOK, rw.Code)
+		require.Equal(t, ContentTypeDIDJSON, rw.Header().Get("Content-Type"))
+	})
+
+	t.Run("error - representation not supported", func(t *testing.T) {
+		for _, accept := range []string{"text/html", "application/ld+json", "application/did+json;q=0"} {
+			rw := resolve(t, resolver, did, accept, nil)
+			requireError(t, rw, http.StatusNotAcceptable, ErrorRepresentationNotSupported)
+		}
+	})
+
+	t.Run("error - unsupported resolution options", func(t *testing.T) {
+		for _, option := range []string{versionIDOption, versionTimeOption} {
+			rw := resolve(t, resolver, did, "", url.Values{option: {"1"}})
+			requireError(t, rw, http.StatusBadRequest, ErrorInvalidOptions)
+		}
+	})
+
+	t.Run("error - resolution errors", func(t *testing.T) {
+		tests := []struct {
+			err    error
+			status int
+			code   string
+		}{
+			{fmt.Errorf("%w: bad DID", document.ErrInvalidDID), http.StatusBadRequest, ErrorInvalidDID},
+			{fmt.Errorf("%w: injected store error", document.ErrNotFound), http.StatusNotFound, ErrorNotFound},
+			{fmt.Errorf("%w: injected", protocol.ErrUnavailable), http.StatusServiceUnavailable, ErrorServiceUnavailable},
+			{errors.New("injected resolver error"), http.StatusInternalServerError, ErrorInternal},
+		}
+
+		for _, tc := range tests {
+			rw := resolve(t, &mockResolver{err: tc.err}, did, ContentTypeDIDLDJSON, nil)
+			requireError(t, rw, tc.status, tc.code)
+
+			var result ResolutionResult
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+			require.NotContains(t, result.ResolutionMetadata.Message, "injected")
+		}
+	})
+
+	t.Run("deactivated", func(t *testing.T) {
+		rw := resolve(t, &mockResolver{err: document.ErrDeactivated}, did, ContentTypeDIDLDJSON, nil)
+		require.Equal(t, http.StatusGone, rw.Code)
+		require.Equal(t, ContentTypeResolutionResult, rw.Header().Get("Content-Type"))
+
+		var result ResolutionResult
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+		require.Nil(t, result.Document)
+		require.Empty(t, result.ResolutionMetadata.Error)
+		require.True(t, result.DocumentMetadata.Deactivated)
+	})
+}
+
+func resolve(t *testing.T, resolver *mockResolver, id, accept string, params url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+
+	h := NewResolveHandler(basePath, resolver)
+
+	router := mux.NewRouter()
+	router.HandleFunc(h.Path(), h.Handler()).Methods(h.Method())
+
+	target := basePath + "/identifiers/" + id
+	if len(params) > 0 {
+		target += "?" + params.Encode()
+	}
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+
+	return rw
+}
+
+func requireError(t *testing.T, rw *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+
+	require.Equal(t, status, rw.Code)
+	require.Equal(t, ContentTypeResolutionResult, rw.Header().Get("Content-Type"))
+
+	var result ResolutionResult
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &result))
+	require.Nil(t, result.Document)
+	require.Equal(t, code, result.ResolutionMetadata.Error)
+	require.NotEmpty(t, result.ResolutionMetadata.Message)
+}
+
+type mockResolver struct {
+	result *document.ResolutionResult
+	err    error
+}
+
+func (m *mockResolver) ResolveDocument(string) (*document.ResolutionResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return m.result, nil
+}
@@ -27,7 +27,7 @@ func TestSign(t *testing.T) {
 
 		signature, err := signer.Sign(msg)
 		require.NoError(t, err)
-		require.NotEmpty(t, signature)
+		require.Len(t, signature, 64)
 	})
 
 	t.Run("success EC P-384", func(t *testing.T) {
@@ -38,18 +38,18 @@ func TestSign(t *testing.T) {
 
 		signature, err := signer.Sign(msg)
 		require.NoError(t, err)
-		require.NotEmpty(t, signature)
+		require.Len(t, signature, 96)
 	})
 
 	t.Run("success EC P-521", func(t *testing.T) {
 		privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
 		require.NoError(t, err)
 
-		signer := New(privateKey, "ES521", "key-1")
+		signer := New(privateKey, "ES512", "key-1")
 
 		signature, err := signer.Sign(msg)
 		require.NoError(t, err)
-		require.NotEmpty(t, signature)
+		require.Len(t, signature, 132)
 	})
 
 	t.Run("success EC secp256k1 ", func(t *testing.T) {
@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/edsigner"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+)
+
+// KeyType is the type of a key which is created by the local key manager.
+type KeyType string
+
+const (
+	// ECDSAP256 is an ECDSA key on the P-256 curve (ES256).
+	ECDSAP256 KeyType = "P-256"
+	// ECDSAP384 is an ECDSA key on the P-384 curve (ES384).
+	ECDSAP384 KeyType = "P-384"
+	// ECDSAP521 is an ECDSA key on the P-521 curve (ES512).
+	ECDSAP521 KeyType = "P-521"
+	// ECDSASecp256k1 is an ECDSA key on the secp256k1 curve (ES256K).
+	ECDSASecp256k1 KeyType = "secp256k1"
+	// ED25519 is an Ed25519 key (EdDSA).
+	ED25519 KeyType = "Ed25519"
+)
+
+var curves = map[KeyType]elliptic.Curve{
+	ECDSAP256:      elliptic.P256(),
+	ECDSAP384:      elliptic.P384(),
+	ECDSAP521:      elliptic.P521(),
+	ECDSASecp256k1: btcec.S256(),
+}
+
+var algorithms = map[KeyType]string{
+	ECDSAP256:      "ES256",
+	ECDSAP384:      "ES384",
+	ECDSAP521:      "ES512",
+	ECDSASecp256k1: "ES256K",
+	ED25519:        "EdDSA",
+}
+
+// LocalKeyManager is a software key manager which holds the private keys in memory by key ID.
+// It is a stand-in for an HSM or KMS in tests and development environments.
+type LocalKeyManager struct {
+	mutex sync.RWMutex
+	keys  map[string]*localKey
+}
+
+type localKey struct {
+	keyType    KeyType
+	privateKey crypto.Signer
+}
+
+// NewLocalKeyManager returns a new, empty local key manager.
+func NewLocalKeyManager() *LocalKeyManager {
+	return &LocalKeyManager{
+		keys: make(map[string]*localKey),
+	}
+}
+
+// CreateKey creates a key of the given type and returns its public key in JWK format.
+// An existing key with the same key ID is replaced.
+func (m *LocalKeyManager) CreateKey(keyID string, keyType KeyType) (*jws.JWK, error) {
+	var privateKey crypto.Signer
+
+	if keyType == ED25519 {
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate key: %w", err)
+		}
+
+		privateKey = edKey
+	} else {
+		curve, ok := curves[keyType]
+		if !ok {
+			return nil, fmt.Errorf("key type [%s] is not supported", keyType)
+		}
+
+		ecKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate key: %w", err)
+		}
+
+		privateKey = ecKey
+	}
+
+	jwk, err := pubkey.GetPublicKeyJWK(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.keys[keyID] = &localKey{keyType: keyType, privateKey: privateKey}
+
+	return jwk, nil
+}
+
+// DeleteKey deletes the key with the given key ID.
+func (m *LocalKeyManager) DeleteKey(keyID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.keys, keyID)
+}
+
+// Sign signs the message with the given key. An error is returned if the algorithm cannot be used with the key.
+func (m *LocalKeyManager) Sign(ctx context.Context, keyID, alg string, msg []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := m.get(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected := algorithms[key.keyType]; alg != expected {
+		return nil, fmt.Errorf("algorithm [%s] cannot be used with [%s] key; expected [%s]", alg, key.keyType, expected)
+	}
+
+	switch privateKey := key.privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		return ecsigner.New(privateKey, alg, "").Sign(msg)
+	case ed25519.PrivateKey:
+		return edsigner.New(privateKey, alg, "").Sign(msg)
+	default:
+		return nil, fmt.Errorf("unexpected private key type %T", privateKey)
+	}
+}
+
+// PublicKey returns the public key of the given key in JWK format.
+func (m *LocalKeyManager) PublicKey(ctx context.Context, keyID string) (*jws.JWK, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := m.get(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pubkey.GetPublicKeyJWK(key.privateKey.Public())
+}
+
+func (m *LocalKeyManager) get(keyID string) (*localKey, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	key, ok := m.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: [%s]", ErrKeyNotFound, keyID)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+)
+
+func TestLocalKeyManager(t *testing.T) {
+	registry := verifier.New(verifier.WithDefaultVerifiers())
+
+	t.Run("success", func(t *testing.T) {
+		km := NewLocalKeyManager()
+
+		for keyType, alg := range algorithms {
+			publicKey, err := km.CreateKey(string(keyType), keyType)
+			require.NoError(t, err)
+			require.Equal(t, string(keyType), publicKey.Crv)
+
+			jwk, err := km.PublicKey(context.Background(), string(keyType))
+			require.NoError(t, err)
+			require.Equal(t, publicKey, jwk)
+
+			compactJWS, err := signutil.SignPayload([]byte("payload"), NewSigner(km, string(keyType), alg, ""))
+			require.NoError(t, err)
+			require.NoError(t, registry.Verify(compactJWS, publicKey), keyType)
+		}
+	})
+
+	t.Run("error - unsupported key type", func(t *testing.T) {
+		publicKey, err := NewLocalKeyManager().CreateKey(keyID, "RSA")
+		require.EqualError(t, err, "key type [RSA] is not supported")
+		require.Nil(t, publicKey)
+	})
+
+	t.Run("error - algorithm doesn't match key", func(t *testing.T) {
+		km := NewLocalKeyManager()
+
+		_, err := km.CreateKey(keyID, ECDSAP384)
+		require.NoError(t, err)
+
+		signature, err := km.Sign(context.Background(), keyID, "ES256", []byte("payload"))
+		require.EqualError(t, err, "algorithm [ES256] cannot be used with [P-384] key; expected [ES384]")
+		require.Nil(t, signature)
+	})
+
+	t.Run("error - key not found", func(t *testing.T) {
+		km := NewLocalKeyManager()
+
+		_, err := km.CreateKey(keyID, ED25519)
+		require.NoError(t, err)
+
+		km.DeleteKey(keyID)
+
+		signature, err := km.Sign(context.Background(), keyID, "EdDSA", []byte("payload"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+		require.EqualError(t, err, "key not found: [key-1]")
+		require.Nil(t, signature)
+
+		publicKey, err := km.PublicKey(context.Background(), keyID)
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+		require.Nil(t, publicKey)
+	})
+
+	t.Run("error - context cancelled", func(t *testing.T) {
+		km := NewLocalKeyManager()
+
+		_, err := km.CreateKey(keyID, ECDSAP256)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = km.Sign(ctx, keyID, "ES256", []byte("payload"))
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, err = km.PublicKey(ctx, keyID)
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+}
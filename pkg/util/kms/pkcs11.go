@@ -0,0 +1,138 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	// register hash functions which are used for ECDSA signatures.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"sync"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+// Mechanism is a PKCS#11 signing mechanism (CKM_*).
+type Mechanism uint
+
+const (
+	// MechanismECDSA is CKM_ECDSA. The data that is signed is the digest of the message and the signature is R || S.
+	MechanismECDSA Mechanism = 0x1041
+	// MechanismEdDSA is CKM_EDDSA. The data that is signed is the message.
+	MechanismEdDSA Mechanism = 0x1057
+)
+
+// ObjectHandle is the handle of a PKCS#11 object (CK_OBJECT_HANDLE).
+type ObjectHandle uint
+
+// PKCS11Session is the subset of a PKCS#11 session which is needed for signing. Implementations adapt
+// a PKCS#11 library (e.g. C_FindObjects, C_SignInit and C_Sign) or another HSM API to this interface.
+type PKCS11Session interface {
+	// FindPrivateKey returns the handle of the private key with the given label (CKA_LABEL). The returned
+	// error must wrap ErrKeyNotFound if the key doesn't exist.
+	FindPrivateKey(label string) (ObjectHandle, error)
+
+	// SignInit initializes a signing operation with the given mechanism and private key.
+	SignInit(mechanism Mechanism, key ObjectHandle) error
+
+	// Sign signs the data in a single part and finishes the signing operation.
+	Sign(data []byte) ([]byte, error)
+
+	// PublicKey returns the public key of the given private key in JWK format.
+	PublicKey(key ObjectHandle) (*jws.JWK, error)
+}
+
+type pkcs11Algorithm struct {
+	mechanism Mechanism
+	hash      crypto.Hash
+}
+
+var pkcs11Algorithms = map[string]pkcs11Algorithm{
+	"ES256":  {mechanism: MechanismECDSA, hash: crypto.SHA256},
+	"ES384":  {mechanism: MechanismECDSA, hash: crypto.SHA384},
+	"ES512":  {mechanism: MechanismECDSA, hash: crypto.SHA512},
+	"ES256K": {mechanism: MechanismECDSA, hash: crypto.SHA256},
+	"EdDSA":  {mechanism: MechanismEdDSA},
+}
+
+// PKCS11KeyManager is a key manager which signs with keys that are held by a PKCS#11 token. The key ID is
+// the label of the private key.
+type PKCS11KeyManager struct {
+	// a PKCS#11 session can only process one signing operation at a time
+	mutex   sync.Mutex
+	session PKCS11Session
+}
+
+// NewPKCS11KeyManager returns a new key manager for the given PKCS#11 session.
+func NewPKCS11KeyManager(session PKCS11Session) *PKCS11KeyManager {
+	return &PKCS11KeyManager{session: session}
+}
+
+// Sign signs the message with the given key and JWS algorithm.
+func (m *PKCS11KeyManager) Sign(ctx context.Context, keyID, alg string, msg []byte) ([]byte, error) {
+	algorithm, ok := pkcs11Algorithms[alg]
+	if !ok {
+		return nil, fmt.Errorf("algorithm [%s] is not supported", alg)
+	}
+
+	data := msg
+
+	if algorithm.hash != 0 {
+		hasher := algorithm.hash.New()
+
+		_, err := hasher.Write(msg)
+		if err != nil {
+			return nil, fmt.Errorf("hash message: %w", err)
+		}
+
+		data = hasher.Sum(nil)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// the context may have been cancelled while waiting for the session
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := m.session.FindPrivateKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.session.SignInit(algorithm.mechanism, key)
+	if err != nil {
+		return nil, fmt.Errorf("sign init: %w", err)
+	}
+
+	signature, err := m.session.Sign(data)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	return signature, nil
+}
+
+// PublicKey returns the public key of the given key in JWK format.
+func (m *PKCS11KeyManager) PublicKey(ctx context.Context, keyID string) (*jws.JWK, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key, err := m.session.FindPrivateKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.session.PublicKey(key)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
+)
+
+func TestPKCS11KeyManager(t *testing.T) {
+	registry := verifier.New(verifier.WithDefaultVerifiers())
+
+	t.Run("success", func(t *testing.T) {
+		km := NewPKCS11KeyManager(newMockSession())
+
+		for label, alg := range map[string]string{"p256": "ES256", "p384": "ES384", "p521": "ES512", "ed25519": "EdDSA"} {
+			publicKey, err := km.PublicKey(context.Background(), label)
+			require.NoError(t, err)
+
+			compactJWS, err := signutil.SignPayload([]byte("payload"), NewSigner(km, label, alg, ""))
+			require.NoError(t, err)
+			require.NoError(t, registry.Verify(compactJWS, publicKey), alg)
+		}
+	})
+
+	t.Run("error - unsupported algorithm", func(t *testing.T) {
+		km := NewPKCS11KeyManager(newMockSession())
+
+		_, err := km.Sign(context.Background(), "p256", "PS256", []byte("payload"))
+		require.EqualError(t, err, "algorithm [PS256] is not supported")
+	})
+
+	t.Run("error - key not found", func(t *testing.T) {
+		km := NewPKCS11KeyManager(newMockSession())
+
+		_, err := km.Sign(context.Background(), "unknown", "ES256", []byte("payload"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+
+		_, err = km.PublicKey(context.Background(), "unknown")
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+	})
+
+	t.Run("error - session errors", func(t *testing.T) {
+		session := newMockSession()
+		km := NewPKCS11KeyManager(session)
+
+		session.signInitErr = errors.New("CKR_KEY_FUNCTION_NOT_PERMITTED")
+
+		_, err := km.Sign(context.Background(), "p256", "ES256", []byte("payload"))
+		require.EqualError(t, err, "sign init: CKR_KEY_FUNCTION_NOT_PERMITTED")
+
+		session.signInitErr = nil
+		session.signErr = errors.New("CKR_DEVICE_ERROR")
+
+		_, err = km.Sign(context.Background(), "p256", "ES256", []byte("payload"))
+		require.EqualError(t, err, "sign: CKR_DEVICE_ERROR")
+	})
+
+	t.Run("error - context cancelled", func(t *testing.T) {
+		km := NewPKCS11KeyManager(newMockSession())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := km.Sign(ctx, "p256", "ES256", []byte("payload"))
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, err = km.PublicKey(ctx, "p256")
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+// mockSession is a PKCS#11 session which holds its keys in memory.
+type mockSession struct {
+	keys    []crypto.Signer
+	handles map[string]ObjectHandle
+
+	mechanism Mechanism
+	key       ObjectHandle
+
+	signInitErr error
+	signErr     error
+}
+
+func newMockSession() *mockSession {
+	s := &mockSession{handles: make(map[string]ObjectHandle)}
+
+	for label, curve := range map[string]elliptic.Curve{"p256": elliptic.P256(), "p384": elliptic.P384(), "p521": elliptic.P521()} {
+		privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+
+		s.add(label, privateKey)
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	s.add("ed25519", privateKey)
+
+	return s
+}
+
+func (s *mockSession) add(label string, privateKey crypto.Signer) {
+	s.handles[label] = ObjectHandle(len(s.keys))
+	s.keys = append(s.keys, privateKey)
+}
+
+func (s *mockSession) FindPrivateKey(label string) (ObjectHandle, error) {
+	handle, ok := s.handles[label]
+	if !ok {
+		return 0, fmt.Errorf("%w: [%s]", ErrKeyNotFound, label)
+	}
+
+	return handle, nil
+}
+
+func (s *mockSession) SignInit(mechanism Mechanism, key ObjectHandle) error {
+	s.mechanism = mechanism
+	s.key = key
+
+	return s.signInitErr
+}
+
+func (s *mockSession) Sign(data []byte) ([]byte, error) {
+	if s.signErr != nil {
+		return nil, s.signErr
+	}
+
+	switch privateKey := s.keys[s.key].(type) {
+	case *ecdsa.PrivateKey:
+		if s.mechanism != MechanismECDSA {
+			return nil, errors.New("CKR_KEY_TYPE_INCONSISTENT")
+		}
+
+		r, sig, err := ecdsa.Sign(rand.Reader, privateKey, data)
+		if err != nil {
+			return nil, err
+		}
+
+		size := (privateKey.Curve.Params().BitSize + 7) / 8
+
+		return append(padded(r.Bytes(), size), padded(sig.Bytes(), size)...), nil
+	case ed25519.PrivateKey:
+		if s.mechanism != MechanismEdDSA {
+			return nil, errors.New("CKR_KEY_TYPE_INCONSISTENT")
+		}
+
+		return ed25519.Sign(privateKey, data), nil
+	default:
+		return nil, errors.New("CKR_KEY_HANDLE_INVALID")
+	}
+}
+
+func (s *mockSession) PublicKey(key ObjectHandle) (*jws.JWK, error) {
+	return pubkey.GetPublicKeyJWK(s.keys[key].Public())
+}
+
+func padded(b []byte, size int) []byte {
+	result := make([]byte, size)
+	copy(result[size-len(b):], b)
+
+	return result
+}
@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kms contains a signer for the Sidetree client request builders which signs with keys that are held
+// by a key management system (e.g. an HSM or a cloud KMS) instead of in process memory.
+package kms
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+)
+
+// ErrKeyNotFound is returned (wrapped) by a key manager when the key doesn't exist.
+var ErrKeyNotFound = errors.New("key not found")
+
+const defaultTimeout = 30 * time.Second
+
+// KeyManager signs messages with keys which are held by a key management system.
+type KeyManager interface {
+	// Sign signs the message with the given key and JWS algorithm. The signature must be in the format
+	// which is defined for the algorithm by RFC 7518 (e.g. R || S for ECDSA).
+	Sign(ctx context.Context, keyID, alg string, msg []byte) ([]byte, error)
+
+	// PublicKey returns the public key of the given key in JWK format.
+	PublicKey(ctx context.Context, keyID string) (*jws.JWK, error)
+}
+
+// SignResult is the result of an asynchronous signing request.
+type SignResult struct {
+	Signature []byte
+	Err       error
+}
+
+// Signer signs with a key which is held by a key manager. It implements the ContextSigner interface
+// of the client request builders, so signing can be cancelled by the caller of the *WithContext builders.
+type Signer struct {
+	km      KeyManager
+	keyID   string
+	alg     string
+	kid     string
+	timeout time.Duration
+}
+
+// SignerOption is a signer instance option.
+type SignerOption func(opts *Signer)
+
+// WithTimeout sets the timeout of the signing requests whose context doesn't have a deadline (default 30s).
+func WithTimeout(timeout time.Duration) SignerOption {
+	return func(opts *Signer) {
+		opts.timeout = timeout
+	}
+}
+
+// NewSigner returns a new signer for the key with the given key ID. The alg and kid are used as the
+// JWS protected headers (the kid is empty for recovery keys).
+func NewSigner(km KeyManager, keyID, alg, kid string, opts ...SignerOption) *Signer {
+	signer := &Signer{
+		km:      km,
+		keyID:   keyID,
+		alg:     alg,
+		kid:     kid,
+		timeout: defaultTimeout,
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(signer)
+	}
+
+	return signer
+}
+
+// Headers provides required JWS protected headers. It provides information about signing key and algorithm.
+func (s *Signer) Headers() jws.Headers {
+	headers := make(jws.Headers)
+	headers[jws.HeaderAlgorithm] = s.alg
+	headers[jws.HeaderKeyID] = s.kid
+
+	return headers
+}
+
+// Sign signs msg and returns signature value. The signing request times out after the signer's timeout.
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	return s.SignContext(context.Background(), msg)
+}
+
+// SignContext signs msg and returns signature value. The signing request is abandoned when the context is done.
+// The signer's timeout is applied if the context doesn't have a deadline.
+func (s *Signer) SignContext(ctx context.Context, msg []byte) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	select {
+	case result := <-s.SignAsync(ctx, msg):
+		return result.Signature, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SignAsync signs msg in the background. The result is delivered on the returned channel, which is buffered
+// so that the result isn't blocked if the caller stops waiting for it.
+func (s *Signer) SignAsync(ctx context.Context, msg []byte) <-chan SignResult {
+	results := make(chan SignResult, 1)
+
+	go func() {
+		signature, err := s.km.Sign(ctx, s.keyID, s.alg, msg)
+
+		results <- SignResult{Signature: signature, Err: err}
+	}()
+
+	return results
+}
+
+// PublicKey returns the public key of the signer's key in JWK format.
+func (s *Signer) PublicKey(ctx context.Context) (*jws.JWK, error) {
+	return s.km.PublicKey(ctx, s.keyID)
+}
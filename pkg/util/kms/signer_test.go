@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+)
+
+const keyID = "key-1"
+
+func TestSigner(t *testing.T) {
+	km := NewLocalKeyManager()
+
+	publicKey, err := km.CreateKey(keyID, ECDSAP256)
+	require.NoError(t, err)
+
+	registry := verifier.New(verifier.WithDefaultVerifiers())
+
+	t.Run("success", func(t *testing.T) {
+		signer := NewSigner(km, keyID, "ES256", "update-key")
+
+		alg, ok := signer.Headers().Algorithm()
+		require.True(t, ok)
+		require.Equal(t, "ES256", alg)
+
+		kid, ok := signer.Headers().KeyID()
+		require.True(t, ok)
+		require.Equal(t, "update-key", kid)
+
+		compactJWS, err := signutil.SignPayload([]byte("payload"), signer)
+		require.NoError(t, err)
+		require.NoError(t, registry.Verify(compactJWS, publicKey))
+
+		jwk, err := signer.PublicKey(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, publicKey, jwk)
+	})
+
+	t.Run("success - async", func(t *testing.T) {
+		signer := NewSigner(km, keyID, "ES256", "")
+
+		result := <-signer.SignAsync(context.Background(), []byte("payload"))
+		require.NoError(t, result.Err)
+		require.Len(t, result.Signature, 64)
+	})
+
+	t.Run("error - key manager error", func(t *testing.T) {
+		signer := NewSigner(km, "unknown", "ES256", "")
+
+		signature, err := signer.Sign([]byte("payload"))
+		require.True(t, errors.Is(err, ErrKeyNotFound))
+		require.Nil(t, signature)
+
+		result := <-signer.SignAsync(context.Background(), []byte("payload"))
+		require.True(t, errors.Is(result.Err, ErrKeyNotFound))
+	})
+
+	t.Run("error - timeout", func(t *testing.T) {
+		slow := &blockingKeyManager{release: make(chan struct{})}
+		defer close(slow.release)
+
+		signer := NewSigner(slow, keyID, "ES256", "", WithTimeout(10*time.Millisecond))
+
+		signature, err := signer.Sign([]byte("payload"))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Nil(t, signature)
+	})
+
+	t.Run("error - timeout without context deadline", func(t *testing.T) {
+		slow := &blockingKeyManager{release: make(chan struct{})}
+		defer close(slow.release)
+
+		signer := NewSigner(slow, keyID, "ES256", "", WithTimeout(10*time.Millisecond))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		signature, err := signer.SignContext(ctx, []byte("payload"))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Nil(t, signature)
+	})
+
+	t.Run("error - context cancelled", func(t *testing.T) {
+		slow := &blockingKeyManager{release: make(chan struct{})}
+		defer close(slow.release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		signature, err := NewSigner(slow, keyID, "ES256", "").SignContext(ctx, []byte("payload"))
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, signature)
+	})
+}
+
+// blockingKeyManager blocks signing requests until it's released.
+type blockingKeyManager struct {
+	release chan struct{}
+}
+
+func (m *blockingKeyManager) Sign(context.Context, string, string, []byte) ([]byte, error) {
+	<-m.release
+
+	return []byte("signature"), nil
+}
+
+func (m *blockingKeyManager) PublicKey(context.Context, string) (*jws.JWK, error) {
+	return nil, errors.New("not implemented")
+}
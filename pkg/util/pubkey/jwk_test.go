@@ -11,6 +11,8 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/base64"
+	"math/big"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -29,6 +31,42 @@ func TestGetPublicKeyJWK(t *testing.T) {
 		require.Equal(t, "EC", jwk.Kty)
 	})
 
+	t.Run("success EC P-384 and P-521", func(t *testing.T) {
+		tests := []struct {
+			curve elliptic.Curve
+			crv   string
+			size  int
+		}{
+			{elliptic.P384(), "P-384", 48},
+			{elliptic.P521(), "P-521", 66},
+		}
+
+		for _, tc := range tests {
+			// coordinates have to be padded to the full size of the curve (RFC 7518 section 6.2.1.2)
+			// so try enough keys for one of them to have leading zero bytes
+			for i := 0; i < 20; i++ {
+				privateKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+				require.NoError(t, err)
+
+				jwk, err := GetPublicKeyJWK(&privateKey.PublicKey)
+				require.NoError(t, err)
+				require.Equal(t, tc.crv, jwk.Crv)
+				require.Equal(t, "EC", jwk.Kty)
+
+				x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+				require.NoError(t, err)
+				require.Len(t, x, tc.size)
+
+				y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+				require.NoError(t, err)
+				require.Len(t, y, tc.size)
+
+				require.Equal(t, privateKey.X, new(big.Int).SetBytes(x))
+				require.Equal(t, privateKey.Y, new(big.Int).SetBytes(y))
+			}
+		}
+	})
+
 	t.Run("success EC secp256k1 ", func(t *testing.T) {
 		privateKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
 		require.NoError(t, err)
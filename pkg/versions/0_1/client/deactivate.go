@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"context"
 	"errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
@@ -25,6 +26,15 @@ type Signer interface {
 	Headers() jws.Headers
 }
 
+// ContextSigner is a Signer which binds signing to a context (e.g. a signer which signs with a remote key
+// management system). The *WithContext request builders sign using SignContext if the signer implements it.
+type ContextSigner interface {
+	Signer
+
+	// SignContext signs data and returns signature value. Signing is abandoned when the context is done.
+	SignContext(ctx context.Context, data []byte) ([]byte, error)
+}
+
 // DeactivateRequestInfo is the information required to create deactivate request.
 type DeactivateRequestInfo struct {
 
@@ -41,6 +51,12 @@ type DeactivateRequestInfo struct {
 
 // NewDeactivateRequest is utility function to create payload for 'deactivate' request.
 func NewDeactivateRequest(info *DeactivateRequestInfo) ([]byte, error) {
+	return NewDeactivateRequestWithContext(context.Background(), info)
+}
+
+// NewDeactivateRequestWithContext is utility function to create payload for 'deactivate' request. Signing is
+// bound to the given context if the signer is a ContextSigner.
+func NewDeactivateRequestWithContext(ctx context.Context, info *DeactivateRequestInfo) ([]byte, error) {
 	if err := validateDeactivateRequest(info); err != nil {
 		return nil, err
 	}
@@ -50,7 +66,7 @@ func NewDeactivateRequest(info *DeactivateRequestInfo) ([]byte, error) {
 		RecoveryKey: info.RecoveryKey,
 	}
 
-	jws, err := signutil.SignModel(signedDataModel, info.Signer)
+	jws, err := signutil.SignModelWithContext(ctx, signedDataModel, info.Signer)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,230 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/kms"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+)
+
+var _ ContextSigner = (*kms.Signer)(nil)
+
+// TestRequests_KMSSigner creates, updates, recovers and deactivates a document with keys which are held
+// by a key manager, and checks the requests with the operation parser and the signature verifiers.
+func TestRequests_KMSSigner(t *testing.T) {
+	km := kms.NewLocalKeyManager()
+
+	parser, err := operationparser.New(mocks.NewMockProtocolClient().Protocol)
+	require.NoError(t, err)
+
+	verifiers := verifier.New(verifier.WithDefaultVerifiers())
+
+	newKey := func(keyID string, keyType kms.KeyType) (*jws.JWK, string) {
+		publicKey, err := km.CreateKey(keyID, keyType)
+		require.NoError(t, err)
+
+		c, err := commitment.Calculate(publicKey, sha2_256, crypto.SHA256)
+		require.NoError(t, err)
+
+		return publicKey, c
+	}
+
+	recoveryKey, recoveryCommitment := newKey("recovery-1", kms.ECDSAP256)
+	updateKey, updateCommitment := newKey("update-1", kms.ED25519)
+
+	replace, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/name", "value": "Jane"}]`)
+	require.NoError(t, err)
+
+	// create
+	request, err := NewCreateRequest(&CreateRequestInfo{
+		OpaqueDocument:     `{"name":"Jane"}`,
+		RecoveryCommitment: recoveryCommitment,
+		UpdateCommitment:   updateCommitment,
+		MultihashCode:      sha2_256,
+	})
+	require.NoError(t, err)
+
+	createOp, err := parser.ParseCreateOperation(request, false)
+	require.NoError(t, err)
+
+	didSuffix := createOp.UniqueSuffix
+
+	// update
+	_, nextUpdateCommitment := newKey("update-2", kms.ED25519)
+
+	request, err = NewUpdateRequest(&UpdateRequestInfo{
+		DidSuffix:        didSuffix,
+		Patches:          []patch.Patch{replace},
+		UpdateCommitment: nextUpdateCommitment,
+		UpdateKey:        updateKey,
+		MultihashCode:    sha2_256,
+		Signer:           kms.NewSigner(km, "update-1", "EdDSA", "update-1"),
+	})
+	require.NoError(t, err)
+
+	updateOp, updateSignedData, err := parser.ParseUpdateOperationWithSignedData(request, false)
+	require.NoError(t, err)
+	require.NoError(t, verifiers.Verify(updateOp.SignedData, updateSignedData.UpdateKey))
+
+	// recover
+	nextRecoveryKey, nextRecoveryCommitment := newKey("recovery-2", kms.ECDSAP256)
+	_, nextUpdateCommitment = newKey("update-3", kms.ED25519)
+
+	request, err = NewRecoverRequest(&RecoverRequestInfo{
+		DidSuffix:          didSuffix,
+		RecoveryKey:        recoveryKey,
+		OpaqueDocument:     `{"name":"John"}`,
+		RecoveryCommitment: nextRecoveryCommitment,
+		UpdateCommitment:   nextUpdateCommitment,
+		MultihashCode:      sha2_256,
+		Signer:             kms.NewSigner(km, "recovery-1", "ES256", ""),
+	})
+	require.NoError(t, err)
+
+	recoverOp, recoverSignedData, err := parser.ParseRecoverOperationWithSignedData(request, false)
+	require.NoError(t, err)
+	require.NoError(t, verifiers.Verify(recoverOp.SignedData, recoverSignedData.RecoveryKey))
+
+	// deactivate
+	request, err = NewDeactivateRequest(&DeactivateRequestInfo{
+		DidSuffix:   didSuffix,
+		RecoveryKey: nextRecoveryKey,
+		Signer:      kms.NewSigner(km, "recovery-2", "ES256", ""),
+	})
+	require.NoError(t, err)
+
+	deactivateOp, deactivateSignedData, err := parser.ParseDeactivateOperationWithSignedData(request, false)
+	require.NoError(t, err)
+	require.NoError(t, verifiers.Verify(deactivateOp.SignedData, deactivateSignedData.RecoveryKey))
+
+	// a request which is signed with another key isn't valid
+	request, err = NewDeactivateRequest(&DeactivateRequestInfo{
+		DidSuffix:   didSuffix,
+		RecoveryKey: nextRecoveryKey,
+		Signer:      kms.NewSigner(km, "recovery-1", "ES256", ""),
+	})
+	require.NoError(t, err)
+
+	deactivateOp, deactivateSignedData, err = parser.ParseDeactivateOperationWithSignedData(request, false)
+	require.NoError(t, err)
+	require.EqualError(t, verifiers.Verify(deactivateOp.SignedData, deactivateSignedData.RecoveryKey),
+		"ecdsa: invalid signature")
+}
+
+// TestRequests_KMSSignerWithContext checks that a remote signing request which is made by a request builder is
+// bound to the caller's context.
+func TestRequests_KMSSignerWithContext(t *testing.T) {
+	km := &blockingKeyManager{release: make(chan struct{})}
+	defer close(km.release)
+
+	replace, err := patch.NewJSONPatch(`[{"op": "replace", "path": "/name", "value": "Jane"}]`)
+	require.NoError(t, err)
+
+	updateKey := &jws.JWK{Kty: "OKP", Crv: "Ed25519", X: "x"}
+
+	newUpdateRequestInfo := func() *UpdateRequestInfo {
+		return &UpdateRequestInfo{
+			DidSuffix:        "suffix",
+			Patches:          []patch.Patch{replace},
+			UpdateCommitment: "commitment",
+			UpdateKey:        updateKey,
+			MultihashCode:    sha2_256,
+			Signer:           kms.NewSigner(km, "update-1", "EdDSA", "update-1"),
+		}
+	}
+
+	t.Run("update - cancelled while signing", func(t *testing.T) {
+		started := &blockingKeyManager{started: make(chan struct{}), release: make(chan struct{})}
+		defer close(started.release)
+
+		info := newUpdateRequestInfo()
+		info.Signer = kms.NewSigner(started, "update-1", "EdDSA", "update-1")
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			<-started.started
+			cancel()
+		}()
+
+		request, err := NewUpdateRequestWithContext(ctx, info)
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, request)
+	})
+
+	t.Run("recover - deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		request, err := NewRecoverRequestWithContext(ctx, &RecoverRequestInfo{
+			DidSuffix:          "suffix",
+			RecoveryKey:        &jws.JWK{Kty: "EC", Crv: "P-256", X: "x", Y: "y"},
+			OpaqueDocument:     `{"name":"John"}`,
+			RecoveryCommitment: "commitment",
+			UpdateCommitment:   "commitment",
+			MultihashCode:      sha2_256,
+			Signer:             kms.NewSigner(km, "recovery-1", "ES256", ""),
+		})
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Nil(t, request)
+	})
+
+	t.Run("deactivate - context already done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		request, err := NewDeactivateRequestWithContext(ctx, &DeactivateRequestInfo{
+			DidSuffix:   "suffix",
+			RecoveryKey: &jws.JWK{Kty: "EC", Crv: "P-256", X: "x", Y: "y"},
+			Signer:      NewMockSigner(nil),
+		})
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Nil(t, request)
+	})
+
+	t.Run("signer timeout without deadline", func(t *testing.T) {
+		info := newUpdateRequestInfo()
+		info.Signer = kms.NewSigner(km, "update-1", "EdDSA", "update-1", kms.WithTimeout(10*time.Millisecond))
+
+		request, err := NewUpdateRequest(info)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Nil(t, request)
+	})
+}
+
+// blockingKeyManager blocks signing requests until it's released.
+type blockingKeyManager struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingKeyManager) Sign(context.Context, string, string, []byte) ([]byte, error) {
+	if m.started != nil {
+		close(m.started)
+	}
+
+	<-m.release
+
+	return []byte("signature"), nil
+}
+
+func (m *blockingKeyManager) PublicKey(context.Context, string) (*jws.JWK, error) {
+	return nil, errors.New("not implemented")
+}
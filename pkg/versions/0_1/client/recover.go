@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"context"
 	"errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
@@ -50,6 +51,12 @@ type RecoverRequestInfo struct {
 
 // NewRecoverRequest is utility function to create payload for 'recovery' request.
 func NewRecoverRequest(info *RecoverRequestInfo) ([]byte, error) {
+	return NewRecoverRequestWithContext(context.Background(), info)
+}
+
+// NewRecoverRequestWithContext is utility function to create payload for 'recovery' request. Signing is bound to the
+// given context if the signer is a ContextSigner.
+func NewRecoverRequestWithContext(ctx context.Context, info *RecoverRequestInfo) ([]byte, error) {
 	err := validateRecoverRequest(info)
 	if err != nil {
 		return nil, err
@@ -76,7 +83,7 @@ func NewRecoverRequest(info *RecoverRequestInfo) ([]byte, error) {
 		RecoveryCommitment: info.RecoveryCommitment,
 	}
 
-	jws, err := signutil.SignModel(signedDataModel, info.Signer)
+	jws, err := signutil.SignModelWithContext(ctx, signedDataModel, info.Signer)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"context"
 	"errors"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
@@ -42,6 +43,12 @@ type UpdateRequestInfo struct {
 
 // NewUpdateRequest is utility function to create payload for 'update' request.
 func NewUpdateRequest(info *UpdateRequestInfo) ([]byte, error) {
+	return NewUpdateRequestWithContext(context.Background(), info)
+}
+
+// NewUpdateRequestWithContext is utility function to create payload for 'update' request. Signing is bound to the
+// given context if the signer is a ContextSigner.
+func NewUpdateRequestWithContext(ctx context.Context, info *UpdateRequestInfo) ([]byte, error) {
 	if err := validateUpdateRequest(info); err != nil {
 		return nil, err
 	}
@@ -61,7 +68,7 @@ func NewUpdateRequest(info *UpdateRequestInfo) ([]byte, error) {
 		UpdateKey: info.UpdateKey,
 	}
 
-	jws, err := signutil.SignModel(signedDataModel, info.Signer)
+	jws, err := signutil.SignModelWithContext(ctx, signedDataModel, info.Signer)
 	if err != nil {
 		return nil, err
 	}
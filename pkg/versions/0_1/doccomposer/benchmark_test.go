@@ -0,0 +1,62 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package doccomposer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+)
+
+// benchmarkPatchCounts are the numbers of patches applied to the document.
+var benchmarkPatchCounts = []int{1, 100, 1000}
+
+func BenchmarkApplyPatches(b *testing.B) {
+	documentComposer := New()
+
+	for _, numPatches := range benchmarkPatchCounts {
+		patches := getAddServicesPatches(b, numPatches)
+
+		b.Run(fmt.Sprintf("%d patches", numPatches), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				doc, err := setupDefaultDoc()
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				// patches are applied one at a time (as they would be when applying consecutive operations)
+				for _, p := range patches {
+					doc, err = documentComposer.ApplyPatches(doc, []patch.Patch{p})
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func getAddServicesPatches(b *testing.B, numPatches int) []patch.Patch {
+	b.Helper()
+
+	var patches []patch.Patch
+
+	for i := 0; i < numPatches; i++ {
+		p, err := patch.NewAddServiceEndpointsPatch(fmt.Sprintf(
+			`[{"id": "service%d", "type": "service", "serviceEndpoint": "https://example.com/%d"}]`, i, i))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		patches = append(patches, p)
+	}
+
+	return patches
+}
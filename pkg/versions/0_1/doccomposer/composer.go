@@ -14,6 +14,7 @@ import (
 	"github.com/trustbloc/edge-core/pkg/log"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
+	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 )
 
@@ -125,10 +126,11 @@ func applyAddPublicKeys(doc document.Document, entry interface{}) (document.Docu
 	logger.Debugf("applying add public keys patch: %v", entry)
 
 	addPublicKeys := document.ParsePublicKeys(entry)
-	existingPublicKeysMap := sliceToMapPK(doc.PublicKeys())
+	existingPublicKeys := doc.PublicKeys()
+	existingPublicKeysMap := sliceToMapPK(existingPublicKeys)
 
-	var newPublicKeys []document.PublicKey
-	newPublicKeys = append(newPublicKeys, doc.PublicKeys()...)
+	newPublicKeys := make([]document.PublicKey, 0, len(existingPublicKeys)+len(addPublicKeys))
+	newPublicKeys = append(newPublicKeys, existingPublicKeys...)
 
 	for _, key := range addPublicKeys {
 		_, ok := existingPublicKeysMap[key.ID()]
@@ -211,10 +213,11 @@ func applyAddServiceEndpoints(doc document.Document, entry interface{}) (documen
 	didDoc := document.DidDocumentFromJSONLDObject(doc.JSONLdObject())
 
 	addServices := document.ParseServices(entry)
-	existingServicesMap := sliceToMapServices(didDoc.Services())
+	existingServices := didDoc.Services()
+	existingServicesMap := sliceToMapServices(existingServices)
 
-	var newServices []document.Service
-	newServices = append(newServices, didDoc.Services()...)
+	newServices := make([]document.Service, 0, len(existingServices)+len(addServices))
+	newServices = append(newServices, existingServices...)
 
 	for _, service := range addServices {
 		_, ok := existingServicesMap[service.ID()]
@@ -272,7 +275,7 @@ func applyRemoveServiceEndpoints(doc document.Document, entry interface{}) (docu
 
 func sliceToMapServices(services []document.Service) map[string]document.Service {
 	// convert slice to map
-	values := make(map[string]document.Service)
+	values := make(map[string]document.Service, len(services))
 	for _, svc := range services {
 		values[svc.ID()] = svc
 	}
@@ -282,13 +285,7 @@ func sliceToMapServices(services []document.Service) map[string]document.Service
 
 // deepCopy returns deep copy of JSON object.
 func deepCopy(doc document.Document) (document.Document, error) {
-	bytes, err := json.Marshal(doc)
-	if err != nil {
-		return nil, err
-	}
-
-	var result document.Document
-	err = json.Unmarshal(bytes, &result)
+	result, err := docutil.DeepCopyMap(doc)
 	if err != nil {
 		return nil, err
 	}
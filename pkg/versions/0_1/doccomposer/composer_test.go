@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package doccomposer
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -408,6 +409,32 @@ const testDoc = `{
   ]
 }`
 
+func TestDeepCopy(t *testing.T) {
+	original, err := setupDefaultDoc()
+	require.NoError(t, err)
+
+	original["nilMap"] = map[string]interface{}(nil)
+	original["nilSlice"] = []interface{}(nil)
+	original["strings"] = []string{"a", "b"}
+	original["number"] = 1
+	original["key"] = document.PublicKey{"id": "key1"}
+
+	// the copy must be the same as the result of a JSON round-trip
+	bytes, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	expected := make(document.Document)
+	require.NoError(t, json.Unmarshal(bytes, &expected))
+
+	result, err := deepCopy(original)
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+
+	// modifying the copy must not modify the original
+	result.PublicKeys()[0]["id"] = "modified"
+	require.Equal(t, "key1", original.PublicKeys()[0].ID())
+}
+
 const addKeys = `[{
 		  "id": "key3",
 		  "type": "JsonWebKey2020",
@@ -37,3 +37,12 @@ type Operation struct {
 	// SuffixDataModel is suffix data model
 	SuffixData *SuffixDataModel
 }
+
+// ParsedOperation contains an operation which has been parsed for resolution together with the signed data
+// model of the operation (only the signed data model which corresponds to the operation type is set).
+type ParsedOperation struct {
+	Operation            *Operation
+	UpdateSignedData     *UpdateSignedDataModel
+	RecoverSignedData    *RecoverSignedDataModel
+	DeactivateSignedData *DeactivateSignedDataModel
+}
@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operationapplier
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/patch"
+	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
+)
+
+// benchmarkOperationCounts are the numbers of operations (including the create operation) of the generated DIDs.
+var benchmarkOperationCounts = []int{1, 100, 1000}
+
+func BenchmarkApply(b *testing.B) {
+	applier, err := New(p, parser, dc)
+	require.NoError(b, err)
+
+	for _, numOps := range benchmarkOperationCounts {
+		ops := getOperations(b, numOps)
+
+		b.Run(fmt.Sprintf("%d operations", numOps), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				rm := &protocol.ResolutionModel{}
+
+				for _, op := range ops {
+					var err error
+
+					rm, err = applier.Apply(op, rm)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// getOperations returns a create operation followed by (numOps - 1) update operations.
+// Each update operation adds a service to the document.
+func getOperations(b *testing.B, numOps int) []*operation.AnchoredOperation {
+	b.Helper()
+
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ops := []*operation.AnchoredOperation{createOp}
+
+	for i := 1; i < numOps; i++ {
+		servicePatch, err := patch.NewAddServiceEndpointsPatch(fmt.Sprintf(
+			`[{"id": "service%d", "type": "service", "serviceEndpoint": "https://example.com/%d"}]`, i, i))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		op, nextUpdateKey, err := getUpdateOperationWithPatch(ecsigner.New(updateKey, "ES256", updateKeyID),
+			updateKey, createOp.UniqueSuffix, servicePatch)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		ops = append(ops, getAnchoredOperationWithBlockNum(op, uint64(i)))
+		updateKey = nextUpdateKey
+	}
+
+	return ops
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
-	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
@@ -29,6 +29,19 @@ type Applier struct {
 	protocol.Protocol
 	OperationParser
 	protocol.DocumentComposer
+
+	verifiers *verifier.Registry
+}
+
+// Option is an applier instance option.
+type Option func(opts *Applier)
+
+// WithVerifierRegistry sets the registry of signature verifiers. The built-in verifiers are used by default.
+// Only the verifiers for the protocol's signature and key algorithms are used.
+func WithVerifierRegistry(registry *verifier.Registry) Option {
+	return func(opts *Applier) {
+		opts.verifiers = registry
+	}
 }
 
 // OperationParser defines the functions for parsing operations.
@@ -36,51 +49,89 @@ type OperationParser interface {
 	ValidateSuffixData(suffixData *model.SuffixDataModel) error
 	ValidateDelta(delta *model.DeltaModel) error
 	ParseCreateOperation(request []byte, anchor bool) (*model.Operation, error)
-	ParseUpdateOperation(request []byte, anchor bool) (*model.Operation, error)
-	ParseRecoverOperation(request []byte, anchor bool) (*model.Operation, error)
-	ParseDeactivateOperation(request []byte, anchor bool) (*model.Operation, error)
-	ParseSignedDataForUpdate(compactJWS string) (*model.UpdateSignedDataModel, error)
-	ParseSignedDataForDeactivate(compactJWS string) (*model.DeactivateSignedDataModel, error)
-	ParseSignedDataForRecover(compactJWS string) (*model.RecoverSignedDataModel, error)
+	ParseUpdateOperationWithSignedData(request []byte, anchor bool) (*model.Operation, *model.UpdateSignedDataModel, error)
+	ParseRecoverOperationWithSignedData(request []byte, anchor bool) (*model.Operation, *model.RecoverSignedDataModel, error)
+	ParseDeactivateOperationWithSignedData(request []byte, anchor bool) (*model.Operation, *model.DeactivateSignedDataModel, error)
 }
 
-// New returns a new operation applier for the given protocol.
-func New(p protocol.Protocol, parser OperationParser, dc protocol.DocumentComposer) *Applier {
-	return &Applier{
+// New returns a new operation applier for the given protocol. An error is returned if the protocol
+// parameters are not valid.
+func New(p protocol.Protocol, parser OperationParser, dc protocol.DocumentComposer, opts ...Option) (*Applier, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	applier := &Applier{
 		Protocol:         p,
 		OperationParser:  parser,
 		DocumentComposer: dc,
+		verifiers:        verifier.New(verifier.WithDefaultVerifiers()),
+	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(applier)
 	}
+
+	if err := p.ValidateVerifiers(applier.verifiers); err != nil {
+		return nil, err
+	}
+
+	applier.verifiers = applier.verifiers.Filter(p.SignatureAlgorithms, p.KeyAlgorithms)
+
+	return applier, nil
 }
 
 // Apply applies the given anchored operation.
 func (s *Applier) Apply(op *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	return s.apply(op, nil, rm)
+}
+
+// ApplyParsed applies the given operation which has been parsed by the operation parser of this protocol version
+// (see OperationParser.ParseAnchoredOperation).
+func (s *Applier) ApplyParsed(op *protocol.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+	parsed, ok := op.Model.(*model.ParsedOperation)
+	if !ok {
+		return nil, fmt.Errorf("unsupported parsed operation model [%T]", op.Model)
+	}
+
+	return s.apply(op.AnchoredOperation, parsed, rm)
+}
+
+// apply applies the anchored operation. The operation is parsed (in anchor mode) if it hasn't been parsed yet.
+func (s *Applier) apply(op *operation.AnchoredOperation, parsed *model.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	switch op.Type {
 	case operation.TypeCreate:
-		return s.applyCreateOperation(op, rm)
+		return s.applyCreateOperation(op, parsed, rm)
 	case operation.TypeUpdate:
-		return s.applyUpdateOperation(op, rm)
+		return s.applyUpdateOperation(op, parsed, rm)
 	case operation.TypeDeactivate:
-		return s.applyDeactivateOperation(op, rm)
+		return s.applyDeactivateOperation(op, parsed, rm)
 	case operation.TypeRecover:
-		return s.applyRecoverOperation(op, rm)
+		return s.applyRecoverOperation(op, parsed, rm)
 	default:
 		return nil, fmt.Errorf("operation type not supported for process operation")
 	}
 }
 
-func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation, parsed *model.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	logger.Debugf("Applying create operation: %+v", anchoredOp)
 
 	if rm.Doc != nil {
 		return nil, errors.New("create has to be the first operation")
 	}
 
-	op, err := s.OperationParser.ParseCreateOperation(anchoredOp.OperationBuffer, true)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse create operation in anchor mode: %s", err.Error())
+	if parsed == nil {
+		op, err := s.OperationParser.ParseCreateOperation(anchoredOp.OperationBuffer, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse create operation in anchor mode: %s", err.Error())
+		}
+
+		parsed = &model.ParsedOperation{Operation: op}
 	}
 
+	op := parsed.Operation
+
 	// from this point any error should advance recovery commitment
 	result := &protocol.ResolutionModel{
 		Doc:                              make(document.Document),
@@ -91,7 +142,7 @@ func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation,
 	}
 
 	// verify actual delta hash matches expected delta hash
-	err = docutil.IsValidModelMultihash(op.Delta, op.SuffixData.DeltaHash)
+	err := docutil.IsValidModelMultihash(op.Delta, op.SuffixData.DeltaHash)
 	if err != nil {
 		logger.Infof("Delta doesn't match delta hash; set update commitment to nil and advance recovery commitment {UniqueSuffix: %s, Type: %s, TransactionTime: %d, TransactionNumber: %d}. Reason: %s", anchoredOp.UniqueSuffix, anchoredOp.Type, anchoredOp.TransactionTime, anchoredOp.TransactionTime, err)
 
@@ -119,31 +170,32 @@ func (s *Applier) applyCreateOperation(anchoredOp *operation.AnchoredOperation,
 	return result, nil
 }
 
-func (s *Applier) applyUpdateOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
+func (s *Applier) applyUpdateOperation(anchoredOp *operation.AnchoredOperation, parsed *model.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
 	logger.Debugf("Applying update operation: %+v", anchoredOp)
 
 	if rm.Doc == nil {
 		return nil, errors.New("update cannot be first operation")
 	}
 
-	op, err := s.OperationParser.ParseUpdateOperation(anchoredOp.OperationBuffer, true)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse update operation in anchor mode: %s", err.Error())
-	}
+	if parsed == nil {
+		op, signedDataModel, err := s.OperationParser.ParseUpdateOperationWithSignedData(anchoredOp.OperationBuffer, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse update operation in anchor mode: %s", err.Error())
+		}
 
-	signedDataModel, err := s.ParseSignedDataForUpdate(op.SignedData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal signed data model while applying update: %s", err.Error())
+		parsed = &model.ParsedOperation{Operation: op, UpdateSignedData: signedDataModel}
 	}
 
+	op, signedDataModel := parsed.Operation, parsed.UpdateSignedData
+
 	// verify the delta against the signed delta hash
-	err = docutil.IsValidModelMultihash(op.Delta, signedDataModel.DeltaHash)
+	err := docutil.IsValidModelMultihash(op.Delta, signedDataModel.DeltaHash)
 	if err != nil {
 		return nil, fmt.Errorf("update delta doesn't match delta hash: %s", err.Error())
 	}
 
 	// verify signature
-	_, err = internal.VerifyJWS(op.SignedData, signedDataModel.UpdateKey)
+	err = s.verifiers.Verify(op.SignedData, signedDataModel.UpdateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check signature: %s", err.Error())
 	}
@@ -168,30 +220,31 @@ func (s *Applier) applyUpdateOperation(anchoredOp *operation.AnchoredOperation,
 	}, nil
 }
 
-func (s *Applier) applyDeactivateOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
+func (s *Applier) applyDeactivateOperation(anchoredOp *operation.AnchoredOperation, parsed *model.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) {
 	logger.Debugf("[%s] Applying deactivate operation: %+v", anchoredOp)
 
 	if rm.Doc == nil {
 		return nil, errors.New("deactivate can only be applied to an existing document")
 	}
 
-	op, err := s.OperationParser.ParseDeactivateOperation(anchoredOp.OperationBuffer, true)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse deactive operation in anchor mode: %s", err.Error())
-	}
+	if parsed == nil {
+		op, signedDataModel, err := s.OperationParser.ParseDeactivateOperationWithSignedData(anchoredOp.OperationBuffer, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deactive operation in anchor mode: %s", err.Error())
+		}
 
-	signedDataModel, err := s.ParseSignedDataForDeactivate(op.SignedData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse signed data model while applying deactivate: %s", err.Error())
+		parsed = &model.ParsedOperation{Operation: op, DeactivateSignedData: signedDataModel}
 	}
 
+	op, signedDataModel := parsed.Operation, parsed.DeactivateSignedData
+
 	// verify signed did suffix against actual did suffix
 	if op.UniqueSuffix != signedDataModel.DidSuffix {
 		return nil, errors.New("did suffix doesn't match signed value")
 	}
 
 	// verify signature
-	_, err = internal.VerifyJWS(op.SignedData, signedDataModel.RecoveryKey)
+	err := s.verifiers.Verify(op.SignedData, signedDataModel.RecoveryKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check signature: %s", err.Error())
 	}
@@ -206,25 +259,26 @@ func (s *Applier) applyDeactivateOperation(anchoredOp *operation.AnchoredOperati
 	}, nil
 }
 
-func (s *Applier) applyRecoverOperation(anchoredOp *operation.AnchoredOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
+func (s *Applier) applyRecoverOperation(anchoredOp *operation.AnchoredOperation, parsed *model.ParsedOperation, rm *protocol.ResolutionModel) (*protocol.ResolutionModel, error) { //nolint:dupl
 	logger.Debugf("Applying recover operation: %+v", anchoredOp)
 
 	if rm.Doc == nil {
 		return nil, errors.New("recover can only be applied to an existing document")
 	}
 
-	op, err := s.OperationParser.ParseRecoverOperation(anchoredOp.OperationBuffer, true)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse recover operation in anchor mode: %s", err.Error())
-	}
+	if parsed == nil {
+		op, signedDataModel, err := s.OperationParser.ParseRecoverOperationWithSignedData(anchoredOp.OperationBuffer, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse recover operation in anchor mode: %s", err.Error())
+		}
 
-	signedDataModel, err := s.ParseSignedDataForRecover(op.SignedData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse signed data model while applying recover: %s", err.Error())
+		parsed = &model.ParsedOperation{Operation: op, RecoverSignedData: signedDataModel}
 	}
 
+	op, signedDataModel := parsed.Operation, parsed.RecoverSignedData
+
 	// verify signature
-	_, err = internal.VerifyJWS(op.SignedData, signedDataModel.RecoveryKey)
+	err := s.verifiers.Verify(op.SignedData, signedDataModel.RecoveryKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check signature: %s", err.Error())
 	}
@@ -25,6 +25,8 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/document"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
 	"github.com/trustbloc/sidetree-core-go/pkg/patch"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
@@ -58,11 +60,78 @@ var (
 		Patches:              []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
 	}
 
-	parser = operationparser.New(p)
+	parser = newParser(p)
 
 	dc = doccomposer.New()
 )
 
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
+		require.NotNil(t, applier)
+	})
+
+	t.Run("error - invalid protocol", func(t *testing.T) {
+		invalid := p
+		invalid.Patches = []string{"unknown"}
+
+		applier, err := New(invalid, parser, dc)
+		require.Error(t, err)
+		require.Nil(t, applier)
+		require.Contains(t, err.Error(), "invalid protocol: patch [unknown] is not supported")
+	})
+
+	t.Run("error - no verifier for key algorithm", func(t *testing.T) {
+		invalid := p
+		invalid.KeyAlgorithms = []string{"RSA"}
+
+		applier, err := New(invalid, parser, dc)
+		require.Error(t, err)
+		require.Nil(t, applier)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "key algorithm [RSA] is not supported")
+
+		// the verifier registry doesn't contain a verifier for the protocol's signature algorithms
+		applier, err = New(p, parser, dc, WithVerifierRegistry(verifier.New()))
+		require.Error(t, err)
+		require.Nil(t, applier)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+	})
+
+	t.Run("success - verifier registry", func(t *testing.T) {
+		recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+		require.NoError(t, err)
+
+		registry := verifier.New(
+			verifier.WithDefaultVerifiers(),
+			verifier.WithVerifier("ES256", "EC", "P-256", verifier.VerifierFunc(func(*jws.JWK, []byte, []byte) error {
+				return errors.New("injected verifier error")
+			})),
+		)
+
+		applier, err := New(p, parser, dc, WithVerifierRegistry(registry))
+		require.NoError(t, err)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, createOp.UniqueSuffix, 1)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(updateOp, rm)
+		require.Error(t, err)
+		require.Nil(t, rm)
+		require.Contains(t, err.Error(), "failed to check signature: injected verifier error")
+	})
+}
+
 func TestApplier_Apply(t *testing.T) {
 	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -71,7 +140,8 @@ func TestApplier_Apply(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("update is first operation error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		const uniqueSuffix = "uniqueSuffix"
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
@@ -84,7 +154,8 @@ func TestApplier_Apply(t *testing.T) {
 	})
 
 	t.Run("create is second operation error", func(t *testing.T) {
-		applier := New(p, parser, &mockDocComposer{})
+		applier, err := New(p, parser, &mockDocComposer{})
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -98,7 +169,8 @@ func TestApplier_Apply(t *testing.T) {
 	})
 
 	t.Run("apply recover to non existing document error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -113,7 +185,8 @@ func TestApplier_Apply(t *testing.T) {
 	})
 
 	t.Run("invalid operation type error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		doc, err := applier.Apply(&operation.AnchoredOperation{Type: "invalid"}, &protocol.ResolutionModel{Doc: make(document.Document)})
 		require.Error(t, err)
@@ -133,10 +206,12 @@ func TestApplier_Apply(t *testing.T) {
 		createOp.Delta = delta
 
 		anchoredOp := getAnchoredOperation(createOp)
-		err = store.Put(anchoredOp)
+		err = store.Put([]*operation.AnchoredOperation{anchoredOp})
 		require.Nil(t, err)
 
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
+
 		rm, err := applier.Apply(anchoredOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
 		require.Equal(t, make(document.Document), rm.Doc)
@@ -145,7 +220,8 @@ func TestApplier_Apply(t *testing.T) {
 	})
 
 	t.Run("error - apply patches (document composer) error", func(t *testing.T) {
-		applier := New(p, parser, &mockDocComposer{Err: errors.New("document composer error")})
+		applier, err := New(p, parser, &mockDocComposer{Err: errors.New("document composer error")})
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -158,6 +234,53 @@ func TestApplier_Apply(t *testing.T) {
 	})
 }
 
+func TestApplier_ApplyParsed(t *testing.T) {
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	applier, err := New(p, parser, dc)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+		require.NoError(t, err)
+
+		updateOp, _, err := getAnchoredUpdateOperation(updateKey, createOp.UniqueSuffix, 1)
+		require.NoError(t, err)
+
+		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, createOp.UniqueSuffix)
+		require.NoError(t, err)
+
+		rm := &protocol.ResolutionModel{}
+
+		for _, op := range []*operation.AnchoredOperation{createOp, updateOp, deactivateOp} {
+			parsed, err := parser.ParseAnchoredOperation(op)
+			require.NoError(t, err)
+
+			expected, err := applier.Apply(op, rm)
+			require.NoError(t, err)
+
+			rm, err = applier.ApplyParsed(parsed, rm)
+			require.NoError(t, err)
+			require.Equal(t, expected, rm)
+		}
+
+		require.Nil(t, rm.Doc)
+	})
+
+	t.Run("error - unsupported model", func(t *testing.T) {
+		rm, err := applier.ApplyParsed(&protocol.ParsedOperation{
+			AnchoredOperation: &operation.AnchoredOperation{Type: operation.TypeUpdate},
+			Model:             "model",
+		}, &protocol.ResolutionModel{})
+		require.EqualError(t, err, "unsupported parsed operation model [string]")
+		require.Nil(t, rm)
+	})
+}
+
 func TestUpdateDocument(t *testing.T) {
 	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, e)
@@ -171,7 +294,8 @@ func TestUpdateDocument(t *testing.T) {
 	uniqueSuffix := createOp.UniqueSuffix
 
 	t.Run("success", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -199,7 +323,8 @@ func TestUpdateDocument(t *testing.T) {
 	})
 
 	t.Run("error -  operation with reused next commitment", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -242,7 +367,8 @@ func TestUpdateDocument(t *testing.T) {
 	})
 
 	t.Run("missing signed data error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -262,7 +388,8 @@ func TestUpdateDocument(t *testing.T) {
 	})
 
 	t.Run("unmarshal signed data model error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -287,7 +414,8 @@ func TestUpdateDocument(t *testing.T) {
 	})
 
 	t.Run("invalid signature error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -312,7 +440,8 @@ func TestUpdateDocument(t *testing.T) {
 	})
 
 	t.Run("delta hash doesn't match delta error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -332,7 +461,8 @@ func TestUpdateDocument(t *testing.T) {
 	})
 
 	t.Run("error - document composer error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
 		require.NoError(t, err)
@@ -343,7 +473,8 @@ func TestUpdateDocument(t *testing.T) {
 		updateOp, _, err := getAnchoredUpdateOperation(updateKey, uniqueSuffix, 1)
 		require.NoError(t, err)
 
-		applier = New(p, parser, &mockDocComposer{Err: errors.New("document composer error")})
+		applier, err = New(p, parser, &mockDocComposer{Err: errors.New("document composer error")})
+		require.NoError(t, err)
 
 		rm, err = applier.Apply(updateOp, rm)
 		require.Error(t, err)
@@ -368,7 +499,8 @@ func TestDeactivate(t *testing.T) {
 	uniqueSuffix := createOp.UniqueSuffix
 
 	t.Run("success", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -385,7 +517,9 @@ func TestDeactivate(t *testing.T) {
 		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, uniqueSuffix)
 		require.NoError(t, err)
 
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
+
 		doc, err := applier.Apply(deactivateOp, &protocol.ResolutionModel{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "deactivate can only be applied to an existing document")
@@ -397,10 +531,12 @@ func TestDeactivate(t *testing.T) {
 
 		deactivateOp, err := getAnchoredDeactivateOperation(recoveryKey, dummyUniqueSuffix)
 		require.NoError(t, err)
-		err = store.Put(deactivateOp)
+		err = store.Put([]*operation.AnchoredOperation{deactivateOp})
+		require.NoError(t, err)
+
+		applier, err := New(p, parser, &mockDocComposer{})
 		require.NoError(t, err)
 
-		applier := New(p, parser, &mockDocComposer{})
 		doc, err := applier.Apply(deactivateOp, &protocol.ResolutionModel{})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "deactivate can only be applied to an existing document")
@@ -408,7 +544,8 @@ func TestDeactivate(t *testing.T) {
 	})
 
 	t.Run("missing signed data error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -427,7 +564,8 @@ func TestDeactivate(t *testing.T) {
 	})
 
 	t.Run("unmarshal signed data model error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -451,7 +589,8 @@ func TestDeactivate(t *testing.T) {
 	})
 
 	t.Run("invalid signature error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -473,7 +612,8 @@ func TestDeactivate(t *testing.T) {
 	})
 
 	t.Run("did suffix doesn't match signed value error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -500,6 +640,67 @@ func TestDeactivate(t *testing.T) {
 	})
 }
 
+func TestApplier_EllipticCurves(t *testing.T) {
+	pc := p
+	pc.SignatureAlgorithms = []string{"ES256", "ES384", "ES512"}
+	pc.KeyAlgorithms = []string{"P-256", "P-384", "P-521"}
+
+	ecParser := newParser(pc)
+
+	recoveryKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	updateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	createOp, err := getAnchoredCreateOperation(recoveryKey, updateKey)
+	require.NoError(t, err)
+
+	uniqueSuffix := createOp.UniqueSuffix
+
+	t.Run("success", func(t *testing.T) {
+		applier, err := New(pc, ecParser, dc)
+		require.NoError(t, err)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		updateOp, _, err := getUpdateOperationWithSigner(ecsigner.New(updateKey, "ES512", updateKeyID), updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.NoError(t, err)
+
+		didDoc := document.DidDocumentFromJSONLDObject(rm.Doc)
+		require.Equal(t, "special1", didDoc["test"])
+
+		deactivateOp, err := getDeactivateOperationWithSigner(ecsigner.New(recoveryKey, "ES384", ""), recoveryKey, uniqueSuffix)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(getAnchoredOperation(deactivateOp), rm)
+		require.NoError(t, err)
+		require.NotNil(t, rm)
+		require.Empty(t, rm.UpdateCommitment)
+		require.Empty(t, rm.RecoveryCommitment)
+	})
+
+	t.Run("error - algorithm doesn't match key curve", func(t *testing.T) {
+		applier, err := New(pc, ecParser, dc)
+		require.NoError(t, err)
+
+		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
+		require.NoError(t, err)
+
+		updateOp, _, err := getUpdateOperationWithSigner(ecsigner.New(updateKey, "ES256", updateKeyID), updateKey, uniqueSuffix, 1)
+		require.NoError(t, err)
+
+		rm, err = applier.Apply(getAnchoredOperation(updateOp), rm)
+		require.Error(t, err)
+		require.Nil(t, rm)
+		require.Contains(t, err.Error(), "no verifier for algorithm [ES256] and key [P-521]")
+	})
+}
+
 func TestRecover(t *testing.T) {
 	recoveryKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, e)
@@ -513,7 +714,8 @@ func TestRecover(t *testing.T) {
 	uniqueSuffix := createOp.UniqueSuffix
 
 	t.Run("success", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -539,7 +741,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("success - operation with invalid signature rejected", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -569,7 +772,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("success - operation with valid signature and invalid delta accepted", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -588,7 +792,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("missing signed data error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -607,7 +812,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("unmarshal signed data model error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -631,7 +837,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("invalid signature error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		rm, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -653,7 +860,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("delta hash doesn't match delta error", func(t *testing.T) {
-		applier := New(p, parser, dc)
+		applier, err := New(p, parser, dc)
+		require.NoError(t, err)
 
 		createResult, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -673,7 +881,8 @@ func TestRecover(t *testing.T) {
 	})
 
 	t.Run("error - document composer error", func(t *testing.T) {
-		applier := New(p, parser, &mockDocComposer{Err: errors.New("doc composer error")})
+		applier, err := New(p, parser, &mockDocComposer{Err: errors.New("doc composer error")})
+		require.NoError(t, err)
 
 		createResult, err := applier.Apply(createOp, &protocol.ResolutionModel{})
 		require.NoError(t, err)
@@ -723,6 +932,10 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 		return nil, nil, err
 	}
 
+	return getUpdateOperationWithPatch(s, privateKey, uniqueSuffix, jsonPatch)
+}
+
+func getUpdateOperationWithPatch(s client.Signer, privateKey *ecdsa.PrivateKey, uniqueSuffix string, p patch.Patch) (*model.Operation, *ecdsa.PrivateKey, error) {
 	nextUpdateKey, updateCommitment, err := generateKeyAndCommitment()
 	if err != nil {
 		return nil, nil, err
@@ -730,7 +943,7 @@ func getUpdateOperationWithSigner(s client.Signer, privateKey *ecdsa.PrivateKey,
 
 	delta := &model.DeltaModel{
 		UpdateCommitment: updateCommitment,
-		Patches:          []patch.Patch{jsonPatch},
+		Patches:          []patch.Patch{p},
 	}
 
 	deltaHash, err := docutil.CalculateModelMultihash(delta, sha2_256)
@@ -930,7 +1143,7 @@ func getDefaultStore(recoveryKey, updateKey *ecdsa.PrivateKey) (*mocks.MockOpera
 	}
 
 	// store default create operation
-	err = store.Put(createOp)
+	err = store.Put([]*operation.AnchoredOperation{createOp})
 	if err != nil {
 		panic(err)
 	}
@@ -1115,3 +1328,12 @@ func (m *mockDocComposer) ApplyPatches(doc document.Document, patches []patch.Pa
 
 	return make(document.Document), nil
 }
+
+func newParser(p protocol.Protocol) *operationparser.Parser {
+	parser, err := operationparser.New(p)
+	if err != nil {
+		panic(err)
+	}
+
+	return parser
+}
@@ -1,72 +1,133 @@
 package operationparser
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
 // GetRevealValue returns this operation reveal value.
 func (p *Parser) GetRevealValue(opBytes []byte) (*jws.JWK, error) {
-	// namespace is irrelevant in this case
-	op, err := p.ParseOperation("", opBytes)
+	parsed, err := p.parseOperationModel(opBytes, false)
 	if err != nil {
 		return nil, fmt.Errorf("get reveal value - parse operation error: %s", err.Error())
 	}
 
-	switch op.Type { //nolint:exhaustive
-	case operation.TypeUpdate:
-		signedDataModel, innerErr := p.ParseSignedDataForUpdate(op.SignedData)
-		if innerErr != nil {
-			return nil, fmt.Errorf("failed to parse signed data model for update: %s", innerErr.Error())
-		}
+	if parsed.Operation.Type == operation.TypeCreate {
+		return nil, fmt.Errorf("operation type '%s' not supported for getting operation reveal value", parsed.Operation.Type)
+	}
 
-		return signedDataModel.UpdateKey, nil
+	return getRevealValue(parsed), nil
+}
 
-	case operation.TypeDeactivate:
-		signedDataModel, innerErr := p.ParseSignedDataForDeactivate(op.SignedData)
-		if innerErr != nil {
-			return nil, fmt.Errorf("failed to parse signed data model for deactivate: %s", innerErr.Error())
-		}
+// GetCommitment returns next operation commitment.
+func (p *Parser) GetCommitment(opBytes []byte) (string, error) {
+	parsed, err := p.parseOperationModel(opBytes, false)
+	if err != nil {
+		return "", fmt.Errorf("get commitment - parse operation error: %s", err.Error())
+	}
 
-		return signedDataModel.RecoveryKey, nil
+	if parsed.Operation.Type == operation.TypeCreate {
+		return "", fmt.Errorf("operation type '%s' not supported for getting next operation commitment", parsed.Operation.Type)
+	}
 
-	case operation.TypeRecover:
-		signedDataModel, innerErr := p.ParseSignedDataForRecover(op.SignedData)
-		if innerErr != nil {
-			return nil, fmt.Errorf("failed to parse signed data model for recover: %s", innerErr.Error())
-		}
+	return getNextCommitment(parsed), nil
+}
+
+// ParseAnchoredOperation parses the anchored operation for resolution. Create operations are parsed in anchor mode
+// (the delta is validated when the operation is applied). Update, recover and deactivate operations are validated
+// the same way as by GetRevealValue and GetCommitment.
+func (p *Parser) ParseAnchoredOperation(anchoredOp *operation.AnchoredOperation) (*protocol.ParsedOperation, error) {
+	parsed, err := p.parseOperationModel(anchoredOp.OperationBuffer, true)
+	if err != nil {
+		return nil, fmt.Errorf("parse anchored operation: %s", err.Error())
+	}
 
-		return signedDataModel.RecoveryKey, nil
+	if parsed.Operation.Type != anchoredOp.Type {
+		return nil, fmt.Errorf("parse anchored operation: operation type [%s] doesn't match anchored operation type [%s]",
+			parsed.Operation.Type, anchoredOp.Type)
 	}
 
-	return nil, fmt.Errorf("operation type '%s' not supported for getting operation reveal value", op.Type)
+	return &protocol.ParsedOperation{
+		AnchoredOperation: anchoredOp,
+		RevealValue:       getRevealValue(parsed),
+		NextCommitment:    getNextCommitment(parsed),
+		Model:             parsed,
+	}, nil
 }
 
-// GetCommitment returns next operation commitment.
-func (p *Parser) GetCommitment(opBytes []byte) (string, error) {
-	// namespace is irrelevant in this case
-	op, err := p.ParseOperation("", opBytes)
+// parseOperationModel parses and validates the operation (the same way as ParseOperation does) and returns
+// the operation together with the signed data that was parsed during validation, so that the signed data
+// doesn't have to be parsed again. If anchorCreate is true then a create operation is parsed in anchor mode.
+func (p *Parser) parseOperationModel(opBytes []byte, anchorCreate bool) (*model.ParsedOperation, error) {
+	schema := &operationSchema{}
+	err := json.Unmarshal(opBytes, schema)
 	if err != nil {
-		return "", fmt.Errorf("get commitment - parse operation error: %s", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal operation buffer into operation schema: %s", err.Error())
 	}
 
-	switch op.Type { //nolint:exhaustive
+	switch schema.Operation {
+	case operation.TypeCreate:
+		op, err := p.ParseCreateOperation(opBytes, anchorCreate)
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.ParsedOperation{Operation: op}, nil
+
 	case operation.TypeUpdate:
-		return op.Delta.UpdateCommitment, nil
+		op, signedData, err := p.ParseUpdateOperationWithSignedData(opBytes, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.ParsedOperation{Operation: op, UpdateSignedData: signedData}, nil
 
 	case operation.TypeDeactivate:
-		return "", nil
+		op, signedData, err := p.ParseDeactivateOperationWithSignedData(opBytes, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.ParsedOperation{Operation: op, DeactivateSignedData: signedData}, nil
 
 	case operation.TypeRecover:
-		signedDataModel, innerErr := p.ParseSignedDataForRecover(op.SignedData)
-		if innerErr != nil {
-			return "", fmt.Errorf("failed to parse signed data model for recover: %s", innerErr.Error())
+		op, signedData, err := p.ParseRecoverOperationWithSignedData(opBytes, false)
+		if err != nil {
+			return nil, err
 		}
 
-		return signedDataModel.RecoveryCommitment, nil
+		return &model.ParsedOperation{Operation: op, RecoverSignedData: signedData}, nil
+
+	default:
+		return nil, fmt.Errorf("parse operation: operation type [%s] not supported", schema.Operation)
+	}
+}
+
+func getRevealValue(parsed *model.ParsedOperation) *jws.JWK {
+	switch {
+	case parsed.UpdateSignedData != nil:
+		return parsed.UpdateSignedData.UpdateKey
+	case parsed.RecoverSignedData != nil:
+		return parsed.RecoverSignedData.RecoveryKey
+	case parsed.DeactivateSignedData != nil:
+		return parsed.DeactivateSignedData.RecoveryKey
+	default:
+		return nil
 	}
+}
 
-	return "", fmt.Errorf("operation type '%s' not supported for getting next operation commitment", op.Type)
+func getNextCommitment(parsed *model.ParsedOperation) string {
+	switch {
+	case parsed.UpdateSignedData != nil:
+		return parsed.Operation.Delta.UpdateCommitment
+	case parsed.RecoverSignedData != nil:
+		return parsed.RecoverSignedData.RecoveryCommitment
+	default:
+		return ""
+	}
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
 	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
@@ -22,12 +23,14 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/util/ecsigner"
 	"github.com/trustbloc/sidetree-core-go/pkg/util/pubkey"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/client"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
 func TestParser_GetCommitment(t *testing.T) {
 	p := mocks.NewMockProtocolClient()
 
-	parser := New(p.Protocol)
+	parser, err := New(p.Protocol)
+	require.NoError(t, err)
 
 	recoveryKey, _, err := generateKeyAndCommitment(p.Protocol)
 	require.NoError(t, err)
@@ -102,7 +105,8 @@ func TestParser_GetCommitment(t *testing.T) {
 func TestParser_GetRevealValue(t *testing.T) {
 	p := mocks.NewMockProtocolClient()
 
-	parser := New(p.Protocol)
+	parser, err := New(p.Protocol)
+	require.NoError(t, err)
 
 	recoveryKey, _, err := generateKeyAndCommitment(p.Protocol)
 	require.NoError(t, err)
@@ -176,6 +180,98 @@ func TestParser_GetRevealValue(t *testing.T) {
 	})
 }
 
+func TestParser_ParseAnchoredOperation(t *testing.T) {
+	p := mocks.NewMockProtocolClient()
+
+	parser, err := New(p.Protocol)
+	require.NoError(t, err)
+
+	recoveryKey, _, err := generateKeyAndCommitment(p.Protocol)
+	require.NoError(t, err)
+
+	updateKey, _, err := generateKeyAndCommitment(p.Protocol)
+	require.NoError(t, err)
+
+	_, recoveryCommitment, err := generateKeyAndCommitment(p.Protocol)
+	require.NoError(t, err)
+
+	_, updateCommitment, err := generateKeyAndCommitment(p.Protocol)
+	require.NoError(t, err)
+
+	recoveryJWK, err := pubkey.GetPublicKeyJWK(&recoveryKey.PublicKey)
+	require.NoError(t, err)
+
+	updateJWK, err := pubkey.GetPublicKeyJWK(&updateKey.PublicKey)
+	require.NoError(t, err)
+
+	t.Run("success - create", func(t *testing.T) {
+		create, err := generateCreateRequest(recoveryCommitment, updateCommitment, parser.Protocol)
+		require.NoError(t, err)
+
+		anchoredOp := &operation.AnchoredOperation{Type: operation.TypeCreate, OperationBuffer: create}
+
+		op, err := parser.ParseAnchoredOperation(anchoredOp)
+		require.NoError(t, err)
+		require.Equal(t, anchoredOp, op.AnchoredOperation)
+		require.Nil(t, op.RevealValue)
+		require.Empty(t, op.NextCommitment)
+
+		parsed, ok := op.Model.(*model.ParsedOperation)
+		require.True(t, ok)
+		require.Equal(t, recoveryCommitment, parsed.Operation.SuffixData.RecoveryCommitment)
+	})
+
+	t.Run("success - update", func(t *testing.T) {
+		update, err := generateUpdateRequest(updateKey, updateCommitment, parser.Protocol)
+		require.NoError(t, err)
+
+		op, err := parser.ParseAnchoredOperation(&operation.AnchoredOperation{Type: operation.TypeUpdate, OperationBuffer: update})
+		require.NoError(t, err)
+		require.Equal(t, updateJWK, op.RevealValue)
+		require.Equal(t, updateCommitment, op.NextCommitment)
+
+		parsed, ok := op.Model.(*model.ParsedOperation)
+		require.True(t, ok)
+		require.NotNil(t, parsed.UpdateSignedData)
+	})
+
+	t.Run("success - recover", func(t *testing.T) {
+		recover, err := generateRecoverRequest(recoveryKey, recoveryCommitment, parser.Protocol)
+		require.NoError(t, err)
+
+		op, err := parser.ParseAnchoredOperation(&operation.AnchoredOperation{Type: operation.TypeRecover, OperationBuffer: recover})
+		require.NoError(t, err)
+		require.Equal(t, recoveryJWK, op.RevealValue)
+		require.Equal(t, recoveryCommitment, op.NextCommitment)
+	})
+
+	t.Run("success - deactivate", func(t *testing.T) {
+		deactivate, err := generateDeactivateRequest(recoveryKey)
+		require.NoError(t, err)
+
+		op, err := parser.ParseAnchoredOperation(&operation.AnchoredOperation{Type: operation.TypeDeactivate, OperationBuffer: deactivate})
+		require.NoError(t, err)
+		require.Equal(t, recoveryJWK, op.RevealValue)
+		require.Empty(t, op.NextCommitment)
+	})
+
+	t.Run("error - type mismatch", func(t *testing.T) {
+		deactivate, err := generateDeactivateRequest(recoveryKey)
+		require.NoError(t, err)
+
+		op, err := parser.ParseAnchoredOperation(&operation.AnchoredOperation{Type: operation.TypeRecover, OperationBuffer: deactivate})
+		require.EqualError(t, err, "parse anchored operation: operation type [deactivate] doesn't match anchored operation type [recover]")
+		require.Nil(t, op)
+	})
+
+	t.Run("error - parse operation fails", func(t *testing.T) {
+		op, err := parser.ParseAnchoredOperation(&operation.AnchoredOperation{Type: operation.TypeUpdate, OperationBuffer: []byte(`{"type":"other"}`)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse anchored operation")
+		require.Nil(t, op)
+	})
+}
+
 func generateRecoverRequest(recoveryKey *ecdsa.PrivateKey, commitment string, p protocol.Protocol) ([]byte, error) {
 	jwk, err := pubkey.GetPublicKeyJWK(&recoveryKey.PublicKey)
 	if err != nil {
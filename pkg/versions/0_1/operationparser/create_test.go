@@ -14,7 +14,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
-	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/commitment"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
@@ -25,12 +24,11 @@ import (
 const invalid = "invalid"
 
 func TestParseCreateOperation(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm: sha2_256,
-		Patches:            []string{"replace", "add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
-	}
+	p := newTestProtocol()
+	p.Patches = []string{"replace", "add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"}
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		request, err := getCreateRequestBytes()
@@ -141,11 +139,10 @@ func TestParseCreateOperation(t *testing.T) {
 }
 
 func TestValidateSuffixData(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm: sha2_256,
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("invalid patch data hash", func(t *testing.T) {
 		suffixData, err := getSuffixData()
@@ -168,12 +165,10 @@ func TestValidateSuffixData(t *testing.T) {
 }
 
 func TestValidateDelta(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm: sha2_256,
-		Patches:            []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("invalid next update commitment hash", func(t *testing.T) {
 		delta, err := getDelta()
@@ -198,9 +193,10 @@ func TestValidateDelta(t *testing.T) {
 }
 
 func TestValidateCreateRequest(t *testing.T) {
-	p := protocol.Protocol{}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		create, err := getCreateRequest()
@@ -262,8 +258,8 @@ func getDelta() (*model.DeltaModel, error) {
 
 func getSuffixData() (*model.SuffixDataModel, error) {
 	jwk := &jws.JWK{
-		Kty: "kty",
-		Crv: "crv",
+		Kty: "EC",
+		Crv: "P-256",
 		X:   "x",
 	}
 
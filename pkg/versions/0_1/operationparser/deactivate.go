@@ -17,18 +17,26 @@ import (
 
 // ParseDeactivateOperation will parse deactivate operation.
 func (p *Parser) ParseDeactivateOperation(request []byte, anchor bool) (*model.Operation, error) {
+	op, _, err := p.ParseDeactivateOperationWithSignedData(request, anchor)
+
+	return op, err
+}
+
+// ParseDeactivateOperationWithSignedData will parse deactivate operation and return it along with
+// the signed data model that was parsed during validation.
+func (p *Parser) ParseDeactivateOperationWithSignedData(request []byte, anchor bool) (*model.Operation, *model.DeactivateSignedDataModel, error) {
 	schema, err := p.parseDeactivateRequest(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	signedData, err := p.ParseSignedDataForDeactivate(schema.SignedData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if signedData.DidSuffix != schema.DidSuffix {
-		return nil, errors.New("signed did suffix mismatch for deactivate")
+		return nil, nil, errors.New("signed did suffix mismatch for deactivate")
 	}
 
 	return &model.Operation{
@@ -36,7 +44,7 @@ func (p *Parser) ParseDeactivateOperation(request []byte, anchor bool) (*model.O
 		OperationBuffer: request,
 		UniqueSuffix:    schema.DidSuffix,
 		SignedData:      schema.SignedData,
-	}, nil
+	}, signedData, nil
 }
 
 func (p *Parser) parseDeactivateRequest(payload []byte) (*model.DeactivateRequest, error) {
@@ -82,5 +90,13 @@ func (p *Parser) ParseSignedDataForDeactivate(compactJWS string) (*model.Deactiv
 		return nil, fmt.Errorf("signed data for deactivate: %s", err.Error())
 	}
 
+	if err := p.validateSignatureAlgorithm(jws, signedData.RecoveryKey); err != nil {
+		return nil, fmt.Errorf("signed data for deactivate: %s", err.Error())
+	}
+
+	if err := p.validateKeyID(jws, signedData.RecoveryKey); err != nil {
+		return nil, fmt.Errorf("signed data for deactivate: %s", err.Error())
+	}
+
 	return signedData, nil
 }
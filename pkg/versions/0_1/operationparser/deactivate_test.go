@@ -13,7 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
-	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
@@ -22,13 +21,10 @@ import (
 const sha2_256 = 18
 
 func TestParseDeactivateOperation(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm:  sha2_256,
-		SignatureAlgorithms: []string{"alg"},
-		KeyAlgorithms:       []string{"crv"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		payload, err := getDeactivateRequestBytes()
@@ -38,6 +34,15 @@ func TestParseDeactivateOperation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, operation.TypeDeactivate, op.Type)
 	})
+	t.Run("success - with signed data", func(t *testing.T) {
+		payload, err := getDeactivateRequestBytes()
+		require.NoError(t, err)
+
+		op, signedData, err := parser.ParseDeactivateOperationWithSignedData(payload, false)
+		require.NoError(t, err)
+		require.Equal(t, operation.TypeDeactivate, op.Type)
+		require.Equal(t, op.UniqueSuffix, signedData.DidSuffix)
+	})
 	t.Run("missing unique suffix", func(t *testing.T) {
 		schema, err := parser.ParseDeactivateOperation([]byte("{}"), false)
 		require.Error(t, err)
@@ -104,19 +109,22 @@ func TestParseDeactivateOperation(t *testing.T) {
 		require.Nil(t, op)
 	})
 	t.Run("error - key algorithm not supported", func(t *testing.T) {
-		p := protocol.Protocol{
-			MultihashAlgorithm:  sha2_256,
-			SignatureAlgorithms: []string{"alg"},
-			KeyAlgorithms:       []string{"other"},
-		}
-		parser := New(p)
+		p := newTestProtocol()
+		p.SignatureAlgorithms = []string{"ES256", "ES384"}
+		p.KeyAlgorithms = []string{"P-256", "P-384"}
+
+		parser, err := New(p)
+		require.NoError(t, err)
+
+		// New rejects ES256 without P-256 so the key algorithms are restricted after the parser is created
+		parser.Protocol.KeyAlgorithms = []string{"P-384"}
 
 		request, err := getDeactivateRequestBytes()
 		require.NoError(t, err)
 
 		op, err := parser.ParseDeactivateOperation(request, false)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "signed data for deactivate: key algorithm 'crv' is not in the allowed list [other]")
+		require.Contains(t, err.Error(), "signed data for deactivate: key algorithm 'P-256' is not in the allowed list [P-384]")
 		require.Nil(t, op)
 	})
 }
@@ -142,8 +150,8 @@ func getSignedDataForDeactivate() *model.DeactivateSignedDataModel {
 	return &model.DeactivateSignedDataModel{
 		DidSuffix: "did",
 		RecoveryKey: &jws.JWK{
-			Kty: "kty",
-			Crv: "crv",
+			Kty: "EC",
+			Crv: "P-256",
 			X:   "x",
 		},
 	}
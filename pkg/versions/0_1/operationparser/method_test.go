@@ -25,7 +25,8 @@ const (
 func TestParser_ParseDID(t *testing.T) {
 	p := mocks.NewMockProtocolClient()
 
-	parser := New(p.Protocol)
+	parser, err := New(p.Protocol)
+	require.NoError(t, err)
 
 	const testDID = "doc:method:abc"
 
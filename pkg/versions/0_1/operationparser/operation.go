@@ -13,19 +13,51 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
 // Parser is an operation parser.
 type Parser struct {
 	protocol.Protocol
+
+	verifiers *verifier.Registry
+}
+
+// Option is a parser instance option.
+type Option func(opts *Parser)
+
+// WithVerifierRegistry sets the registry of signature verifiers. The built-in verifiers are used by default.
+func WithVerifierRegistry(registry *verifier.Registry) Option {
+	return func(opts *Parser) {
+		opts.verifiers = registry
+	}
 }
 
-// New returns a new operation parser.
-func New(p protocol.Protocol) *Parser {
-	return &Parser{
-		Protocol: p,
+// New returns a new operation parser. An error is returned if the protocol parameters are not valid
+// or if no signature verifier is registered for one of the protocol's key algorithms.
+func New(p protocol.Protocol, opts ...Option) (*Parser, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	parser := &Parser{
+		Protocol:  p,
+		verifiers: verifier.New(verifier.WithDefaultVerifiers()),
 	}
+
+	// apply options
+	for _, opt := range opts {
+		opt(parser)
+	}
+
+	if err := p.ValidateVerifiers(parser.verifiers); err != nil {
+		return nil, err
+	}
+
+	parser.verifiers = parser.verifiers.Filter(p.SignatureAlgorithms, p.KeyAlgorithms)
+
+	return parser, nil
 }
 
 // Parse parses and validates operation.
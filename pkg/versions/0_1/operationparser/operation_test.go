@@ -8,24 +8,23 @@ package operationparser
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
 )
 
 const namespace = "did:sidetree"
 
 func TestGetOperation(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm:  sha2_256,
-		SignatureAlgorithms: []string{"alg"},
-		KeyAlgorithms:       []string{"crv"},
-		Patches:             []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("create", func(t *testing.T) {
 		operation, err := getCreateRequestBytes()
@@ -61,16 +60,19 @@ func TestGetOperation(t *testing.T) {
 	})
 	t.Run("operation parsing error", func(t *testing.T) {
 		// set-up invalid hash algorithm in protocol configuration
-		invalid := protocol.Protocol{
-			SignatureAlgorithms: []string{"not-used"},
-		}
+		invalid := newTestProtocol()
+		invalid.SignatureAlgorithms = []string{"ES384"}
+		invalid.KeyAlgorithms = []string{"P-384"}
+
+		parser, err := New(invalid)
+		require.NoError(t, err)
 
 		operation, err := getRecoverRequestBytes()
 		require.NoError(t, err)
 
-		op, err := New(invalid).Parse(namespace, operation)
+		op, err := parser.Parse(namespace, operation)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "recover: failed to parse signed data: algorithm 'alg' is not in the allowed list [not-used]")
+		require.Contains(t, err.Error(), "recover: failed to parse signed data: algorithm 'ES256' is not in the allowed list [ES384]")
 		require.Nil(t, op)
 	})
 	t.Run("unsupported operation type error", func(t *testing.T) {
@@ -88,6 +90,71 @@ func TestGetOperation(t *testing.T) {
 	})
 }
 
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		parser, err := New(newTestProtocol())
+		require.NoError(t, err)
+		require.NotNil(t, parser)
+	})
+
+	t.Run("error - invalid protocol", func(t *testing.T) {
+		p := newTestProtocol()
+		p.MaxOperationCount = 0
+
+		parser, err := New(p)
+		require.Error(t, err)
+		require.Nil(t, parser)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "maxOperationCount must be greater than 0")
+	})
+
+	t.Run("success - verifier registry", func(t *testing.T) {
+		p := newTestProtocol()
+		p.SignatureAlgorithms = []string{"PS256"}
+		p.KeyAlgorithms = []string{"RSA"}
+
+		registry := verifier.New(verifier.WithVerifier("PS256", "RSA", "",
+			verifier.VerifierFunc(func(*jws.JWK, []byte, []byte) error { return nil })))
+
+		parser, err := New(p, WithVerifierRegistry(registry))
+		require.NoError(t, err)
+		require.NotNil(t, parser)
+
+		rsaKey := &jws.JWK{Kty: "RSA", N: "n", E: "AQAB"}
+		require.NoError(t, parser.validateSigningKey(rsaKey, p.KeyAlgorithms))
+	})
+
+	t.Run("error - no verifier for key algorithm", func(t *testing.T) {
+		p := newTestProtocol()
+		p.KeyAlgorithms = []string{"P-256", "RSA"}
+
+		parser, err := New(p)
+		require.Error(t, err)
+		require.Nil(t, parser)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "key algorithm [RSA] is not supported by any of the signature algorithms [ES256]")
+
+		// the verifier for P-384 keys isn't used since ES384 isn't one of the protocol's signature algorithms
+		p.KeyAlgorithms = []string{"P-256", "P-384"}
+
+		parser, err = New(p)
+		require.Error(t, err)
+		require.Nil(t, parser)
+		require.Contains(t, err.Error(), "key algorithm [P-384] is not supported")
+	})
+
+	t.Run("error - no verifier for signature algorithm", func(t *testing.T) {
+		p := newTestProtocol()
+		p.SignatureAlgorithms = []string{"ES256", "PS256"}
+
+		parser, err := New(p)
+		require.Error(t, err)
+		require.Nil(t, parser)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "signature algorithm [PS256] is not supported for any of the key algorithms [P-256]")
+	})
+}
+
 func getUnsupportedRequest() []byte {
 	schema := &operationSchema{
 		Operation: "unsupported",
@@ -100,3 +167,20 @@ func getUnsupportedRequest() []byte {
 
 	return payload
 }
+
+// newTestProtocol returns valid protocol parameters for the parser tests.
+func newTestProtocol() protocol.Protocol {
+	return protocol.Protocol{
+		MultihashAlgorithm:   sha2_256,
+		HashAlgorithm:        5, // crypto code for sha256 hash function
+		MaxOperationCount:    10,
+		MaxOperationSize:     2000,
+		CompressionAlgorithm: "GZIP",
+		MaxAnchorFileSize:    1000000,
+		MaxMapFileSize:       1000000,
+		MaxChunkFileSize:     1000000,
+		SignatureAlgorithms:  []string{"ES256"},
+		KeyAlgorithms:        []string{"P-256"},
+		Patches:              []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
+	}
+}
@@ -16,25 +16,34 @@ import (
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
 	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/model"
 )
 
 // ParseRecoverOperation will parse recover operation.
 func (p *Parser) ParseRecoverOperation(request []byte, anchor bool) (*model.Operation, error) {
+	op, _, err := p.ParseRecoverOperationWithSignedData(request, anchor)
+
+	return op, err
+}
+
+// ParseRecoverOperationWithSignedData will parse recover operation and return it along with
+// the signed data model that was parsed during validation.
+func (p *Parser) ParseRecoverOperationWithSignedData(request []byte, anchor bool) (*model.Operation, *model.RecoverSignedDataModel, error) {
 	schema, err := p.parseRecoverRequest(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	_, err = p.ParseSignedDataForRecover(schema.SignedData)
+	signedData, err := p.ParseSignedDataForRecover(schema.SignedData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if !anchor {
 		err = p.ValidateDelta(schema.Delta)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -44,7 +53,7 @@ func (p *Parser) ParseRecoverOperation(request []byte, anchor bool) (*model.Oper
 		UniqueSuffix:    schema.DidSuffix,
 		Delta:           schema.Delta,
 		SignedData:      schema.SignedData,
-	}, nil
+	}, signedData, nil
 }
 
 func (p *Parser) parseRecoverRequest(payload []byte) (*model.RecoverRequest, error) {
@@ -78,6 +87,14 @@ func (p *Parser) ParseSignedDataForRecover(compactJWS string) (*model.RecoverSig
 		return nil, err
 	}
 
+	if err := p.validateSignatureAlgorithm(jws, schema.RecoveryKey); err != nil {
+		return nil, fmt.Errorf("signed data for recovery: %s", err.Error())
+	}
+
+	if err := p.validateKeyID(jws, schema.RecoveryKey); err != nil {
+		return nil, fmt.Errorf("signed data for recovery: %s", err.Error())
+	}
+
 	return schema, nil
 }
 
@@ -182,8 +199,43 @@ func (p *Parser) validateSigningKey(key *jws.JWK, allowedAlgorithms []string) er
 		return fmt.Errorf("signing key validation failed: %s", err.Error())
 	}
 
-	if !contains(allowedAlgorithms, key.Crv) {
-		return errors.Errorf("key algorithm '%s' is not in the allowed list %v", key.Crv, allowedAlgorithms)
+	keyAlg := verifier.KeyAlgorithm(key)
+	if !contains(allowedAlgorithms, keyAlg) {
+		return errors.Errorf("key algorithm '%s' is not in the allowed list %v", keyAlg, allowedAlgorithms)
+	}
+
+	return nil
+}
+
+// validateSignatureAlgorithm checks that a signature verifier is registered for the algorithm of the signed data
+// and the signing key (e.g. an ES256 signature cannot be verified with a P-384 key).
+func (p *Parser) validateSignatureAlgorithm(signedData *internal.JSONWebSignature, key *jws.JWK) error {
+	alg, _ := signedData.ProtectedHeaders.Algorithm()
+
+	_, err := p.verifiers.Get(alg, key)
+
+	return err
+}
+
+// validateKeyID checks that the key ID of the signed data, if present, is the JWK thumbprint of the signing key
+// if the protocol enables JWK thumbprints.
+func (p *Parser) validateKeyID(signedData *internal.JSONWebSignature, key *jws.JWK) error {
+	if !p.JWKThumbprints {
+		return nil
+	}
+
+	kid, ok := signedData.ProtectedHeaders.KeyID()
+	if !ok || kid == "" {
+		return nil
+	}
+
+	thumbprint, err := key.Thumbprint()
+	if err != nil {
+		return err
+	}
+
+	if kid != thumbprint {
+		return fmt.Errorf("kid [%s] is not the JWK thumbprint of the signing key", kid)
 	}
 
 	return nil
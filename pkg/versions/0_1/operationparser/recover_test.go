@@ -13,7 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
-	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	internal "github.com/trustbloc/sidetree-core-go/pkg/internal/jws"
 	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
@@ -27,14 +26,10 @@ const (
 )
 
 func TestParseRecoverOperation(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm:  sha2_256,
-		SignatureAlgorithms: []string{"alg"},
-		KeyAlgorithms:       []string{"crv"},
-		Patches:             []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		request, err := getRecoverRequestBytes()
@@ -44,6 +39,18 @@ func TestParseRecoverOperation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, operation.TypeRecover, op.Type)
 	})
+	t.Run("success - with signed data", func(t *testing.T) {
+		request, err := getRecoverRequestBytes()
+		require.NoError(t, err)
+
+		op, signedData, err := parser.ParseRecoverOperationWithSignedData(request, false)
+		require.NoError(t, err)
+		require.Equal(t, operation.TypeRecover, op.Type)
+
+		expected, err := parser.ParseSignedDataForRecover(op.SignedData)
+		require.NoError(t, err)
+		require.Equal(t, expected, signedData)
+	})
 	t.Run("parse recover request error", func(t *testing.T) {
 		schema, err := parser.ParseRecoverOperation([]byte(""), false)
 		require.Error(t, err)
@@ -142,12 +149,10 @@ func TestParseRecoverOperation(t *testing.T) {
 }
 
 func TestValidateSignedDataForRecovery(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm: sha2_256,
-		KeyAlgorithms:      []string{"crv"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("missing recovery key", func(t *testing.T) {
 		signed := getSignedDataForRecovery()
@@ -176,12 +181,10 @@ func TestValidateSignedDataForRecovery(t *testing.T) {
 func TestParseSignedData(t *testing.T) {
 	mockSigner := NewMockSigner()
 
-	p := protocol.Protocol{
-		MultihashAlgorithm:  sha2_256,
-		SignatureAlgorithms: []string{"alg"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		jwsSignature, err := internal.NewJWS(nil, nil, []byte("payload"), mockSigner)
@@ -231,25 +234,31 @@ func TestParseSignedData(t *testing.T) {
 		compactJWS, err := jwsSignature.SerializeCompact(false)
 		require.NoError(t, err)
 
-		parser := New(protocol.Protocol{SignatureAlgorithms: []string{"other"}})
+		p := newTestProtocol()
+		p.SignatureAlgorithms = []string{"ES384"}
+		p.KeyAlgorithms = []string{"P-384"}
+
+		parser, err := New(p)
+		require.NoError(t, err)
 
 		jws, err := parser.parseSignedData(compactJWS)
 		require.Error(t, err)
 		require.Nil(t, jws)
-		require.Contains(t, err.Error(), "failed to parse signed data: algorithm 'alg' is not in the allowed list [other]")
+		require.Contains(t, err.Error(), "failed to parse signed data: algorithm 'ES256' is not in the allowed list [ES384]")
 	})
 }
 
 func TestValidateSigningKey(t *testing.T) {
 	testJWK := &jws.JWK{
-		Kty: "kty",
-		Crv: "crv",
+		Kty: "EC",
+		Crv: "P-256",
 		X:   "x",
 	}
 
-	allowedAlgorithms := []string{"crv"}
+	allowedAlgorithms := []string{"P-256"}
 
-	parser := New(protocol.Protocol{})
+	parser, err := New(newTestProtocol())
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		err := parser.validateSigningKey(testJWK, allowedAlgorithms)
@@ -258,7 +267,7 @@ func TestValidateSigningKey(t *testing.T) {
 
 	t.Run("error - required info is missing (kty)", func(t *testing.T) {
 		err := parser.validateSigningKey(&jws.JWK{
-			Crv: "crv",
+			Crv: "P-256",
 			X:   "x",
 		}, allowedAlgorithms)
 		require.Error(t, err)
@@ -268,12 +277,13 @@ func TestValidateSigningKey(t *testing.T) {
 	t.Run("error - key algorithm not supported", func(t *testing.T) {
 		err := parser.validateSigningKey(testJWK, []string{"other"})
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "key algorithm 'crv' is not in the allowed list [other]")
+		require.Contains(t, err.Error(), "key algorithm 'P-256' is not in the allowed list [other]")
 	})
 }
 
 func TestValidateRecoverRequest(t *testing.T) {
-	parser := New(protocol.Protocol{})
+	parser, err := New(newTestProtocol())
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		recover, err := getDefaultRecoverRequest()
@@ -305,7 +315,8 @@ func TestValidateRecoverRequest(t *testing.T) {
 func TestValidateProtectedHeader(t *testing.T) {
 	algs := []string{"alg-1", "alg-2"}
 
-	parser := New(protocol.Protocol{})
+	parser, err := New(newTestProtocol())
+	require.NoError(t, err)
 
 	t.Run("success - kid can be empty", func(t *testing.T) {
 		protected := getHeaders("alg-1", "")
@@ -405,8 +416,8 @@ func getDefaultRecoverRequest() (*model.RecoverRequest, error) {
 func getSignedDataForRecovery() *model.RecoverSignedDataModel {
 	return &model.RecoverSignedDataModel{
 		RecoveryKey: &jws.JWK{
-			Kty: "kty",
-			Crv: "crv",
+			Kty: "EC",
+			Crv: "P-256",
 			X:   "x",
 		},
 		RecoveryCommitment: computeMultihash([]byte("recoveryReveal")),
@@ -433,7 +444,7 @@ type MockSigner struct {
 // New creates new mock signer (default to recovery signer).
 func NewMockSigner() *MockSigner {
 	headers := make(jws.Headers)
-	headers[jws.HeaderAlgorithm] = "alg"
+	headers[jws.HeaderAlgorithm] = "ES256"
 	headers[jws.HeaderKeyID] = "kid"
 
 	return &MockSigner{MockHeaders: headers, MockSignature: []byte("signature")}
@@ -18,20 +18,28 @@ import (
 
 // ParseUpdateOperation will parse update operation.
 func (p *Parser) ParseUpdateOperation(request []byte, anchor bool) (*model.Operation, error) {
+	op, _, err := p.ParseUpdateOperationWithSignedData(request, anchor)
+
+	return op, err
+}
+
+// ParseUpdateOperationWithSignedData will parse update operation and return it along with
+// the signed data model that was parsed during validation.
+func (p *Parser) ParseUpdateOperationWithSignedData(request []byte, anchor bool) (*model.Operation, *model.UpdateSignedDataModel, error) {
 	schema, err := p.parseUpdateRequest(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	_, err = p.ParseSignedDataForUpdate(schema.SignedData)
+	signedData, err := p.ParseSignedDataForUpdate(schema.SignedData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if !anchor {
 		err = p.ValidateDelta(schema.Delta)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -41,7 +49,7 @@ func (p *Parser) ParseUpdateOperation(request []byte, anchor bool) (*model.Opera
 		UniqueSuffix:    schema.DidSuffix,
 		Delta:           schema.Delta,
 		SignedData:      schema.SignedData,
-	}, nil
+	}, signedData, nil
 }
 
 func (p *Parser) parseUpdateRequest(payload []byte) (*model.UpdateRequest, error) {
@@ -75,6 +83,14 @@ func (p *Parser) ParseSignedDataForUpdate(compactJWS string) (*model.UpdateSigne
 		return nil, err
 	}
 
+	if err := p.validateSignatureAlgorithm(jws, schema.UpdateKey); err != nil {
+		return nil, fmt.Errorf("signed data for update: %s", err.Error())
+	}
+
+	if err := p.validateKeyID(jws, schema.UpdateKey); err != nil {
+		return nil, fmt.Errorf("signed data for update: %s", err.Error())
+	}
+
 	return schema, nil
 }
 
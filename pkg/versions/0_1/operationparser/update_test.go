@@ -13,7 +13,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
-	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
 	"github.com/trustbloc/sidetree-core-go/pkg/docutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/internal/signutil"
 	"github.com/trustbloc/sidetree-core-go/pkg/jws"
@@ -22,14 +21,10 @@ import (
 )
 
 func TestParseUpdateOperation(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm:  sha2_256,
-		SignatureAlgorithms: []string{"alg"},
-		KeyAlgorithms:       []string{"crv"},
-		Patches:             []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		payload, err := getUpdateRequestBytes()
@@ -39,6 +34,18 @@ func TestParseUpdateOperation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, operation.TypeUpdate, op.Type)
 	})
+	t.Run("success - with signed data", func(t *testing.T) {
+		payload, err := getUpdateRequestBytes()
+		require.NoError(t, err)
+
+		op, signedData, err := parser.ParseUpdateOperationWithSignedData(payload, false)
+		require.NoError(t, err)
+		require.Equal(t, operation.TypeUpdate, op.Type)
+
+		expected, err := parser.ParseSignedDataForUpdate(op.SignedData)
+		require.NoError(t, err)
+		require.Equal(t, expected, signedData)
+	})
 	t.Run("invalid json", func(t *testing.T) {
 		schema, err := parser.ParseUpdateOperation([]byte(""), false)
 		require.Error(t, err)
@@ -109,13 +116,10 @@ func TestParseUpdateOperation(t *testing.T) {
 }
 
 func TestParseSignedDataForUpdate(t *testing.T) {
-	p := protocol.Protocol{
-		MultihashAlgorithm:  sha2_256,
-		SignatureAlgorithms: []string{"alg"},
-		KeyAlgorithms:       []string{"crv"},
-	}
+	p := newTestProtocol()
 
-	parser := New(p)
+	parser, err := New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		req, err := getDefaultUpdateRequest()
@@ -156,16 +160,74 @@ func TestParseSignedDataForUpdate(t *testing.T) {
 		require.Nil(t, schema)
 		require.Contains(t, err.Error(), "invalid character")
 	})
+	t.Run("private key in update key", func(t *testing.T) {
+		payload, err := json.Marshal(map[string]interface{}{
+			"deltaHash": computeMultihash([]byte("delta")),
+			"updateKey": map[string]interface{}{"kty": "EC", "crv": "P-256", "x": "x", "y": "y", "d": "d"},
+		})
+		require.NoError(t, err)
+
+		compactJWS, err := signutil.SignPayload(payload, NewMockSigner())
+		require.NoError(t, err)
+
+		schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+		require.EqualError(t, err,
+			"signed data for update: signing key validation failed: JWK must not contain the private key (d)")
+		require.Nil(t, schema)
+	})
+	t.Run("JWK thumbprints - key ID", func(t *testing.T) {
+		p := newTestProtocol()
+		p.JWKThumbprints = true
+
+		parser, err := New(p)
+		require.NoError(t, err)
+
+		updateKey := &jws.JWK{Kty: "EC", Crv: "P-256", X: "x", Y: "y"}
+
+		thumbprint, err := updateKey.Thumbprint()
+		require.NoError(t, err)
+
+		payload, err := json.Marshal(model.UpdateSignedDataModel{
+			DeltaHash: computeMultihash([]byte("delta")),
+			UpdateKey: updateKey,
+		})
+		require.NoError(t, err)
+
+		for _, kid := range []string{thumbprint, ""} {
+			signer := NewMockSigner()
+			signer.MockHeaders[jws.HeaderKeyID] = kid
+
+			compactJWS, err := signutil.SignPayload(payload, signer)
+			require.NoError(t, err)
+
+			schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+			require.NoError(t, err)
+			require.Equal(t, updateKey, schema.UpdateKey)
+		}
+
+		compactJWS, err := signutil.SignPayload(payload, NewMockSigner())
+		require.NoError(t, err)
+
+		schema, err := parser.ParseSignedDataForUpdate(compactJWS)
+		require.EqualError(t, err, "signed data for update: kid [kid] is not the JWK thumbprint of the signing key")
+		require.Nil(t, schema)
+
+		// the key ID isn't checked if the protocol doesn't enable JWK thumbprints
+		parser, err = New(newTestProtocol())
+		require.NoError(t, err)
+
+		schema, err = parser.ParseSignedDataForUpdate(compactJWS)
+		require.NoError(t, err)
+		require.NotNil(t, schema)
+	})
 }
 
 func TestValidateUpdateDelta(t *testing.T) {
 	t.Run("invalid next update commitment hash", func(t *testing.T) {
-		p := protocol.Protocol{
-			MultihashAlgorithm: sha2_256,
-			Patches:            []string{"add-public-keys", "remove-public-keys", "add-services", "remove-services", "ietf-json-patch"},
-		}
+		p := newTestProtocol()
 
-		parser := New(p)
+		parser, err := New(p)
+		require.NoError(t, err)
 
 		delta, err := getUpdateDelta()
 		require.NoError(t, err)
@@ -179,7 +241,8 @@ func TestValidateUpdateDelta(t *testing.T) {
 }
 
 func TestValidateUpdateRequest(t *testing.T) {
-	parser := New(protocol.Protocol{})
+	parser, err := New(newTestProtocol())
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		update, err := getDefaultUpdateRequest()
@@ -215,8 +278,8 @@ func getUpdateRequest(delta *model.DeltaModel) (*model.UpdateRequest, error) {
 	}
 
 	updateKey := &jws.JWK{
-		Crv: "crv",
-		Kty: "kty",
+		Crv: "P-256",
+		Kty: "EC",
 		X:   "x",
 	}
 
@@ -273,7 +336,7 @@ func getTestPatch() string {
 }
 
 var testJWK = &jws.JWK{
-	Crv: "crv",
-	Kty: "kty",
+	Crv: "P-256",
+	Kty: "EC",
 	X:   "x",
 }
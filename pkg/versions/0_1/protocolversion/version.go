@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolversion
+
+import (
+	"errors"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/doccomposer"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/didvalidator"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationapplier"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/operationparser"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprocessor"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/txnprovider"
+)
+
+// VersionID is the version of the Sidetree protocol implemented by this package.
+const VersionID = "0.1"
+
+// Register registers the factory for this version of the protocol with the given registry.
+func Register(registry *protocol.Registry) error {
+	return registry.Register(VersionID, NewFactory())
+}
+
+// Factory creates protocol versions that implement version 0.1 of the Sidetree protocol.
+type Factory struct{}
+
+// NewFactory returns a new protocol version factory.
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// Create creates a new protocol version for the given protocol parameters.
+func (f *Factory) Create(p protocol.Protocol, providers *protocol.Providers) (protocol.Version, error) {
+	if providers == nil {
+		return nil, errors.New("missing providers")
+	}
+
+	if providers.CasClient == nil {
+		return nil, errors.New("missing CAS client")
+	}
+
+	if providers.CompressionProvider == nil {
+		return nil, errors.New("missing compression provider")
+	}
+
+	if providers.OperationStore == nil {
+		return nil, errors.New("missing operation store")
+	}
+
+	var parserOpts []operationparser.Option
+
+	var applierOpts []operationapplier.Option
+
+	if providers.VerifierRegistry != nil {
+		parserOpts = append(parserOpts, operationparser.WithVerifierRegistry(providers.VerifierRegistry))
+		applierOpts = append(applierOpts, operationapplier.WithVerifierRegistry(providers.VerifierRegistry))
+	}
+
+	parser, err := operationparser.New(p, parserOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	composer := doccomposer.New()
+
+	applier, err := operationapplier.New(p, parser, composer, applierOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := txnprovider.NewOperationProvider(p, parser, providers.CasClient, providers.CompressionProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := txnprovider.NewOperationHandler(p, providers.CasClient, providers.CompressionProvider, parser)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := providers.DocumentValidator
+	if validator == nil {
+		validator = didvalidator.New(providers.OperationStore)
+	}
+
+	return &Version{
+		version:  VersionID,
+		protocol: p,
+		txnProcessor: txnprocessor.New(&txnprocessor.Providers{
+			OpStore:                   providers.OperationStore,
+			OperationProtocolProvider: provider,
+		}),
+		operationParser:   parser,
+		operationApplier:  applier,
+		operationHandler:  handler,
+		operationProvider: provider,
+		documentComposer:  composer,
+		documentValidator: validator,
+	}, nil
+}
+
+// Version implements protocol.Version for version 0.1 of the Sidetree protocol.
+type Version struct {
+	version           string
+	protocol          protocol.Protocol
+	txnProcessor      protocol.TxnProcessor
+	operationParser   protocol.OperationParser
+	operationApplier  protocol.OperationApplier
+	operationHandler  protocol.OperationHandler
+	operationProvider protocol.OperationProvider
+	documentComposer  protocol.DocumentComposer
+	documentValidator protocol.DocumentValidator
+}
+
+// Version returns the protocol version.
+func (v *Version) Version() string {
+	return v.version
+}
+
+// Protocol returns the protocol parameters.
+func (v *Version) Protocol() protocol.Protocol {
+	return v.protocol
+}
+
+// TransactionProcessor returns the transaction processor.
+func (v *Version) TransactionProcessor() protocol.TxnProcessor {
+	return v.txnProcessor
+}
+
+// OperationParser returns the operation parser.
+func (v *Version) OperationParser() protocol.OperationParser {
+	return v.operationParser
+}
+
+// OperationApplier returns the operation applier.
+func (v *Version) OperationApplier() protocol.OperationApplier {
+	return v.operationApplier
+}
+
+// OperationHandler returns the operation handler.
+func (v *Version) OperationHandler() protocol.OperationHandler {
+	return v.operationHandler
+}
+
+// OperationProvider returns the operation provider.
+func (v *Version) OperationProvider() protocol.OperationProvider {
+	return v.operationProvider
+}
+
+// DocumentComposer returns the document composer.
+func (v *Version) DocumentComposer() protocol.DocumentComposer {
+	return v.documentComposer
+}
+
+// DocumentValidator returns the document validator.
+func (v *Version) DocumentValidator() protocol.DocumentValidator {
+	return v.documentValidator
+}
@@ -0,0 +1,122 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protocolversion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/compression"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws"
+	"github.com/trustbloc/sidetree-core-go/pkg/jws/verifier"
+	"github.com/trustbloc/sidetree-core-go/pkg/mocks"
+	"github.com/trustbloc/sidetree-core-go/pkg/versions/0_1/docvalidator/docvalidator"
+)
+
+func TestRegister(t *testing.T) {
+	registry := protocol.NewRegistry()
+
+	require.NoError(t, Register(registry))
+	require.Equal(t, []string{VersionID}, registry.Versions())
+
+	v, err := registry.CreateVersion(VersionID, mocks.NewMockProtocolClient().Protocol, newProviders())
+	require.NoError(t, err)
+	require.Equal(t, VersionID, v.Version())
+}
+
+func TestFactory_Create(t *testing.T) {
+	p := mocks.NewMockProtocolClient().Protocol
+
+	t.Run("success", func(t *testing.T) {
+		f := NewFactory()
+
+		v, err := f.Create(p, newProviders())
+		require.NoError(t, err)
+		require.Equal(t, VersionID, v.Version())
+		require.Equal(t, p, v.Protocol())
+		require.NotNil(t, v.TransactionProcessor())
+		require.NotNil(t, v.OperationParser())
+		require.NotNil(t, v.OperationApplier())
+		require.NotNil(t, v.OperationHandler())
+		require.NotNil(t, v.OperationProvider())
+		require.NotNil(t, v.DocumentComposer())
+		require.NotNil(t, v.DocumentValidator())
+	})
+
+	t.Run("success - custom document validator", func(t *testing.T) {
+		providers := newProviders()
+		validator := docvalidator.New(providers.OperationStore)
+		providers.DocumentValidator = validator
+
+		v, err := NewFactory().Create(p, providers)
+		require.NoError(t, err)
+		require.Equal(t, validator, v.DocumentValidator())
+	})
+
+	t.Run("success - custom verifier registry", func(t *testing.T) {
+		rsa := p
+		rsa.SignatureAlgorithms = []string{"PS256"}
+		rsa.KeyAlgorithms = []string{"RSA"}
+
+		providers := newProviders()
+		providers.VerifierRegistry = verifier.New(verifier.WithVerifier("PS256", "RSA", "",
+			verifier.VerifierFunc(func(*jws.JWK, []byte, []byte) error { return nil })))
+
+		v, err := NewFactory().Create(rsa, providers)
+		require.NoError(t, err)
+		require.NotNil(t, v)
+	})
+
+	t.Run("error - missing providers", func(t *testing.T) {
+		v, err := NewFactory().Create(p, nil)
+		require.EqualError(t, err, "missing providers")
+		require.Nil(t, v)
+
+		providers := newProviders()
+		providers.CasClient = nil
+
+		v, err = NewFactory().Create(p, providers)
+		require.EqualError(t, err, "missing CAS client")
+		require.Nil(t, v)
+
+		providers = newProviders()
+		providers.CompressionProvider = nil
+
+		v, err = NewFactory().Create(p, providers)
+		require.EqualError(t, err, "missing compression provider")
+		require.Nil(t, v)
+
+		providers = newProviders()
+		providers.OperationStore = nil
+
+		v, err = NewFactory().Create(p, providers)
+		require.EqualError(t, err, "missing operation store")
+		require.Nil(t, v)
+	})
+
+	t.Run("error - invalid protocol", func(t *testing.T) {
+		invalid := p
+		invalid.KeyAlgorithms = []string{"RSA"}
+
+		v, err := NewFactory().Create(invalid, newProviders())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, protocol.ErrInvalidProtocol))
+		require.Contains(t, err.Error(), "key algorithm [RSA] is not supported")
+		require.Nil(t, v)
+	})
+}
+
+func newProviders() *protocol.Providers {
+	return &protocol.Providers{
+		CasClient:           mocks.NewMockCasClient(nil),
+		CompressionProvider: compression.New(compression.WithDefaultAlgorithms()),
+		OperationStore:      mocks.NewMockOperationStore(nil),
+	}
+}
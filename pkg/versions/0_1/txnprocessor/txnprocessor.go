@@ -14,19 +14,15 @@ import (
 
 	"github.com/trustbloc/sidetree-core-go/pkg/api/operation"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/protocol"
+	"github.com/trustbloc/sidetree-core-go/pkg/api/store"
 	"github.com/trustbloc/sidetree-core-go/pkg/api/txn"
 )
 
 var logger = log.New("sidetree-core-observer")
 
-// OperationStore interface to access operation store.
-type OperationStore interface {
-	Put(ops []*operation.AnchoredOperation) error
-}
-
 // Providers contains the providers required by the TxnProcessor.
 type Providers struct {
-	OpStore                   OperationStore
+	OpStore                   store.OperationWriter
 	OperationProtocolProvider protocol.OperationProvider
 }
 
@@ -60,7 +56,7 @@ func (p *TxnProcessor) processTxnOperations(txnOps []*operation.AnchoredOperatio
 	batchSuffixes := make(map[string]bool)
 
 	var ops []*operation.AnchoredOperation
-	for _, op := range txnOps {
+	for index, op := range txnOps {
 		_, ok := batchSuffixes[op.UniqueSuffix]
 		if ok {
 			logger.Warnf("[%s] duplicate suffix[%s] found in transaction operations: discarding operation %v", sidetreeTxn.Namespace, op.UniqueSuffix, op)
@@ -68,7 +64,7 @@ func (p *TxnProcessor) processTxnOperations(txnOps []*operation.AnchoredOperatio
 			continue
 		}
 
-		updatedOp := updateAnchoredOperation(op, sidetreeTxn)
+		updatedOp := updateAnchoredOperation(op, uint(index), sidetreeTxn)
 
 		logger.Debugf("updated operation with blockchain time: %s", updatedOp.UniqueSuffix)
 		ops = append(ops, updatedOp)
@@ -84,13 +80,17 @@ func (p *TxnProcessor) processTxnOperations(txnOps []*operation.AnchoredOperatio
 	return nil
 }
 
-func updateAnchoredOperation(op *operation.AnchoredOperation, sidetreeTxn txn.SidetreeTxn) *operation.AnchoredOperation {
+func updateAnchoredOperation(op *operation.AnchoredOperation, index uint, sidetreeTxn txn.SidetreeTxn) *operation.AnchoredOperation {
 	//  The logical blockchain time that this operation was anchored on the blockchain
 	op.TransactionTime = sidetreeTxn.TransactionTime
 	// The transaction number of the transaction this operation was batched within
 	op.TransactionNumber = sidetreeTxn.TransactionNumber
+	// The position of this operation within the batch
+	op.OperationIndex = index
 	// The genesis time of the protocol that was used for this operation
 	op.ProtocolGenesisTime = sidetreeTxn.ProtocolGenesisTime
+	// The anchor string of the transaction this operation was batched within
+	op.AnchorString = sidetreeTxn.AnchorString
 
 	return op
 }
@@ -87,10 +87,12 @@ func TestProcessTxnOperations(t *testing.T) {
 
 func TestUpdateOperation(t *testing.T) {
 	t.Run("test success", func(t *testing.T) {
-		updatedOps := updateAnchoredOperation(&operation.AnchoredOperation{UniqueSuffix: "abc"},
-			txn.SidetreeTxn{TransactionTime: 20, TransactionNumber: 2})
+		updatedOps := updateAnchoredOperation(&operation.AnchoredOperation{UniqueSuffix: "abc"}, 3,
+			txn.SidetreeTxn{TransactionTime: 20, TransactionNumber: 2, AnchorString: anchorString})
 		require.Equal(t, uint64(20), updatedOps.TransactionTime)
 		require.Equal(t, uint64(2), updatedOps.TransactionNumber)
+		require.Equal(t, uint(3), updatedOps.OperationIndex)
+		require.Equal(t, anchorString, updatedOps.AnchorString)
 	})
 }
 
@@ -30,9 +30,13 @@ type OperationHandler struct {
 	cp       compressionProvider
 }
 
-// NewOperationHandler returns new operations handler.
-func NewOperationHandler(p protocol.Protocol, cas cas.Client, cp compressionProvider, parser OperationParser) *OperationHandler {
-	return &OperationHandler{cas: cas, protocol: p, cp: cp, parser: parser}
+// NewOperationHandler returns new operations handler. An error is returned if the protocol parameters are not valid.
+func NewOperationHandler(p protocol.Protocol, cas cas.Client, cp compressionProvider, parser OperationParser) (*OperationHandler, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &OperationHandler{cas: cas, protocol: p, cp: cp, parser: parser}, nil
 }
 
 // PrepareTxnFiles will create batch files(chunk, map, anchor) from batch operations,
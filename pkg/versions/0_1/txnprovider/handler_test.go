@@ -42,12 +42,26 @@ const (
 func TestNewOperationHandler(t *testing.T) {
 	protocol := mocks.NewMockProtocolClient().Protocol
 
-	handler := NewOperationHandler(
+	handler, err := NewOperationHandler(
 		protocol,
 		mocks.NewMockCasClient(nil),
 		compression.New(compression.WithDefaultAlgorithms()),
-		operationparser.New(protocol))
+		newParser(t, protocol))
+	require.NoError(t, err)
+
 	require.NotNil(t, handler)
+
+	invalid := protocol
+	invalid.CompressionAlgorithm = "invalid"
+
+	handler, err = NewOperationHandler(
+		invalid,
+		mocks.NewMockCasClient(nil),
+		compression.New(compression.WithDefaultAlgorithms()),
+		newParser(t, protocol))
+	require.Error(t, err)
+	require.Nil(t, handler)
+	require.Contains(t, err.Error(), "invalid protocol: compression algorithm [invalid] is not supported")
 }
 
 func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
@@ -63,11 +77,12 @@ func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		ops := getTestOperations(createOpsNum, updateOpsNum, deactivateOpsNum, recoverOpsNum)
 
-		handler := NewOperationHandler(
+		handler, err := NewOperationHandler(
 			protocol,
 			mocks.NewMockCasClient(nil),
 			compression,
-			operationparser.New(protocol))
+			newParser(t, protocol))
+		require.NoError(t, err)
 
 		anchorString, err := handler.PrepareTxnFiles(ops)
 		require.NoError(t, err)
@@ -123,11 +138,12 @@ func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
 	})
 
 	t.Run("error - no operations provided", func(t *testing.T) {
-		handler := NewOperationHandler(
+		handler, err := NewOperationHandler(
 			protocol,
 			mocks.NewMockCasClient(nil),
 			compression,
-			operationparser.New(protocol))
+			newParser(t, protocol))
+		require.NoError(t, err)
 
 		anchorString, err := handler.PrepareTxnFiles(nil)
 		require.Error(t, err)
@@ -136,11 +152,12 @@ func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
 	})
 
 	t.Run("error - parse operation fails", func(t *testing.T) {
-		handler := NewOperationHandler(
+		handler, err := NewOperationHandler(
 			protocol,
 			mocks.NewMockCasClient(nil),
 			compression,
-			operationparser.New(protocol))
+			newParser(t, protocol))
+		require.NoError(t, err)
 
 		op := &operation.QueuedOperation{
 			OperationBuffer: []byte(`{"key":"value"}`),
@@ -157,11 +174,12 @@ func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
 	t.Run("error - write to CAS error for chunk file", func(t *testing.T) {
 		ops := getTestOperations(createOpsNum, updateOpsNum, deactivateOpsNum, recoverOpsNum)
 
-		handler := NewOperationHandler(
+		handler, err := NewOperationHandler(
 			protocol,
 			mocks.NewMockCasClient(errors.New("CAS error")),
 			compression,
-			operationparser.New(protocol))
+			newParser(t, protocol))
+		require.NoError(t, err)
 
 		anchorString, err := handler.PrepareTxnFiles(ops)
 		require.Error(t, err)
@@ -172,11 +190,12 @@ func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
 	t.Run("error - write to CAS error for anchor file", func(t *testing.T) {
 		ops := getTestOperations(0, 0, deactivateOpsNum, 0)
 
-		handler := NewOperationHandler(
+		handler, err := NewOperationHandler(
 			protocol,
 			mocks.NewMockCasClient(errors.New("CAS error")),
 			compression,
-			operationparser.New(protocol))
+			newParser(t, protocol))
+		require.NoError(t, err)
 
 		anchorString, err := handler.PrepareTxnFiles(ops)
 		require.Error(t, err)
@@ -188,11 +207,12 @@ func TestOperationHandler_PrepareTxnFiles(t *testing.T) {
 func TestWriteModelToCAS(t *testing.T) {
 	protocol := mocks.NewMockProtocolClient().Protocol
 
-	handler := NewOperationHandler(
+	handler, err := NewOperationHandler(
 		protocol,
 		mocks.NewMockCasClient(nil),
 		compression.New(compression.WithDefaultAlgorithms()),
-		operationparser.New(protocol))
+		newParser(t, protocol))
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
 		address, err := handler.writeModelToCAS(&models.AnchorFile{}, "alias")
@@ -208,11 +228,12 @@ func TestWriteModelToCAS(t *testing.T) {
 	})
 
 	t.Run("error - CAS error", func(t *testing.T) {
-		handlerWithCASError := NewOperationHandler(
+		handlerWithCASError, err := NewOperationHandler(
 			protocol,
 			mocks.NewMockCasClient(errors.New("CAS error")),
 			compression.New(compression.WithDefaultAlgorithms()),
-			operationparser.New(protocol))
+			newParser(t, protocol))
+		require.NoError(t, err)
 
 		address, err := handlerWithCASError.writeModelToCAS(&models.AnchorFile{}, "alias")
 		require.Error(t, err)
@@ -222,19 +243,20 @@ func TestWriteModelToCAS(t *testing.T) {
 
 	t.Run("error - compression error", func(t *testing.T) {
 		pc := mocks.NewMockProtocolClient()
-		pc.Protocol.CompressionAlgorithm = "invalid"
 
-		handlerWithProtocolError := NewOperationHandler(
+		// compression provider without any algorithms
+		handlerWithCompressionError, err := NewOperationHandler(
 			pc.Protocol,
 			mocks.NewMockCasClient(nil),
-			compression.New(compression.WithDefaultAlgorithms()),
-			operationparser.New(pc.Protocol),
+			compression.New(),
+			newParser(t, pc.Protocol),
 		)
+		require.NoError(t, err)
 
-		address, err := handlerWithProtocolError.writeModelToCAS(&models.AnchorFile{}, "alias")
+		address, err := handlerWithCompressionError.writeModelToCAS(&models.AnchorFile{}, "alias")
 		require.Error(t, err)
 		require.Empty(t, address)
-		require.Contains(t, err.Error(), "compression algorithm 'invalid' not supported")
+		require.Contains(t, err.Error(), "compression algorithm 'GZIP' not supported")
 	})
 }
 
@@ -285,7 +307,10 @@ func generateOperation(num int, opType operation.Type) (*model.Operation, error)
 		panic(err)
 	}
 
-	parser := operationparser.New(cp.Protocol())
+	parser, err := operationparser.New(cp.Protocol())
+	if err != nil {
+		panic(err)
+	}
 
 	return parser.ParseOperation(defaultNS, op)
 }
@@ -405,7 +430,7 @@ func getTestPatch() (patch.Patch, error) {
 }
 
 var testJWK = &jws.JWK{
-	Kty: "kty",
+	Kty: "EC",
 	Crv: "P-256",
 	X:   "x",
 }
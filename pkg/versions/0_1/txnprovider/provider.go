@@ -46,14 +46,18 @@ type OperationParser interface {
 	ValidateDelta(delta *model.DeltaModel) error
 }
 
-// NewOperationProvider returns a new operation provider.
-func NewOperationProvider(p protocol.Protocol, parser OperationParser, cas DCAS, dp decompressionProvider) *OperationProvider {
+// NewOperationProvider returns a new operation provider. An error is returned if the protocol parameters are not valid.
+func NewOperationProvider(p protocol.Protocol, parser OperationParser, cas DCAS, dp decompressionProvider) (*OperationProvider, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &OperationProvider{
 		Protocol: p,
 		parser:   parser,
 		cas:      cas,
 		dp:       dp,
-	}
+	}, nil
 }
 
 // GetTxnOperations will read batch files(Chunk, map, anchor) and assemble batch operations from those files.
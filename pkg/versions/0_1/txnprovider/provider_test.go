@@ -32,13 +32,26 @@ const (
 func TestNewOperationProvider(t *testing.T) {
 	pc := mocks.NewMockProtocolClient()
 
-	handler := NewOperationProvider(
+	handler, err := NewOperationProvider(
 		pc.Protocol,
-		operationparser.New(pc.Protocol),
+		newParser(t, pc.Protocol),
 		mocks.NewMockCasClient(nil),
 		compression.New(compression.WithDefaultAlgorithms()))
+	require.NoError(t, err)
 
 	require.NotNil(t, handler)
+
+	invalid := pc.Protocol
+	invalid.MaxChunkFileSize = 0
+
+	handler, err = NewOperationProvider(
+		invalid,
+		newParser(t, pc.Protocol),
+		mocks.NewMockCasClient(nil),
+		compression.New(compression.WithDefaultAlgorithms()))
+	require.Error(t, err)
+	require.Nil(t, handler)
+	require.Contains(t, err.Error(), "invalid protocol: maxChunkFileSize must be greater than 0")
 }
 
 func TestHandler_GetTxnOperations(t *testing.T) {
@@ -48,12 +61,15 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 	const recoverOpsNum = 2
 
 	pc := mocks.NewMockProtocolClient()
-	parser := operationparser.New(pc.Protocol)
+	parser, err := operationparser.New(pc.Protocol)
+	require.NoError(t, err)
+
 	cp := compression.New(compression.WithDefaultAlgorithms())
 
 	t.Run("success", func(t *testing.T) {
 		cas := mocks.NewMockCasClient(nil)
-		handler := NewOperationHandler(pc.Protocol, cas, cp, operationparser.New(pc.Protocol))
+		handler, err := NewOperationHandler(pc.Protocol, cas, cp, newParser(t, pc.Protocol))
+		require.NoError(t, err)
 
 		ops := getTestOperations(createOpsNum, updateOpsNum, deactivateOpsNum, recoverOpsNum)
 
@@ -61,7 +77,8 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, anchorString)
 
-		provider := NewOperationProvider(pc.Protocol, parser, cas, cp)
+		provider, err := NewOperationProvider(pc.Protocol, parser, cas, cp)
+		require.NoError(t, err)
 
 		txnOps, err := provider.GetTxnOperations(&txn.SidetreeTxn{
 			Namespace:         defaultNS,
@@ -76,7 +93,8 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 
 	t.Run("error - number of operations doesn't match", func(t *testing.T) {
 		cas := mocks.NewMockCasClient(nil)
-		handler := NewOperationHandler(pc.Protocol, cas, cp, operationparser.New(pc.Protocol))
+		handler, err := NewOperationHandler(pc.Protocol, cas, cp, newParser(t, pc.Protocol))
+		require.NoError(t, err)
 
 		ops := getTestOperations(createOpsNum, updateOpsNum, deactivateOpsNum, recoverOpsNum)
 
@@ -91,7 +109,8 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 		ad.NumberOfOperations = 7
 		anchorString = ad.GetAnchorString()
 
-		provider := NewOperationProvider(mocks.NewMockProtocolClient().Protocol, operationparser.New(pc.Protocol), cas, cp)
+		provider, err := NewOperationProvider(mocks.NewMockProtocolClient().Protocol, newParser(t, pc.Protocol), cas, cp)
+		require.NoError(t, err)
 
 		txnOps, err := provider.GetTxnOperations(&txn.SidetreeTxn{
 			Namespace:         defaultNS,
@@ -107,7 +126,8 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 
 	t.Run("error - read from CAS error", func(t *testing.T) {
 		protocolClient := mocks.NewMockProtocolClient()
-		handler := NewOperationProvider(protocolClient.Protocol, operationparser.New(protocolClient.Protocol), mocks.NewMockCasClient(errors.New("CAS error")), cp)
+		handler, err := NewOperationProvider(protocolClient.Protocol, newParser(t, protocolClient.Protocol), mocks.NewMockCasClient(errors.New("CAS error")), cp)
+		require.NoError(t, err)
 
 		txnOps, err := handler.GetTxnOperations(&txn.SidetreeTxn{
 			Namespace:         defaultNS,
@@ -123,7 +143,8 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 
 	t.Run("error - parse anchor operations error", func(t *testing.T) {
 		cas := mocks.NewMockCasClient(nil)
-		handler := NewOperationHandler(pc.Protocol, cas, cp, operationparser.New(pc.Protocol))
+		handler, err := NewOperationHandler(pc.Protocol, cas, cp, newParser(t, pc.Protocol))
+		require.NoError(t, err)
 
 		ops := getTestOperations(createOpsNum, updateOpsNum, deactivateOpsNum, recoverOpsNum)
 
@@ -134,7 +155,11 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 		invalid := mocks.NewMockProtocolClient().Protocol
 		invalid.MultihashAlgorithm = 55
 
-		provider := NewOperationProvider(invalid, operationparser.New(invalid), cas, cp)
+		// bypass protocol validation so that the parser fails at runtime
+		parser := &operationparser.Parser{Protocol: invalid}
+
+		provider, err := NewOperationProvider(pc.Protocol, parser, cas, cp)
+		require.NoError(t, err)
 
 		txnOps, err := provider.GetTxnOperations(&txn.SidetreeTxn{
 			Namespace:         mocks.DefaultNS,
@@ -145,12 +170,13 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 
 		require.Error(t, err)
 		require.Nil(t, txnOps)
-		require.Contains(t, err.Error(), "parse anchor operations: algorithm not supported")
+		require.Contains(t, err.Error(), "parse anchor operations: next recovery commitment hash is not computed with the required supported hash algorithm: 55")
 	})
 
 	t.Run("error - parse anchor data error", func(t *testing.T) {
 		p := mocks.NewMockProtocolClient().Protocol
-		provider := NewOperationProvider(p, operationparser.New(p), mocks.NewMockCasClient(nil), cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), mocks.NewMockCasClient(nil), cp)
+		require.NoError(t, err)
 
 		txnOps, err := provider.GetTxnOperations(&txn.SidetreeTxn{
 			AnchorString:      "abc.anchor",
@@ -170,14 +196,16 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 		ops = append(ops, generateOperations(deactivateOpsNum, operation.TypeDeactivate)...)
 
 		cas := mocks.NewMockCasClient(nil)
-		handler := NewOperationHandler(pc.Protocol, cas, cp, operationparser.New(pc.Protocol))
+		handler, err := NewOperationHandler(pc.Protocol, cas, cp, newParser(t, pc.Protocol))
+		require.NoError(t, err)
 
 		anchorString, err := handler.PrepareTxnFiles(ops)
 		require.NoError(t, err)
 		require.NotEmpty(t, anchorString)
 
 		p := mocks.NewMockProtocolClient().Protocol
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		txnOps, err := provider.GetTxnOperations(&txn.SidetreeTxn{
 			Namespace:         defaultNS,
@@ -193,7 +221,8 @@ func TestHandler_GetTxnOperations(t *testing.T) {
 
 func TestHandler_GetAnchorFile(t *testing.T) {
 	cp := compression.New(compression.WithDefaultAlgorithms())
-	p := protocol.Protocol{MaxAnchorFileSize: maxFileSize, CompressionAlgorithm: compressionAlgorithm}
+	p := newTestProtocol()
+	p.MaxAnchorFileSize = maxFileSize
 
 	cas := mocks.NewMockCasClient(nil)
 	content, err := cp.Compress(compressionAlgorithm, []byte("{}"))
@@ -201,10 +230,12 @@ func TestHandler_GetAnchorFile(t *testing.T) {
 	address, err := cas.Write(content)
 	require.NoError(t, err)
 
-	parser := operationparser.New(p)
+	parser, err := operationparser.New(p)
+	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
-		provider := NewOperationProvider(p, parser, cas, cp)
+		provider, err := NewOperationProvider(p, parser, cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.getAnchorFile(address)
 		require.NoError(t, err)
@@ -212,7 +243,11 @@ func TestHandler_GetAnchorFile(t *testing.T) {
 	})
 
 	t.Run("error - anchor file exceeds maximum size", func(t *testing.T) {
-		provider := NewOperationProvider(protocol.Protocol{MaxAnchorFileSize: 15, CompressionAlgorithm: compressionAlgorithm}, parser, cas, cp)
+		lowMaxFileSize := newTestProtocol()
+		lowMaxFileSize.MaxAnchorFileSize = 15
+
+		provider, err := NewOperationProvider(lowMaxFileSize, parser, cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.getAnchorFile(address)
 		require.Error(t, err)
@@ -226,7 +261,9 @@ func TestHandler_GetAnchorFile(t *testing.T) {
 		require.NoError(t, err)
 		address, err := cas.Write(content)
 
-		provider := NewOperationProvider(p, parser, cas, cp)
+		provider, err := NewOperationProvider(p, parser, cas, cp)
+		require.NoError(t, err)
+
 		file, err := provider.getAnchorFile(address)
 		require.Error(t, err)
 		require.Nil(t, file)
@@ -236,7 +273,8 @@ func TestHandler_GetAnchorFile(t *testing.T) {
 
 func TestHandler_GetMapFile(t *testing.T) {
 	cp := compression.New(compression.WithDefaultAlgorithms())
-	p := protocol.Protocol{MaxMapFileSize: maxFileSize, CompressionAlgorithm: compressionAlgorithm}
+	p := newTestProtocol()
+	p.MaxMapFileSize = maxFileSize
 
 	cas := mocks.NewMockCasClient(nil)
 	content, err := cp.Compress(compressionAlgorithm, []byte("{}"))
@@ -245,7 +283,8 @@ func TestHandler_GetMapFile(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.getMapFile(address)
 		require.NoError(t, err)
@@ -253,9 +292,13 @@ func TestHandler_GetMapFile(t *testing.T) {
 	})
 
 	t.Run("error - map file exceeds maximum size", func(t *testing.T) {
-		lowMaxFileSize := protocol.Protocol{MaxMapFileSize: 5, CompressionAlgorithm: compressionAlgorithm}
-		parser := operationparser.New(lowMaxFileSize)
-		provider := NewOperationProvider(lowMaxFileSize, parser, cas, cp)
+		lowMaxFileSize := newTestProtocol()
+		lowMaxFileSize.MaxMapFileSize = 5
+		parser, err := operationparser.New(lowMaxFileSize)
+		require.NoError(t, err)
+
+		provider, err := NewOperationProvider(lowMaxFileSize, parser, cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.getMapFile(address)
 		require.Error(t, err)
@@ -269,8 +312,12 @@ func TestHandler_GetMapFile(t *testing.T) {
 		require.NoError(t, err)
 		address, err := cas.Write(content)
 
-		parser := operationparser.New(p)
-		provider := NewOperationProvider(p, parser, cas, cp)
+		parser, err := operationparser.New(p)
+		require.NoError(t, err)
+
+		provider, err := NewOperationProvider(p, parser, cas, cp)
+		require.NoError(t, err)
+
 		file, err := provider.getMapFile(address)
 		require.Error(t, err)
 		require.Nil(t, file)
@@ -280,7 +327,8 @@ func TestHandler_GetMapFile(t *testing.T) {
 
 func TestHandler_GetChunkFile(t *testing.T) {
 	cp := compression.New(compression.WithDefaultAlgorithms())
-	p := protocol.Protocol{MaxChunkFileSize: maxFileSize, CompressionAlgorithm: compressionAlgorithm}
+	p := newTestProtocol()
+	p.MaxChunkFileSize = maxFileSize
 
 	cas := mocks.NewMockCasClient(nil)
 	content, err := cp.Compress(compressionAlgorithm, []byte("{}"))
@@ -289,7 +337,8 @@ func TestHandler_GetChunkFile(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.getChunkFile(address)
 		require.NoError(t, err)
@@ -297,8 +346,10 @@ func TestHandler_GetChunkFile(t *testing.T) {
 	})
 
 	t.Run("error - chunk file exceeds maximum size", func(t *testing.T) {
-		lowMaxFileSize := protocol.Protocol{MaxChunkFileSize: 10, CompressionAlgorithm: compressionAlgorithm}
-		provider := NewOperationProvider(lowMaxFileSize, operationparser.New(p), cas, cp)
+		lowMaxFileSize := newTestProtocol()
+		lowMaxFileSize.MaxChunkFileSize = 10
+		provider, err := NewOperationProvider(lowMaxFileSize, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.getChunkFile(address)
 		require.Error(t, err)
@@ -311,7 +362,9 @@ func TestHandler_GetChunkFile(t *testing.T) {
 		require.NoError(t, err)
 		address, err := cas.Write(content)
 
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
+
 		file, err := provider.getChunkFile(address)
 		require.Error(t, err)
 		require.Nil(t, file)
@@ -321,7 +374,8 @@ func TestHandler_GetChunkFile(t *testing.T) {
 
 func TestHandler_readFromCAS(t *testing.T) {
 	cp := compression.New(compression.WithDefaultAlgorithms())
-	p := protocol.Protocol{MaxChunkFileSize: maxFileSize, CompressionAlgorithm: compressionAlgorithm}
+	p := newTestProtocol()
+	p.MaxChunkFileSize = maxFileSize
 
 	cas := mocks.NewMockCasClient(nil)
 	content, err := cp.Compress(compressionAlgorithm, []byte("{}"))
@@ -330,7 +384,8 @@ func TestHandler_readFromCAS(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("success", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.readFromCAS(address, compressionAlgorithm, maxFileSize)
 		require.NoError(t, err)
@@ -338,7 +393,8 @@ func TestHandler_readFromCAS(t *testing.T) {
 	})
 
 	t.Run("error - read from CAS error", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), mocks.NewMockCasClient(errors.New("CAS error")), cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), mocks.NewMockCasClient(errors.New("CAS error")), cp)
+		require.NoError(t, err)
 
 		file, err := provider.getChunkFile("address")
 		require.Error(t, err)
@@ -347,7 +403,8 @@ func TestHandler_readFromCAS(t *testing.T) {
 	})
 
 	t.Run("error - content exceeds maximum size", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.readFromCAS(address, compressionAlgorithm, 20)
 		require.Error(t, err)
@@ -356,7 +413,8 @@ func TestHandler_readFromCAS(t *testing.T) {
 	})
 
 	t.Run("error - decompression error", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), cas, cp)
+		provider, err := NewOperationProvider(p, newParser(t, p), cas, cp)
+		require.NoError(t, err)
 
 		file, err := provider.readFromCAS(address, "alg", maxFileSize)
 		require.Error(t, err)
@@ -369,7 +427,8 @@ func TestHandler_assembleBatchOperations(t *testing.T) {
 	p := newMockProtocolClient().Protocol
 
 	t.Run("success", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), nil, nil)
+		provider, err := NewOperationProvider(p, newParser(t, p), nil, nil)
+		require.NoError(t, err)
 
 		createOp, err := generateOperation(1, operation.TypeCreate)
 		require.NoError(t, err)
@@ -403,7 +462,8 @@ func TestHandler_assembleBatchOperations(t *testing.T) {
 	})
 
 	t.Run("error - anchor, map, chunk file operation number mismatch", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), nil, nil)
+		provider, err := NewOperationProvider(p, newParser(t, p), nil, nil)
+		require.NoError(t, err)
 
 		createOp, err := generateOperation(1, operation.TypeCreate)
 		require.NoError(t, err)
@@ -442,7 +502,8 @@ func TestHandler_assembleBatchOperations(t *testing.T) {
 	})
 
 	t.Run("error - duplicate operations found in anchor/map files", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), nil, nil)
+		provider, err := NewOperationProvider(p, newParser(t, p), nil, nil)
+		require.NoError(t, err)
 
 		createOp, err := generateOperation(1, operation.TypeCreate)
 		require.NoError(t, err)
@@ -484,7 +545,8 @@ func TestHandler_assembleBatchOperations(t *testing.T) {
 	})
 
 	t.Run("error - invalid delta", func(t *testing.T) {
-		provider := NewOperationProvider(p, operationparser.New(p), nil, nil)
+		provider, err := NewOperationProvider(p, newParser(t, p), nil, nil)
+		require.NoError(t, err)
 
 		createOp, err := generateOperation(1, operation.TypeCreate)
 		require.NoError(t, err)
@@ -511,7 +573,11 @@ func TestHandler_assembleBatchOperations(t *testing.T) {
 
 func newMockProtocolClient() *mocks.MockProtocolClient {
 	pc := mocks.NewMockProtocolClient()
-	parser := operationparser.New(pc.Protocol)
+	parser, err := operationparser.New(pc.Protocol)
+	if err != nil {
+		panic(err)
+	}
+
 	dc := doccomposer.New()
 
 	pv := pc.CurrentVersion
@@ -520,3 +586,17 @@ func newMockProtocolClient() *mocks.MockProtocolClient {
 
 	return pc
 }
+
+func newTestProtocol() protocol.Protocol {
+	p := mocks.NewMockProtocolClient().Protocol
+	p.CompressionAlgorithm = compressionAlgorithm
+
+	return p
+}
+
+func newParser(t *testing.T, p protocol.Protocol) *operationparser.Parser {
+	parser, err := operationparser.New(p)
+	require.NoError(t, err)
+
+	return parser
+}